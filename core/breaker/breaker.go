@@ -1,10 +1,13 @@
 package breaker
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/tal-tech/go-zero/core/mathx"
 	"github.com/tal-tech/go-zero/core/proc"
@@ -18,6 +21,12 @@ const (
 	timeFormat        = "15:04:05"
 )
 
+const (
+	modeNormal int32 = iota
+	modeForceOpen
+	modeForceClose
+)
+
 // ErrServiceUnavailable is returned when the Breaker state is open.
 var ErrServiceUnavailable = errors.New("circuit breaker is open")
 
@@ -61,6 +70,17 @@ type (
 		// and causes the same panic again.
 		// acceptable checks if it's a successful call, even if the err is not nil.
 		DoWithFallbackAcceptable(req func() error, fallback func(err error) error, acceptable Acceptable) error
+
+		// ForceOpen forces the Breaker open, all requests are rejected
+		// until ForceClose or Reset is called.
+		ForceOpen()
+
+		// ForceClose forces the Breaker closed, all requests pass through
+		// without being counted, until ForceOpen or Reset is called.
+		ForceClose()
+
+		// Reset clears the forced state and the collected stats of the Breaker.
+		Reset()
 	}
 
 	// Option defines the method to customize a Breaker.
@@ -79,9 +99,19 @@ type (
 		Reject()
 	}
 
+	// a stateChangeFunc is called by the internal breakers on state transitions.
+	stateChangeFunc func(prev, current State, stats StateStats)
+
+	// a strategy builds the internal breaker implementation.
+	strategy func(notify stateChangeFunc) internalThrottle
+
 	circuitBreaker struct {
-		name string
-		throttle
+		name       string
+		mode       int32
+		listeners  []StateChangeListener
+		classifier Acceptable
+		strategy   strategy
+		throttle   atomic.Value // of type loggedThrottle
 	}
 
 	internalThrottle interface {
@@ -98,43 +128,112 @@ type (
 // NewBreaker returns a Breaker object.
 // opts can be used to customize the Breaker.
 func NewBreaker(opts ...Option) Breaker {
-	var b circuitBreaker
+	b := new(circuitBreaker)
 	for _, opt := range opts {
-		opt(&b)
+		opt(b)
 	}
 	if len(b.name) == 0 {
 		b.name = stringx.Rand()
 	}
-	b.throttle = newLoggedThrottle(b.name, newGoogleBreaker())
+	if b.classifier == nil {
+		b.classifier = defaultClassifier
+	}
+	if b.strategy == nil {
+		b.strategy = googleStrategy
+	}
+	b.throttle.Store(b.newThrottle())
+	metricState.Set(float64(StateClosed), b.name)
 
-	return &b
+	return b
 }
 
 func (cb *circuitBreaker) Allow() (Promise, error) {
-	return cb.throttle.allow()
+	switch atomic.LoadInt32(&cb.mode) {
+	case modeForceOpen:
+		return nil, ErrServiceUnavailable
+	case modeForceClose:
+		return nopPromise{}, nil
+	default:
+		return cb.loadThrottle().allow()
+	}
 }
 
 func (cb *circuitBreaker) Do(req func() error) error {
-	return cb.throttle.doReq(req, nil, defaultAcceptable)
+	return cb.doReq(req, nil, defaultAcceptable)
 }
 
 func (cb *circuitBreaker) DoWithAcceptable(req func() error, acceptable Acceptable) error {
-	return cb.throttle.doReq(req, nil, acceptable)
+	return cb.doReq(req, nil, acceptable)
 }
 
 func (cb *circuitBreaker) DoWithFallback(req func() error, fallback func(err error) error) error {
-	return cb.throttle.doReq(req, fallback, defaultAcceptable)
+	return cb.doReq(req, fallback, defaultAcceptable)
 }
 
 func (cb *circuitBreaker) DoWithFallbackAcceptable(req func() error, fallback func(err error) error,
 	acceptable Acceptable) error {
-	return cb.throttle.doReq(req, fallback, acceptable)
+	return cb.doReq(req, fallback, acceptable)
+}
+
+func (cb *circuitBreaker) ForceOpen() {
+	atomic.StoreInt32(&cb.mode, modeForceOpen)
+}
+
+func (cb *circuitBreaker) ForceClose() {
+	atomic.StoreInt32(&cb.mode, modeForceClose)
 }
 
 func (cb *circuitBreaker) Name() string {
 	return cb.name
 }
 
+func (cb *circuitBreaker) Reset() {
+	cb.throttle.Store(cb.newThrottle())
+	atomic.StoreInt32(&cb.mode, modeNormal)
+}
+
+func (cb *circuitBreaker) doReq(req func() error, fallback func(err error) error,
+	acceptable Acceptable) error {
+	switch atomic.LoadInt32(&cb.mode) {
+	case modeForceOpen:
+		if fallback != nil {
+			return fallback(ErrServiceUnavailable)
+		}
+		return ErrServiceUnavailable
+	case modeForceClose:
+		return req()
+	default:
+		return cb.loadThrottle().doReq(req, fallback, cb.wrapAcceptable(acceptable))
+	}
+}
+
+// wrapAcceptable combines the per-call acceptable with the per-breaker classifier,
+// an error accepted by either doesn't count as a breaker failure.
+func (cb *circuitBreaker) wrapAcceptable(acceptable Acceptable) Acceptable {
+	return func(err error) bool {
+		return acceptable(err) || cb.classifier(err)
+	}
+}
+
+func (cb *circuitBreaker) loadThrottle() throttle {
+	return cb.throttle.Load().(loggedThrottle)
+}
+
+func (cb *circuitBreaker) newThrottle() loggedThrottle {
+	return newLoggedThrottle(cb.name, cb.strategy(cb.notifyStateChange))
+}
+
+func (cb *circuitBreaker) notifyStateChange(prev, current State, stats StateStats) {
+	metricState.Set(float64(current), cb.name)
+	metricDropRatio.Set(stats.DropRatio, cb.name)
+	for _, listener := range cb.listeners {
+		listener.OnStateChange(cb.name, prev, current, stats)
+	}
+	for _, listener := range getGlobalListeners() {
+		listener.OnStateChange(cb.name, prev, current, stats)
+	}
+}
+
 // WithName returns a function to set the name of a Breaker.
 func WithName(name string) Option {
 	return func(b *circuitBreaker) {
@@ -142,10 +241,57 @@ func WithName(name string) Option {
 	}
 }
 
+// WithGoogleStrategy returns a function to use the Google SRE adaptive
+// throttling algorithm in a Breaker, which is the default.
+func WithGoogleStrategy() Option {
+	return func(b *circuitBreaker) {
+		b.strategy = googleStrategy
+	}
+}
+
+// WithRatioStrategy returns a function to use a classic closed/open/half-open
+// breaker in a Breaker. The breaker opens when at least minRequests requests
+// are seen in the rolling window and the failure ratio reaches failureRatio.
+// After openTimeout it allows probes concurrent probe requests, and closes
+// again once that many probes succeed.
+// Non-positive arguments fall back to sane defaults.
+func WithRatioStrategy(failureRatio float64, minRequests int64, openTimeout time.Duration,
+	probes int) Option {
+	return func(b *circuitBreaker) {
+		b.strategy = func(notify stateChangeFunc) internalThrottle {
+			rb := newRatioBreaker(failureRatio, minRequests, openTimeout, probes)
+			rb.onStateChange = notify
+			return rb
+		}
+	}
+}
+
+func googleStrategy(notify stateChangeFunc) internalThrottle {
+	gb := newGoogleBreaker()
+	gb.onStateChange = notify
+	return gb
+}
+
+// WithErrorClassifier returns a function to set the error classifier of a Breaker.
+// The classifier reports whether err doesn't indicate an unhealthy callee,
+// such errors don't count as breaker failures.
+// It replaces the default classifier that ignores context cancellations.
+func WithErrorClassifier(classifier Acceptable) Option {
+	return func(b *circuitBreaker) {
+		b.classifier = classifier
+	}
+}
+
 func defaultAcceptable(err error) bool {
 	return err == nil
 }
 
+// defaultClassifier ignores the caller-side errors that say nothing
+// about the health of the callee.
+func defaultClassifier(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
+
 type loggedThrottle struct {
 	name string
 	internalThrottle
@@ -179,11 +325,15 @@ func (lt loggedThrottle) doReq(req func() error, fallback func(err error) error,
 }
 
 func (lt loggedThrottle) logError(err error) error {
+	metricRequests.Inc(lt.name)
 	if err == ErrServiceUnavailable {
+		metricDrops.Inc(lt.name)
 		// if circuit open, not possible to have empty error window
 		stat.Report(fmt.Sprintf(
 			"proc(%s/%d), callee: %s, breaker is open and requests dropped\nlast errors:\n%s",
 			proc.ProcessName(), proc.Pid(), lt.name, lt.errWin))
+	} else {
+		metricAccepts.Inc(lt.name)
 	}
 
 	return err
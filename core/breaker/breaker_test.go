@@ -1,6 +1,7 @@
 package breaker
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -31,7 +32,7 @@ func TestLogReason(t *testing.T) {
 			return errors.New(strconv.Itoa(i))
 		})
 	}
-	errs := b.(*circuitBreaker).throttle.(loggedThrottle).errWin
+	errs := b.(*circuitBreaker).loadThrottle().(loggedThrottle).errWin
 	assert.Equal(t, numHistoryReasons, errs.count)
 }
 
@@ -113,6 +114,93 @@ func TestPromiseWithReason(t *testing.T) {
 	}
 }
 
+func TestDefaultClassifierIgnoresCancellation(t *testing.T) {
+	b := NewBreaker(WithName("classifier-default"))
+	for i := 0; i < 1000; i++ {
+		assert.Equal(t, context.Canceled, b.Do(func() error {
+			return context.Canceled
+		}))
+	}
+
+	// cancellations don't trip the breaker
+	_, err := b.Allow()
+	assert.Nil(t, err)
+}
+
+func TestWithErrorClassifier(t *testing.T) {
+	errBusiness := errors.New("invalid argument")
+	b := NewBreaker(WithName("classifier-custom"), WithErrorClassifier(func(err error) bool {
+		return errors.Is(err, errBusiness)
+	}))
+
+	for i := 0; i < 1000; i++ {
+		assert.Equal(t, errBusiness, b.Do(func() error {
+			return errBusiness
+		}))
+	}
+
+	_, err := b.Allow()
+	assert.Nil(t, err)
+}
+
+func TestForceOpen(t *testing.T) {
+	b := NewBreaker()
+	b.ForceOpen()
+
+	_, err := b.Allow()
+	assert.Equal(t, ErrServiceUnavailable, err)
+	assert.Equal(t, ErrServiceUnavailable, b.Do(func() error {
+		return nil
+	}))
+	assert.Equal(t, ErrServiceUnavailable, b.DoWithFallback(func() error {
+		return nil
+	}, func(err error) error {
+		return err
+	}))
+
+	b.Reset()
+	assert.Nil(t, b.Do(func() error {
+		return nil
+	}))
+}
+
+func TestForceClose(t *testing.T) {
+	b := NewBreaker()
+	b.ForceClose()
+
+	// failures don't count while forced closed
+	for i := 0; i < 1000; i++ {
+		assert.NotNil(t, b.Do(func() error {
+			return errors.New("any")
+		}))
+	}
+
+	promise, err := b.Allow()
+	assert.Nil(t, err)
+	promise.Accept()
+
+	b.Reset()
+	_, err = b.Allow()
+	assert.Nil(t, err)
+}
+
+func TestForceBreakersByName(t *testing.T) {
+	ForceOpen("breakers-force")
+	assert.Equal(t, ErrServiceUnavailable, Do("breakers-force", func() error {
+		return nil
+	}))
+
+	ForceClose("breakers-force")
+	assert.Nil(t, Do("breakers-force", func() error {
+		return nil
+	}))
+
+	Reset("breakers-force")
+	assert.Nil(t, Do("breakers-force", func() error {
+		return nil
+	}))
+}
+
 func BenchmarkGoogleBreaker(b *testing.B) {
 	br := NewBreaker()
 	for i := 0; i < b.N; i++ {
@@ -36,6 +36,21 @@ func DoWithFallbackAcceptable(name string, req func() error, fallback func(err e
 	})
 }
 
+// ForceOpen forces the Breaker with given name open, for ops tooling.
+func ForceOpen(name string) {
+	GetBreaker(name).ForceOpen()
+}
+
+// ForceClose forces the Breaker with given name closed, for ops tooling.
+func ForceClose(name string) {
+	GetBreaker(name).ForceClose()
+}
+
+// Reset clears the forced state and the collected stats of the Breaker with given name.
+func Reset(name string) {
+	GetBreaker(name).Reset()
+}
+
 // GetBreaker returns the Breaker with the given name.
 func GetBreaker(name string) Breaker {
 	lock.RLock()
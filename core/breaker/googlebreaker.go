@@ -2,6 +2,7 @@ package breaker
 
 import (
 	"math"
+	"sync/atomic"
 	"time"
 
 	"github.com/tal-tech/go-zero/core/collection"
@@ -22,6 +23,9 @@ type googleBreaker struct {
 	k     float64
 	stat  *collection.RollingWindow
 	proba *mathx.Proba
+	state int32
+	// called on state transitions, between accepting and rejecting requests.
+	onStateChange func(prev, current State, stats StateStats)
 }
 
 func newGoogleBreaker() *googleBreaker {
@@ -39,6 +43,7 @@ func (b *googleBreaker) accept() error {
 	weightedAccepts := b.k * float64(accepts)
 	// https://landing.google.com/sre/sre-book/chapters/handling-overload/#eq2101
 	dropRatio := math.Max(0, (float64(total-protection)-weightedAccepts)/float64(total+1))
+	b.updateState(dropRatio, accepts, total)
 	if dropRatio <= 0 {
 		return nil
 	}
@@ -50,6 +55,24 @@ func (b *googleBreaker) accept() error {
 	return nil
 }
 
+// updateState tracks whether the breaker is effectively dropping requests,
+// and notifies onStateChange on transitions.
+func (b *googleBreaker) updateState(dropRatio float64, accepts, total int64) {
+	state := StateClosed
+	if dropRatio > 0 {
+		state = StateOpen
+	}
+
+	prev := State(atomic.SwapInt32(&b.state, int32(state)))
+	if prev != state && b.onStateChange != nil {
+		b.onStateChange(prev, state, StateStats{
+			Accepts:   accepts,
+			Total:     total,
+			DropRatio: dropRatio,
+		})
+	}
+}
+
 func (b *googleBreaker) allow() (internalPromise, error) {
 	if err := b.accept(); err != nil {
 		return nil, err
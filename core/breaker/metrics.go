@@ -0,0 +1,47 @@
+package breaker
+
+import "github.com/tal-tech/go-zero/core/metric"
+
+const breakerNamespace = "breaker"
+
+var (
+	metricRequests = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: breakerNamespace,
+		Subsystem: "requests",
+		Name:      "total",
+		Help:      "breaker requests count.",
+		Labels:    []string{"name"},
+	})
+
+	metricAccepts = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: breakerNamespace,
+		Subsystem: "requests",
+		Name:      "accept_total",
+		Help:      "breaker accepted requests count.",
+		Labels:    []string{"name"},
+	})
+
+	metricDrops = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: breakerNamespace,
+		Subsystem: "requests",
+		Name:      "drop_total",
+		Help:      "breaker dropped requests count.",
+		Labels:    []string{"name"},
+	})
+
+	metricState = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: breakerNamespace,
+		Subsystem: "state",
+		Name:      "current",
+		Help:      "breaker state, 0 closed, 1 open, 2 halfopen.",
+		Labels:    []string{"name"},
+	})
+
+	metricDropRatio = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: breakerNamespace,
+		Subsystem: "state",
+		Name:      "drop_ratio",
+		Help:      "breaker drop ratio on the last state change.",
+		Labels:    []string{"name"},
+	})
+)
@@ -0,0 +1,36 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricVecs(t *testing.T) {
+	assert.NotNil(t, metricRequests)
+	assert.NotNil(t, metricAccepts)
+	assert.NotNil(t, metricDrops)
+	assert.NotNil(t, metricState)
+	assert.NotNil(t, metricDropRatio)
+}
+
+func TestMetricsOnStateChange(t *testing.T) {
+	// ensure the instrumented paths survive a full closed/open/closed cycle
+	b := NewBreaker(WithName("metrics-cycle"),
+		WithRatioStrategy(0.5, 10, time.Millisecond, 1))
+
+	for i := 0; i < 10; i++ {
+		b.Do(func() error {
+			return errors.New("failure")
+		})
+	}
+	_, err := b.Allow()
+	assert.Equal(t, ErrServiceUnavailable, err)
+
+	time.Sleep(time.Millisecond * 10)
+	assert.Nil(t, b.Do(func() error {
+		return nil
+	}))
+}
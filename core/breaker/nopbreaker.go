@@ -33,6 +33,15 @@ func (b noOpBreaker) DoWithFallbackAcceptable(req func() error, fallback func(er
 	return req()
 }
 
+func (b noOpBreaker) ForceOpen() {
+}
+
+func (b noOpBreaker) ForceClose() {
+}
+
+func (b noOpBreaker) Reset() {
+}
+
 type nopPromise struct{}
 
 func (p nopPromise) Accept() {
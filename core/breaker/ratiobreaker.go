@@ -0,0 +1,227 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/collection"
+	"github.com/tal-tech/go-zero/core/timex"
+)
+
+const (
+	defaultFailureRatio = 0.5
+	defaultMinRequests  = 10
+	defaultOpenTimeout  = time.Second * 5
+	defaultProbes       = 3
+)
+
+// ratioBreaker is a classic closed/open/half-open circuit breaker,
+// easier to reason about than googleBreaker for low-QPS callees.
+type ratioBreaker struct {
+	failureRatio float64
+	minRequests  int64
+	openTimeout  time.Duration
+	probes       int
+
+	lock           sync.Mutex
+	state          State
+	stat           *collection.RollingWindow
+	openedAt       time.Duration
+	inflightProbes int
+	probeSuccesses int
+	onStateChange  stateChangeFunc
+}
+
+func newRatioBreaker(failureRatio float64, minRequests int64, openTimeout time.Duration,
+	probes int) *ratioBreaker {
+	if failureRatio <= 0 || failureRatio > 1 {
+		failureRatio = defaultFailureRatio
+	}
+	if minRequests <= 0 {
+		minRequests = defaultMinRequests
+	}
+	if openTimeout <= 0 {
+		openTimeout = defaultOpenTimeout
+	}
+	if probes <= 0 {
+		probes = defaultProbes
+	}
+
+	return &ratioBreaker{
+		failureRatio: failureRatio,
+		minRequests:  minRequests,
+		openTimeout:  openTimeout,
+		probes:       probes,
+		stat:         newRatioWindow(),
+	}
+}
+
+func (b *ratioBreaker) accept() error {
+	b.lock.Lock()
+
+	var notify func()
+	var err error
+	switch b.state {
+	case StateOpen:
+		if timex.Since(b.openedAt) < b.openTimeout {
+			err = ErrServiceUnavailable
+			break
+		}
+
+		notify = b.transition(StateHalfOpen)
+		b.inflightProbes = 1
+		b.probeSuccesses = 0
+	case StateHalfOpen:
+		if b.inflightProbes >= b.probes {
+			err = ErrServiceUnavailable
+			break
+		}
+
+		b.inflightProbes++
+	}
+
+	b.lock.Unlock()
+	if notify != nil {
+		notify()
+	}
+
+	return err
+}
+
+func (b *ratioBreaker) allow() (internalPromise, error) {
+	if err := b.accept(); err != nil {
+		return nil, err
+	}
+
+	return ratioPromise{
+		b: b,
+	}, nil
+}
+
+func (b *ratioBreaker) doReq(req func() error, fallback func(err error) error,
+	acceptable Acceptable) error {
+	if err := b.accept(); err != nil {
+		if fallback != nil {
+			return fallback(err)
+		}
+
+		return err
+	}
+
+	defer func() {
+		if e := recover(); e != nil {
+			b.markFailure()
+			panic(e)
+		}
+	}()
+
+	err := req()
+	if acceptable(err) {
+		b.markSuccess()
+	} else {
+		b.markFailure()
+	}
+
+	return err
+}
+
+func (b *ratioBreaker) markSuccess() {
+	b.lock.Lock()
+
+	var notify func()
+	switch b.state {
+	case StateHalfOpen:
+		b.inflightProbes--
+		b.probeSuccesses++
+		if b.probeSuccesses >= b.probes {
+			b.stat = newRatioWindow()
+			notify = b.transition(StateClosed)
+		}
+	case StateClosed:
+		b.stat.Add(1)
+	}
+
+	b.lock.Unlock()
+	if notify != nil {
+		notify()
+	}
+}
+
+func (b *ratioBreaker) markFailure() {
+	b.lock.Lock()
+
+	var notify func()
+	switch b.state {
+	case StateHalfOpen:
+		b.openedAt = timex.Now()
+		notify = b.transition(StateOpen)
+	case StateClosed:
+		b.stat.Add(0)
+		accepts, total := b.history()
+		failures := total - accepts
+		if total >= b.minRequests && float64(failures)/float64(total) >= b.failureRatio {
+			b.openedAt = timex.Now()
+			notify = b.transition(StateOpen)
+		}
+	}
+
+	b.lock.Unlock()
+	if notify != nil {
+		notify()
+	}
+}
+
+func (b *ratioBreaker) history() (accepts, total int64) {
+	b.stat.Reduce(func(b *collection.Bucket) {
+		accepts += int64(b.Sum)
+		total += b.Count
+	})
+
+	return
+}
+
+// transition switches the state to `to` with the lock held,
+// and returns the pending listener notification, if any.
+func (b *ratioBreaker) transition(to State) func() {
+	if b.state == to {
+		return nil
+	}
+
+	prev := b.state
+	b.state = to
+	if b.onStateChange == nil {
+		return nil
+	}
+
+	accepts, total := b.history()
+	var dropRatio float64
+	if total > 0 {
+		dropRatio = float64(total-accepts) / float64(total)
+	}
+	notify := b.onStateChange
+
+	return func() {
+		notify(prev, to, StateStats{
+			Accepts:   accepts,
+			Total:     total,
+			DropRatio: dropRatio,
+		})
+	}
+}
+
+func newRatioWindow() *collection.RollingWindow {
+	bucketDuration := time.Duration(int64(window) / int64(buckets))
+	return collection.NewRollingWindow(buckets, bucketDuration)
+}
+
+type ratioPromise struct {
+	b *ratioBreaker
+}
+
+func (p ratioPromise) Accept() {
+	p.b.markSuccess()
+}
+
+func (p ratioPromise) Reject() {
+	p.b.markFailure()
+}
@@ -0,0 +1,122 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRatioBreakerDefaults(t *testing.T) {
+	b := newRatioBreaker(0, 0, 0, 0)
+	assert.Equal(t, defaultFailureRatio, b.failureRatio)
+	assert.Equal(t, int64(defaultMinRequests), b.minRequests)
+	assert.Equal(t, defaultOpenTimeout, b.openTimeout)
+	assert.Equal(t, defaultProbes, b.probes)
+}
+
+func TestRatioBreakerTrips(t *testing.T) {
+	b := newRatioBreaker(0.5, 10, time.Hour, 1)
+
+	for i := 0; i < 10; i++ {
+		assert.NotNil(t, b.doReq(func() error {
+			return errors.New("failure")
+		}, nil, defaultAcceptable))
+	}
+
+	assert.Equal(t, StateOpen, b.state)
+	_, err := b.allow()
+	assert.Equal(t, ErrServiceUnavailable, err)
+}
+
+func TestRatioBreakerStaysClosedOnLowVolume(t *testing.T) {
+	b := newRatioBreaker(0.5, 10, time.Hour, 1)
+
+	for i := 0; i < 5; i++ {
+		b.doReq(func() error {
+			return errors.New("failure")
+		}, nil, defaultAcceptable)
+	}
+
+	assert.Equal(t, StateClosed, b.state)
+}
+
+func TestRatioBreakerHalfOpenProbes(t *testing.T) {
+	b := newRatioBreaker(0.5, 5, time.Millisecond, 2)
+
+	for i := 0; i < 5; i++ {
+		b.doReq(func() error {
+			return errors.New("failure")
+		}, nil, defaultAcceptable)
+	}
+	assert.Equal(t, StateOpen, b.state)
+
+	time.Sleep(time.Millisecond * 10)
+
+	// first probe moves the breaker to half-open
+	p1, err := b.allow()
+	assert.Nil(t, err)
+	assert.Equal(t, StateHalfOpen, b.state)
+	p2, err := b.allow()
+	assert.Nil(t, err)
+	// no more than `probes` concurrent probes
+	_, err = b.allow()
+	assert.Equal(t, ErrServiceUnavailable, err)
+
+	p1.Accept()
+	p2.Accept()
+	assert.Equal(t, StateClosed, b.state)
+}
+
+func TestRatioBreakerProbeFailureReopens(t *testing.T) {
+	b := newRatioBreaker(0.5, 5, time.Millisecond, 1)
+
+	for i := 0; i < 5; i++ {
+		b.doReq(func() error {
+			return errors.New("failure")
+		}, nil, defaultAcceptable)
+	}
+	assert.Equal(t, StateOpen, b.state)
+
+	time.Sleep(time.Millisecond * 10)
+
+	p, err := b.allow()
+	assert.Nil(t, err)
+	p.Reject()
+	assert.Equal(t, StateOpen, b.state)
+	_, err = b.allow()
+	assert.Equal(t, ErrServiceUnavailable, err)
+}
+
+func TestWithRatioStrategy(t *testing.T) {
+	recorder := new(stateRecorder)
+	b := NewBreaker(WithName("ratio-strategy"),
+		WithRatioStrategy(0.5, 10, time.Hour, 1),
+		WithStateChangeListener(recorder))
+
+	for i := 0; i < 10; i++ {
+		b.Do(func() error {
+			return errors.New("failure")
+		})
+	}
+
+	_, err := b.Allow()
+	assert.Equal(t, ErrServiceUnavailable, err)
+	change, ok := recorder.last()
+	assert.True(t, ok)
+	assert.Equal(t, StateOpen, change.current)
+
+	b.Reset()
+	_, err = b.Allow()
+	assert.Nil(t, err)
+}
+
+func TestRatioBreakerPanic(t *testing.T) {
+	b := newRatioBreaker(0.5, 10, time.Hour, 1)
+	assert.Panics(t, func() {
+		b.doReq(func() error {
+			panic("oops")
+		}, nil, defaultAcceptable)
+	})
+}
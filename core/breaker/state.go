@@ -0,0 +1,69 @@
+package breaker
+
+import "sync"
+
+const (
+	// StateClosed represents the state that the breaker accepts requests.
+	StateClosed State = iota
+	// StateOpen represents the state that the breaker rejects requests.
+	StateOpen
+	// StateHalfOpen represents the state that the breaker allows probe requests.
+	StateHalfOpen
+)
+
+var (
+	listenerLock    sync.RWMutex
+	globalListeners []StateChangeListener
+)
+
+type (
+	// State represents the state of a Breaker.
+	State int32
+
+	// StateStats are the stats that triggered a state change.
+	StateStats struct {
+		Accepts   int64
+		Total     int64
+		DropRatio float64
+	}
+
+	// A StateChangeListener is notified when a Breaker changes its state,
+	// e.g. to log or alert on opening and recovering breakers.
+	StateChangeListener interface {
+		OnStateChange(name string, prev, current State, stats StateStats)
+	}
+)
+
+// AddStateChangeListener registers listener for all the breakers.
+func AddStateChangeListener(listener StateChangeListener) {
+	listenerLock.Lock()
+	defer listenerLock.Unlock()
+	globalListeners = append(globalListeners, listener)
+}
+
+// WithStateChangeListener returns an Option to register listener on a single Breaker.
+func WithStateChangeListener(listener StateChangeListener) Option {
+	return func(b *circuitBreaker) {
+		b.listeners = append(b.listeners, listener)
+	}
+}
+
+// String returns the readable name of s.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "halfopen"
+	default:
+		return "unknown"
+	}
+}
+
+func getGlobalListeners() []StateChangeListener {
+	listenerLock.RLock()
+	defer listenerLock.RUnlock()
+	return globalListeners
+}
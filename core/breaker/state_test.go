@@ -0,0 +1,88 @@
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stateRecorder struct {
+	lock    sync.Mutex
+	changes []stateChange
+}
+
+type stateChange struct {
+	name    string
+	prev    State
+	current State
+	stats   StateStats
+}
+
+func (r *stateRecorder) OnStateChange(name string, prev, current State, stats StateStats) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.changes = append(r.changes, stateChange{
+		name:    name,
+		prev:    prev,
+		current: current,
+		stats:   stats,
+	})
+}
+
+func (r *stateRecorder) last() (stateChange, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.changes) == 0 {
+		return stateChange{}, false
+	}
+	return r.changes[len(r.changes)-1], true
+}
+
+func TestStateChangeListener(t *testing.T) {
+	recorder := new(stateRecorder)
+	b := NewBreaker(WithName("state-listener"), WithStateChangeListener(recorder))
+
+	for i := 0; i < 1000; i++ {
+		_ = b.Do(func() error {
+			return errors.New("failure")
+		})
+	}
+
+	change, ok := recorder.last()
+	assert.True(t, ok)
+	assert.Equal(t, "state-listener", change.name)
+	assert.Equal(t, StateClosed, change.prev)
+	assert.Equal(t, StateOpen, change.current)
+	assert.True(t, change.stats.DropRatio > 0)
+	assert.True(t, change.stats.Total > 0)
+}
+
+func TestGlobalStateChangeListener(t *testing.T) {
+	recorder := new(stateRecorder)
+	AddStateChangeListener(recorder)
+	defer func() {
+		listenerLock.Lock()
+		globalListeners = nil
+		listenerLock.Unlock()
+	}()
+
+	b := NewBreaker(WithName("state-global"))
+	for i := 0; i < 1000; i++ {
+		_ = b.Do(func() error {
+			return errors.New("failure")
+		})
+	}
+
+	change, ok := recorder.last()
+	assert.True(t, ok)
+	assert.Equal(t, "state-global", change.name)
+	assert.Equal(t, StateOpen, change.current)
+}
+
+func TestStateString(t *testing.T) {
+	assert.Equal(t, "closed", StateClosed.String())
+	assert.Equal(t, "open", StateOpen.String())
+	assert.Equal(t, "unknown", State(99).String())
+}
@@ -0,0 +1,154 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/lang"
+)
+
+var (
+	// ErrQueueClosed indicates that the queue is closed, a closed queue
+	// rejects puts, the remaining items still can be taken.
+	ErrQueueClosed = errors.New("queue closed")
+	// ErrQueueTimeout indicates that a put or take timed out.
+	ErrQueueTimeout = errors.New("queue operation timed out")
+)
+
+// A BlockingQueue is a bounded MPMC queue, unlike Ring it never overwrites,
+// the producers block when full and the consumers block when empty.
+// It's suitable as an in-process work queue for executors.
+type BlockingQueue struct {
+	items chan interface{}
+	done  chan lang.PlaceholderType
+	once  sync.Once
+}
+
+// NewBlockingQueue returns a BlockingQueue with up to size items.
+func NewBlockingQueue(size int) *BlockingQueue {
+	if size < 1 {
+		panic("size should be greater than 0")
+	}
+
+	return &BlockingQueue{
+		items: make(chan interface{}, size),
+		done:  make(chan lang.PlaceholderType),
+	}
+}
+
+// Close closes the queue, the following puts are rejected,
+// the remaining items still can be taken. It's safe to call Close more than once.
+func (q *BlockingQueue) Close() {
+	q.once.Do(func() {
+		close(q.done)
+	})
+}
+
+// Len returns the number of items in q.
+func (q *BlockingQueue) Len() int {
+	return len(q.items)
+}
+
+// Put puts v into q, blocks while q is full.
+func (q *BlockingQueue) Put(v interface{}) error {
+	select {
+	case <-q.done:
+		return ErrQueueClosed
+	default:
+	}
+
+	select {
+	case q.items <- v:
+		return nil
+	case <-q.done:
+		return ErrQueueClosed
+	}
+}
+
+// PutCtx puts v into q, blocks while q is full, unblocked when ctx is done.
+func (q *BlockingQueue) PutCtx(ctx context.Context, v interface{}) error {
+	select {
+	case <-q.done:
+		return ErrQueueClosed
+	default:
+	}
+
+	select {
+	case q.items <- v:
+		return nil
+	case <-q.done:
+		return ErrQueueClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// PutWithTimeout puts v into q, blocks up to timeout while q is full.
+func (q *BlockingQueue) PutWithTimeout(v interface{}, timeout time.Duration) error {
+	select {
+	case <-q.done:
+		return ErrQueueClosed
+	default:
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case q.items <- v:
+		return nil
+	case <-q.done:
+		return ErrQueueClosed
+	case <-timer.C:
+		return ErrQueueTimeout
+	}
+}
+
+// Take takes an item from q, blocks while q is empty.
+func (q *BlockingQueue) Take() (interface{}, error) {
+	select {
+	case v := <-q.items:
+		return v, nil
+	case <-q.done:
+		return q.takeRemaining()
+	}
+}
+
+// TakeCtx takes an item from q, blocks while q is empty, unblocked when ctx is done.
+func (q *BlockingQueue) TakeCtx(ctx context.Context) (interface{}, error) {
+	select {
+	case v := <-q.items:
+		return v, nil
+	case <-q.done:
+		return q.takeRemaining()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// TakeWithTimeout takes an item from q, blocks up to timeout while q is empty.
+func (q *BlockingQueue) TakeWithTimeout(timeout time.Duration) (interface{}, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case v := <-q.items:
+		return v, nil
+	case <-q.done:
+		return q.takeRemaining()
+	case <-timer.C:
+		return nil, ErrQueueTimeout
+	}
+}
+
+// takeRemaining drains the remaining items of a closed queue.
+func (q *BlockingQueue) takeRemaining() (interface{}, error) {
+	select {
+	case v := <-q.items:
+		return v, nil
+	default:
+		return nil, ErrQueueClosed
+	}
+}
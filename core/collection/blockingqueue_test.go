@@ -0,0 +1,142 @@
+package collection
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockingQueueBadSize(t *testing.T) {
+	assert.Panics(t, func() {
+		NewBlockingQueue(0)
+	})
+}
+
+func TestBlockingQueuePutTake(t *testing.T) {
+	queue := NewBlockingQueue(2)
+	assert.Nil(t, queue.Put(1))
+	assert.Nil(t, queue.Put(2))
+	assert.Equal(t, 2, queue.Len())
+
+	v, err := queue.Take()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, v)
+	v, err = queue.Take()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, v)
+}
+
+func TestBlockingQueueBlocksWhenFull(t *testing.T) {
+	queue := NewBlockingQueue(1)
+	assert.Nil(t, queue.Put(1))
+	assert.Equal(t, ErrQueueTimeout, queue.PutWithTimeout(2, time.Millisecond*50))
+
+	done := make(chan struct{})
+	go func() {
+		assert.Nil(t, queue.Put(2))
+		close(done)
+	}()
+
+	time.Sleep(time.Millisecond * 50)
+	v, err := queue.Take()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, v)
+	<-done
+}
+
+func TestBlockingQueueTakeTimeout(t *testing.T) {
+	queue := NewBlockingQueue(1)
+	_, err := queue.TakeWithTimeout(time.Millisecond * 50)
+	assert.Equal(t, ErrQueueTimeout, err)
+}
+
+func TestBlockingQueueCtx(t *testing.T) {
+	queue := NewBlockingQueue(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := queue.TakeCtx(ctx)
+	assert.Equal(t, context.Canceled, err)
+
+	assert.Nil(t, queue.Put(1))
+	err = queue.PutCtx(ctx, 2)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestBlockingQueueClose(t *testing.T) {
+	queue := NewBlockingQueue(2)
+	assert.Nil(t, queue.Put(1))
+	queue.Close()
+	queue.Close()
+
+	assert.Equal(t, ErrQueueClosed, queue.Put(2))
+	assert.Equal(t, ErrQueueClosed, queue.PutWithTimeout(2, time.Millisecond))
+	assert.Equal(t, ErrQueueClosed, queue.PutCtx(context.Background(), 2))
+
+	// the remaining items still can be taken
+	v, err := queue.Take()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, v)
+	_, err = queue.Take()
+	assert.Equal(t, ErrQueueClosed, err)
+	_, err = queue.TakeWithTimeout(time.Millisecond)
+	assert.Equal(t, ErrQueueClosed, err)
+}
+
+func TestBlockingQueueCloseUnblocksTake(t *testing.T) {
+	queue := NewBlockingQueue(1)
+	done := make(chan struct{})
+	go func() {
+		_, err := queue.Take()
+		assert.Equal(t, ErrQueueClosed, err)
+		close(done)
+	}()
+
+	time.Sleep(time.Millisecond * 50)
+	queue.Close()
+	<-done
+}
+
+func TestBlockingQueueConcurrent(t *testing.T) {
+	const total = 1000
+	queue := NewBlockingQueue(8)
+	var sum int64
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				v, err := queue.Take()
+				if err != nil {
+					return
+				}
+				atomic.AddInt64(&sum, int64(v.(int)))
+			}
+		}()
+	}
+
+	var producers sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		producers.Add(1)
+		go func(base int) {
+			defer producers.Done()
+			for j := 0; j < total/4; j++ {
+				assert.Nil(t, queue.Put(1))
+			}
+		}(i)
+	}
+	producers.Wait()
+
+	for queue.Len() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	queue.Close()
+	wg.Wait()
+
+	assert.Equal(t, int64(total), atomic.LoadInt64(&sum))
+}
@@ -2,6 +2,7 @@ package collection
 
 import (
 	"container/list"
+	"math"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -9,6 +10,8 @@ import (
 	"github.com/tal-tech/go-zero/core/logx"
 	"github.com/tal-tech/go-zero/core/mathx"
 	"github.com/tal-tech/go-zero/core/syncx"
+	"github.com/tal-tech/go-zero/core/threading"
+	"github.com/tal-tech/go-zero/core/timex"
 )
 
 const (
@@ -20,12 +23,45 @@ const (
 	expiryDeviation = 0.05
 )
 
+// EvictReason represents the reason an entry is removed from a Cache.
+type EvictReason int
+
+// The reasons that entries are removed from a Cache.
+const (
+	// EvictExpired means the entry reached its expiry.
+	EvictExpired EvictReason = iota
+	// EvictLru means the entry is evicted by the count or size limit.
+	EvictLru
+	// EvictDeleted means the entry is deleted by the caller.
+	EvictDeleted
+)
+
+// String returns the readable representation of r.
+func (r EvictReason) String() string {
+	switch r {
+	case EvictExpired:
+		return "expired"
+	case EvictLru:
+		return "lru"
+	case EvictDeleted:
+		return "deleted"
+	default:
+		return "unknown"
+	}
+}
+
 var emptyLruCache = emptyLru{}
 
 type (
 	// CacheOption defines the method to customize a Cache.
 	CacheOption func(cache *Cache)
 
+	// OnEvictFunc is used to handle the entries removed from a Cache.
+	OnEvictFunc func(key string, value interface{}, reason EvictReason)
+
+	// SizeEstimateFunc is used to estimate the bytes an entry takes.
+	SizeEstimateFunc func(key string, value interface{}) int64
+
 	// A Cache object is a in-memory cache.
 	Cache struct {
 		name           string
@@ -37,6 +73,11 @@ type (
 		barrier        syncx.SharedCalls
 		unstableExpiry mathx.Unstable
 		stats          *cacheStat
+		onEvictFn      OnEvictFunc
+		sizeLimit      int64
+		sizeOf         SizeEstimateFunc
+		usedBytes      int64
+		ticker         timex.Ticker
 	}
 )
 
@@ -58,15 +99,26 @@ func NewCache(expire time.Duration, opts ...CacheOption) (*Cache, error) {
 		cache.name = defaultCacheName
 	}
 	cache.stats = newCacheStat(cache.name, cache.size)
+	if cache.sizeLimit > 0 && cache.sizeOf != nil && cache.lruCache == emptyLruCache {
+		// the lru order is needed to evict by size even without a count limit
+		cache.lruCache = newKeyLru(math.MaxInt32, cache.onEvict)
+	}
 
-	timingWheel, err := NewTimingWheel(time.Second, slots, func(k, v interface{}) {
+	expireTask := func(k, v interface{}) {
 		key, ok := k.(string)
 		if !ok {
 			return
 		}
 
-		cache.Del(key)
-	})
+		cache.doDel(key, EvictExpired)
+	}
+	var timingWheel *TimingWheel
+	var err error
+	if cache.ticker != nil {
+		timingWheel, err = NewTimingWheelWithTicker(time.Second, slots, expireTask, cache.ticker)
+	} else {
+		timingWheel, err = NewTimingWheel(time.Second, slots, expireTask)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -77,11 +129,7 @@ func NewCache(expire time.Duration, opts ...CacheOption) (*Cache, error) {
 
 // Del deletes the item with the given key from c.
 func (c *Cache) Del(key string) {
-	c.lock.Lock()
-	delete(c.data, key)
-	c.lruCache.remove(key)
-	c.lock.Unlock()
-	c.timingWheel.RemoveTimer(key)
+	c.doDel(key, EvictDeleted)
 }
 
 // Get returns the item with the given key from c.
@@ -98,13 +146,30 @@ func (c *Cache) Get(key string) (interface{}, bool) {
 
 // Set sets value into c with key.
 func (c *Cache) Set(key string, value interface{}) {
+	c.SetWithExpire(key, value, c.expire)
+}
+
+// SetWithExpire sets value into c with key and the given expire,
+// which overrides the default cache expiry for this entry.
+func (c *Cache) SetWithExpire(key string, value interface{}, expire time.Duration) {
 	c.lock.Lock()
-	_, ok := c.data[key]
+	old, ok := c.data[key]
 	c.data[key] = value
+	if c.sizeOf != nil {
+		if ok {
+			c.usedBytes -= c.sizeOf(key, old)
+		}
+		c.usedBytes += c.sizeOf(key, value)
+	}
 	c.lruCache.add(key)
+	// evict the least recently used entries until within the size limit,
+	// an oversized single entry is kept, the callers can't do better either
+	for c.sizeLimit > 0 && c.usedBytes > c.sizeLimit && len(c.data) > 1 {
+		c.lruCache.removeOldest()
+	}
 	c.lock.Unlock()
 
-	expiry := c.unstableExpiry.AroundDuration(c.expire)
+	expiry := c.unstableExpiry.AroundDuration(expire)
 	if ok {
 		c.timingWheel.MoveTimer(key, expiry)
 	} else {
@@ -152,6 +217,24 @@ func (c *Cache) Take(key string, fetch func() (interface{}, error)) (interface{}
 	return val, nil
 }
 
+func (c *Cache) doDel(key string, reason EvictReason) {
+	c.lock.Lock()
+	value, ok := c.data[key]
+	if ok {
+		delete(c.data, key)
+		if c.sizeOf != nil {
+			c.usedBytes -= c.sizeOf(key, value)
+		}
+		c.lruCache.remove(key)
+	}
+	c.lock.Unlock()
+	c.timingWheel.RemoveTimer(key)
+
+	if ok {
+		c.notifyEvict(key, value, reason)
+	}
+}
+
 func (c *Cache) doGet(key string) (interface{}, bool) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -164,10 +247,27 @@ func (c *Cache) doGet(key string) (interface{}, bool) {
 	return value, ok
 }
 
+func (c *Cache) notifyEvict(key string, value interface{}, reason EvictReason) {
+	if c.onEvictFn == nil {
+		return
+	}
+
+	// run in a separate goroutine, the callers might be holding c.lock
+	fn := c.onEvictFn
+	threading.GoSafe(func() {
+		fn(key, value, reason)
+	})
+}
+
 func (c *Cache) onEvict(key string) {
 	// already locked
+	value := c.data[key]
 	delete(c.data, key)
+	if c.sizeOf != nil {
+		c.usedBytes -= c.sizeOf(key, value)
+	}
 	c.timingWheel.RemoveTimer(key)
+	c.notifyEvict(key, value, EvictLru)
 }
 
 func (c *Cache) size() int {
@@ -185,6 +285,27 @@ func WithLimit(limit int) CacheOption {
 	}
 }
 
+// WithEvictCallback customizes a Cache with an eviction callback,
+// fn is called in a separate goroutine whenever an entry is removed,
+// no matter expired, evicted by the limits or deleted by the caller.
+func WithEvictCallback(fn OnEvictFunc) CacheOption {
+	return func(cache *Cache) {
+		cache.onEvictFn = fn
+	}
+}
+
+// WithSizeLimit bounds a Cache by approximate memory with up to maxBytes,
+// estimate reports the bytes an entry takes, the least recently used entries
+// are evicted when the total exceeds maxBytes.
+func WithSizeLimit(maxBytes int64, estimate SizeEstimateFunc) CacheOption {
+	return func(cache *Cache) {
+		if maxBytes > 0 && estimate != nil {
+			cache.sizeLimit = maxBytes
+			cache.sizeOf = estimate
+		}
+	}
+}
+
 // WithName customizes a Cache with the given name.
 func WithName(name string) CacheOption {
 	return func(cache *Cache) {
@@ -192,10 +313,19 @@ func WithName(name string) CacheOption {
 	}
 }
 
+// WithTicker customizes a Cache to drive the expiry with the given ticker,
+// which makes the expirations testable with a fake ticker.
+func WithTicker(ticker timex.Ticker) CacheOption {
+	return func(cache *Cache) {
+		cache.ticker = ticker
+	}
+}
+
 type (
 	lru interface {
 		add(key string)
 		remove(key string)
+		removeOldest()
 	}
 
 	emptyLru struct{}
@@ -214,6 +344,9 @@ func (elru emptyLru) add(string) {
 func (elru emptyLru) remove(string) {
 }
 
+func (elru emptyLru) removeOldest() {
+}
+
 func newKeyLru(limit int, onEvict func(key string)) *keyLru {
 	return &keyLru{
 		limit:    limit,
@@ -239,9 +372,12 @@ func (klru *keyLru) add(key string) {
 	}
 }
 
+// remove removes the key without the onEvict callback,
+// the callers drive the removal and clean up by themselves.
 func (klru *keyLru) remove(key string) {
 	if elem, ok := klru.elements[key]; ok {
-		klru.removeElement(elem)
+		klru.evicts.Remove(elem)
+		delete(klru.elements, key)
 	}
 }
 
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/timex/timextest"
 )
 
 var errDummy = errors.New("dummy")
@@ -28,6 +29,32 @@ func TestCacheSet(t *testing.T) {
 	assert.Equal(t, "second element", value)
 }
 
+func TestCacheSetWithExpire(t *testing.T) {
+	cache, err := NewCache(time.Minute)
+	assert.Nil(t, err)
+
+	cache.Set("first", "first element")
+	cache.SetWithExpire("second", "second element", time.Second)
+
+	_, ok := cache.Get("second")
+	assert.True(t, ok)
+
+	// the timing wheel ticks every second, the expiry is unstable,
+	// so poll instead of a fixed sleep
+	deadline := time.Now().Add(time.Second * 5)
+	for time.Now().Before(deadline) {
+		if _, ok := cache.Get("second"); !ok {
+			break
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+
+	_, ok = cache.Get("second")
+	assert.False(t, ok)
+	_, ok = cache.Get("first")
+	assert.True(t, ok)
+}
+
 func TestCacheDel(t *testing.T) {
 	cache, err := NewCache(time.Second * 2)
 	assert.Nil(t, err)
@@ -114,6 +141,71 @@ func TestCacheTakeError(t *testing.T) {
 	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
 }
 
+func TestCacheEvictCallback(t *testing.T) {
+	type evictEvent struct {
+		key    string
+		value  interface{}
+		reason EvictReason
+	}
+	events := make(chan evictEvent, 10)
+	cache, err := NewCache(time.Minute, WithLimit(2), WithEvictCallback(
+		func(key string, value interface{}, reason EvictReason) {
+			events <- evictEvent{
+				key:    key,
+				value:  value,
+				reason: reason,
+			}
+		}))
+	assert.Nil(t, err)
+
+	cache.Set("first", "first element")
+	cache.Set("second", "second element")
+	cache.Set("third", "third element")
+
+	select {
+	case event := <-events:
+		assert.Equal(t, "first", event.key)
+		assert.Equal(t, "first element", event.value)
+		assert.Equal(t, EvictLru, event.reason)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for lru eviction")
+	}
+
+	cache.Del("second")
+	select {
+	case event := <-events:
+		assert.Equal(t, "second", event.key)
+		assert.Equal(t, EvictDeleted, event.reason)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for deletion")
+	}
+}
+
+func TestCacheSizeLimit(t *testing.T) {
+	cache, err := NewCache(time.Minute, WithSizeLimit(10, func(key string, value interface{}) int64 {
+		return int64(len(value.(string)))
+	}))
+	assert.Nil(t, err)
+
+	cache.Set("first", "12345")
+	cache.Set("second", "12345")
+	cache.Set("third", "12345")
+
+	_, ok := cache.Get("first")
+	assert.False(t, ok)
+	_, ok = cache.Get("second")
+	assert.True(t, ok)
+	_, ok = cache.Get("third")
+	assert.True(t, ok)
+}
+
+func TestEvictReasonString(t *testing.T) {
+	assert.Equal(t, "expired", EvictExpired.String())
+	assert.Equal(t, "lru", EvictLru.String())
+	assert.Equal(t, "deleted", EvictDeleted.String())
+	assert.Equal(t, "unknown", EvictReason(10).String())
+}
+
 func TestCacheWithLruEvicts(t *testing.T) {
 	cache, err := NewCache(time.Minute, WithLimit(3))
 	assert.Nil(t, err)
@@ -188,3 +280,28 @@ func BenchmarkCache(b *testing.B) {
 		}
 	})
 }
+
+func TestCacheWithTicker(t *testing.T) {
+	clock := timextest.NewFakeClock(time.Now())
+	cache, err := NewCache(time.Second*10, WithTicker(clock.NewTicker(time.Second)))
+	assert.Nil(t, err)
+
+	cache.Set("first", "first element")
+	_, ok := cache.Get("first")
+	assert.True(t, ok)
+
+	// the expiry deviates within [0.95, 1.05] * expire, overshoot it
+	for i := 0; i < 15; i++ {
+		clock.Advance(time.Second)
+		// let the wheel consume the tick before the next one coalesces
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	for i := 0; i < 100; i++ {
+		if _, ok = cache.Get("first"); !ok {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	assert.False(t, ok)
+}
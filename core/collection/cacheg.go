@@ -0,0 +1,312 @@
+package collection
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/mathx"
+)
+
+type (
+	cacheGOptions struct {
+		limit int
+		name  string
+	}
+
+	// CacheGOption defines the method to customize a CacheG.
+	CacheGOption func(opts *cacheGOptions)
+
+	// A CacheG is the generic variant of Cache, it avoids boxing the keys
+	// and the values, and the type assertions on every read.
+	CacheG[K comparable, V any] struct {
+		name           string
+		lock           sync.Mutex
+		data           map[K]V
+		expire         time.Duration
+		timingWheel    *TimingWheel
+		lruCache       lruG[K]
+		barrier        barrierG[K, V]
+		unstableExpiry mathx.Unstable
+		stats          *cacheStat
+	}
+)
+
+// NewCacheG returns a CacheG with given expire.
+func NewCacheG[K comparable, V any](expire time.Duration, opts ...CacheGOption) (*CacheG[K, V], error) {
+	var options cacheGOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if len(options.name) == 0 {
+		options.name = defaultCacheName
+	}
+
+	cache := &CacheG[K, V]{
+		name:           options.name,
+		data:           make(map[K]V),
+		expire:         expire,
+		lruCache:       emptyLruG[K]{},
+		unstableExpiry: mathx.NewUnstable(expiryDeviation),
+	}
+	if options.limit > 0 {
+		cache.lruCache = newKeyLruG(options.limit, cache.onEvict)
+	}
+	cache.stats = newCacheStat(cache.name, cache.size)
+
+	timingWheel, err := NewTimingWheel(time.Second, slots, func(k, v interface{}) {
+		key, ok := k.(K)
+		if !ok {
+			return
+		}
+
+		cache.Del(key)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cache.timingWheel = timingWheel
+	return cache, nil
+}
+
+// Del deletes the item with the given key from c.
+func (c *CacheG[K, V]) Del(key K) {
+	c.lock.Lock()
+	delete(c.data, key)
+	c.lruCache.remove(key)
+	c.lock.Unlock()
+	c.timingWheel.RemoveTimer(key)
+}
+
+// Get returns the item with the given key from c.
+func (c *CacheG[K, V]) Get(key K) (V, bool) {
+	value, ok := c.doGet(key)
+	if ok {
+		c.stats.IncrementHit()
+	} else {
+		c.stats.IncrementMiss()
+	}
+
+	return value, ok
+}
+
+// Set sets value into c with key.
+func (c *CacheG[K, V]) Set(key K, value V) {
+	c.SetWithExpire(key, value, c.expire)
+}
+
+// SetWithExpire sets value into c with key and the given expire,
+// which overrides the default cache expiry for this entry.
+func (c *CacheG[K, V]) SetWithExpire(key K, value V, expire time.Duration) {
+	c.lock.Lock()
+	_, ok := c.data[key]
+	c.data[key] = value
+	c.lruCache.add(key)
+	c.lock.Unlock()
+
+	expiry := c.unstableExpiry.AroundDuration(expire)
+	if ok {
+		c.timingWheel.MoveTimer(key, expiry)
+	} else {
+		c.timingWheel.SetTimer(key, value, expiry)
+	}
+}
+
+// Take returns the item with the given key.
+// If the item is in c, return it directly.
+// If not, use fetch method to get the item, set into c and return it.
+func (c *CacheG[K, V]) Take(key K, fetch func() (V, error)) (V, error) {
+	if val, ok := c.doGet(key); ok {
+		c.stats.IncrementHit()
+		return val, nil
+	}
+
+	var fresh bool
+	val, err := c.barrier.do(key, func() (V, error) {
+		// because O(1) on map search in memory, and fetch is an IO query
+		// so we do double check, cache might be taken by another call
+		if val, ok := c.doGet(key); ok {
+			return val, nil
+		}
+
+		v, e := fetch()
+		if e != nil {
+			var zero V
+			return zero, e
+		}
+
+		fresh = true
+		c.Set(key, v)
+		return v, nil
+	})
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+
+	if fresh {
+		c.stats.IncrementMiss()
+		return val, nil
+	}
+
+	// got the result from previous ongoing query
+	c.stats.IncrementHit()
+	return val, nil
+}
+
+func (c *CacheG[K, V]) doGet(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	value, ok := c.data[key]
+	if ok {
+		c.lruCache.add(key)
+	}
+
+	return value, ok
+}
+
+func (c *CacheG[K, V]) onEvict(key K) {
+	// already locked
+	delete(c.data, key)
+	c.timingWheel.RemoveTimer(key)
+}
+
+func (c *CacheG[K, V]) size() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.data)
+}
+
+// WithLimitG customizes a CacheG with items up to limit.
+func WithLimitG(limit int) CacheGOption {
+	return func(opts *cacheGOptions) {
+		if limit > 0 {
+			opts.limit = limit
+		}
+	}
+}
+
+// WithNameG customizes a CacheG with the given name.
+func WithNameG(name string) CacheGOption {
+	return func(opts *cacheGOptions) {
+		opts.name = name
+	}
+}
+
+type (
+	lruG[K comparable] interface {
+		add(key K)
+		remove(key K)
+		removeOldest()
+	}
+
+	emptyLruG[K comparable] struct{}
+
+	keyLruG[K comparable] struct {
+		limit    int
+		evicts   *list.List
+		elements map[K]*list.Element
+		onEvict  func(key K)
+	}
+)
+
+func (elru emptyLruG[K]) add(K) {
+}
+
+func (elru emptyLruG[K]) remove(K) {
+}
+
+func (elru emptyLruG[K]) removeOldest() {
+}
+
+func newKeyLruG[K comparable](limit int, onEvict func(key K)) *keyLruG[K] {
+	return &keyLruG[K]{
+		limit:    limit,
+		evicts:   list.New(),
+		elements: make(map[K]*list.Element),
+		onEvict:  onEvict,
+	}
+}
+
+func (klru *keyLruG[K]) add(key K) {
+	if elem, ok := klru.elements[key]; ok {
+		klru.evicts.MoveToFront(elem)
+		return
+	}
+
+	// Add new item
+	elem := klru.evicts.PushFront(key)
+	klru.elements[key] = elem
+
+	// Verify size not exceeded
+	if klru.evicts.Len() > klru.limit {
+		klru.removeOldest()
+	}
+}
+
+// remove removes the key without the onEvict callback,
+// the callers drive the removal and clean up by themselves.
+func (klru *keyLruG[K]) remove(key K) {
+	if elem, ok := klru.elements[key]; ok {
+		klru.evicts.Remove(elem)
+		delete(klru.elements, key)
+	}
+}
+
+func (klru *keyLruG[K]) removeOldest() {
+	elem := klru.evicts.Back()
+	if elem == nil {
+		return
+	}
+
+	klru.evicts.Remove(elem)
+	key := elem.Value.(K)
+	delete(klru.elements, key)
+	klru.onEvict(key)
+}
+
+type (
+	// barrierG is a generic flavor of syncx.SharedCalls,
+	// SharedCalls only takes string keys.
+	barrierG[K comparable, V any] struct {
+		lock  sync.Mutex
+		calls map[K]*callG[V]
+	}
+
+	callG[V any] struct {
+		wg  sync.WaitGroup
+		val V
+		err error
+	}
+)
+
+func (b *barrierG[K, V]) do(key K, fn func() (V, error)) (V, error) {
+	b.lock.Lock()
+	if b.calls == nil {
+		b.calls = make(map[K]*callG[V])
+	}
+	if c, ok := b.calls[key]; ok {
+		b.lock.Unlock()
+		c.wg.Wait()
+		return c.val, c.err
+	}
+
+	c := new(callG[V])
+	c.wg.Add(1)
+	b.calls[key] = c
+	b.lock.Unlock()
+
+	defer func() {
+		// delete key first, done later. can't reverse the order, because if reverse,
+		// another do call might wg.Wait() without this call returning.
+		b.lock.Lock()
+		delete(b.calls, key)
+		b.lock.Unlock()
+		c.wg.Done()
+	}()
+
+	c.val, c.err = fn()
+	return c.val, c.err
+}
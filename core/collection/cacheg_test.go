@@ -0,0 +1,110 @@
+package collection
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheGSet(t *testing.T) {
+	cache, err := NewCacheG[string, string](time.Second*2, WithNameG("any"))
+	assert.Nil(t, err)
+
+	cache.Set("first", "first element")
+	cache.Set("second", "second element")
+
+	value, ok := cache.Get("first")
+	assert.True(t, ok)
+	assert.Equal(t, "first element", value)
+	value, ok = cache.Get("second")
+	assert.True(t, ok)
+	assert.Equal(t, "second element", value)
+}
+
+func TestCacheGDel(t *testing.T) {
+	cache, err := NewCacheG[int, string](time.Second * 2)
+	assert.Nil(t, err)
+
+	cache.Set(1, "first element")
+	cache.Set(2, "second element")
+	cache.Del(1)
+
+	_, ok := cache.Get(1)
+	assert.False(t, ok)
+	value, ok := cache.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, "second element", value)
+}
+
+func TestCacheGTake(t *testing.T) {
+	cache, err := NewCacheG[string, string](time.Second * 2)
+	assert.Nil(t, err)
+
+	var count int32
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			cache.Take("first", func() (string, error) {
+				atomic.AddInt32(&count, 1)
+				time.Sleep(time.Millisecond * 100)
+				return "first element", nil
+			})
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, cache.size())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+}
+
+func TestCacheGTakeError(t *testing.T) {
+	cache, err := NewCacheG[string, string](time.Second * 2)
+	assert.Nil(t, err)
+
+	var count int32
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			val, err := cache.Take("first", func() (string, error) {
+				atomic.AddInt32(&count, 1)
+				time.Sleep(time.Millisecond * 100)
+				return "", errDummy
+			})
+			assert.Equal(t, errDummy, err)
+			assert.Equal(t, "", val)
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 0, cache.size())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+}
+
+func TestCacheGWithLruEvicts(t *testing.T) {
+	cache, err := NewCacheG[string, string](time.Minute, WithLimitG(3))
+	assert.Nil(t, err)
+
+	cache.Set("first", "first element")
+	cache.Set("second", "second element")
+	cache.Set("third", "third element")
+	cache.Set("fourth", "fourth element")
+
+	_, ok := cache.Get("first")
+	assert.False(t, ok)
+	value, ok := cache.Get("second")
+	assert.True(t, ok)
+	assert.Equal(t, "second element", value)
+	value, ok = cache.Get("third")
+	assert.True(t, ok)
+	assert.Equal(t, "third element", value)
+	value, ok = cache.Get("fourth")
+	assert.True(t, ok)
+	assert.Equal(t, "fourth element", value)
+}
@@ -0,0 +1,92 @@
+package collection
+
+import "sync"
+
+// SafeMapG is the generic variant of SafeMap, it avoids boxing the keys
+// and the values, and the type assertions on every read.
+// This implementation is not needed until issue below fixed.
+// https://github.com/golang/go/issues/20135
+type SafeMapG[K comparable, V any] struct {
+	lock        sync.RWMutex
+	deletionOld int
+	deletionNew int
+	dirtyOld    map[K]V
+	dirtyNew    map[K]V
+}
+
+// NewSafeMapG returns a SafeMapG.
+func NewSafeMapG[K comparable, V any]() *SafeMapG[K, V] {
+	return &SafeMapG[K, V]{
+		dirtyOld: make(map[K]V),
+		dirtyNew: make(map[K]V),
+	}
+}
+
+// Del deletes the value with the given key from m.
+func (m *SafeMapG[K, V]) Del(key K) {
+	m.lock.Lock()
+	if _, ok := m.dirtyOld[key]; ok {
+		delete(m.dirtyOld, key)
+		m.deletionOld++
+	} else if _, ok := m.dirtyNew[key]; ok {
+		delete(m.dirtyNew, key)
+		m.deletionNew++
+	}
+	if m.deletionOld >= maxDeletion && len(m.dirtyOld) < copyThreshold {
+		for k, v := range m.dirtyOld {
+			m.dirtyNew[k] = v
+		}
+		m.dirtyOld = m.dirtyNew
+		m.deletionOld = m.deletionNew
+		m.dirtyNew = make(map[K]V)
+		m.deletionNew = 0
+	}
+	if m.deletionNew >= maxDeletion && len(m.dirtyNew) < copyThreshold {
+		for k, v := range m.dirtyNew {
+			m.dirtyOld[k] = v
+		}
+		m.dirtyNew = make(map[K]V)
+		m.deletionNew = 0
+	}
+	m.lock.Unlock()
+}
+
+// Get gets the value with the given key from m.
+func (m *SafeMapG[K, V]) Get(key K) (V, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if val, ok := m.dirtyOld[key]; ok {
+		return val, true
+	}
+
+	val, ok := m.dirtyNew[key]
+	return val, ok
+}
+
+// Set sets the value into m with the given key.
+func (m *SafeMapG[K, V]) Set(key K, value V) {
+	m.lock.Lock()
+	if m.deletionOld <= maxDeletion {
+		if _, ok := m.dirtyNew[key]; ok {
+			delete(m.dirtyNew, key)
+			m.deletionNew++
+		}
+		m.dirtyOld[key] = value
+	} else {
+		if _, ok := m.dirtyOld[key]; ok {
+			delete(m.dirtyOld, key)
+			m.deletionOld++
+		}
+		m.dirtyNew[key] = value
+	}
+	m.lock.Unlock()
+}
+
+// Size returns the size of m.
+func (m *SafeMapG[K, V]) Size() int {
+	m.lock.RLock()
+	size := len(m.dirtyOld) + len(m.dirtyNew)
+	m.lock.RUnlock()
+	return size
+}
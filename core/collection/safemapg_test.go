@@ -0,0 +1,43 @@
+package collection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeMapG(t *testing.T) {
+	m := NewSafeMapG[int, int]()
+	for i := 0; i < 100; i++ {
+		m.Set(i, i*i)
+	}
+
+	assert.Equal(t, 100, m.Size())
+	for i := 0; i < 100; i++ {
+		val, ok := m.Get(i)
+		assert.True(t, ok)
+		assert.Equal(t, i*i, val)
+	}
+
+	for i := 0; i < 50; i++ {
+		m.Del(i)
+	}
+	assert.Equal(t, 50, m.Size())
+	_, ok := m.Get(0)
+	assert.False(t, ok)
+}
+
+func TestSafeMapGSwitches(t *testing.T) {
+	m := NewSafeMapG[int, int]()
+	// trigger the dirty map compaction in both directions
+	for round := 0; round < 3; round++ {
+		for i := 0; i < maxDeletion+copyThreshold; i++ {
+			m.Set(i, i)
+		}
+		for i := 0; i < maxDeletion+copyThreshold; i++ {
+			m.Del(i)
+		}
+	}
+
+	assert.Equal(t, 0, m.Size())
+}
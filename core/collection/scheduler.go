@@ -0,0 +1,312 @@
+package collection
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	cronFields = 5
+	// give up searching the next cron fire time after this range,
+	// an empty schedule like "* * 30 2 *" never fires
+	cronSearchYears = 4
+)
+
+// ErrScheduleNever indicates that a schedule has no future fire time.
+var ErrScheduleNever = errors.New("schedule never fires")
+
+type (
+	// A Schedule determines the next fire time of a repeating task.
+	Schedule interface {
+		// Next returns the next fire time after t, the zero time means never.
+		Next(t time.Time) time.Time
+	}
+
+	// A Scheduler runs repeating tasks on a shared TimingWheel,
+	// so lightweight in-process scheduling doesn't need a cron library.
+	Scheduler struct {
+		timingWheel *TimingWheel
+		precision   time.Duration
+	}
+
+	// A ScheduledTask is the cancel handle of a repeating task.
+	ScheduledTask struct {
+		scheduler *Scheduler
+		schedule  Schedule
+		fn        func()
+		lock      sync.Mutex
+		next      time.Time
+		paused    int32
+		canceled  int32
+	}
+
+	intervalSchedule struct {
+		interval time.Duration
+	}
+
+	cronSchedule struct {
+		minutes uint64
+		hours   uint64
+		doms    uint64
+		months  uint64
+		dows    uint64
+		domStar bool
+		dowStar bool
+	}
+)
+
+// NewScheduler returns a Scheduler that fires with the given precision.
+func NewScheduler(precision time.Duration, slots int) (*Scheduler, error) {
+	scheduler := &Scheduler{
+		precision: precision,
+	}
+
+	timingWheel, err := NewTimingWheel(precision, slots, func(k, v interface{}) {
+		task, ok := v.(*ScheduledTask)
+		if !ok {
+			return
+		}
+
+		task.run()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scheduler.timingWheel = timingWheel
+	return scheduler, nil
+}
+
+// Cron schedules fn with the given cron expression, like "*/5 * * * *".
+func (s *Scheduler) Cron(expr string, fn func()) (*ScheduledTask, error) {
+	schedule, err := ParseCron(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Schedule(schedule, fn)
+}
+
+// Every schedules fn to run with the given interval.
+func (s *Scheduler) Every(interval time.Duration, fn func()) (*ScheduledTask, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval %v not positive", interval)
+	}
+
+	return s.Schedule(IntervalSchedule(interval), fn)
+}
+
+// IntervalSchedule returns a Schedule that fires with the given fixed interval.
+func IntervalSchedule(interval time.Duration) Schedule {
+	return intervalSchedule{interval: interval}
+}
+
+// Schedule schedules fn with the given schedule.
+func (s *Scheduler) Schedule(schedule Schedule, fn func()) (*ScheduledTask, error) {
+	now := time.Now()
+	next := schedule.Next(now)
+	if next.IsZero() {
+		return nil, ErrScheduleNever
+	}
+
+	task := &ScheduledTask{
+		scheduler: s,
+		schedule:  schedule,
+		fn:        fn,
+		next:      next,
+	}
+	s.setTimer(task, time.Until(next))
+
+	return task, nil
+}
+
+// Stop stops s and all its tasks.
+func (s *Scheduler) Stop() {
+	s.timingWheel.Stop()
+}
+
+func (s *Scheduler) setTimer(task *ScheduledTask, delay time.Duration) {
+	if delay < s.precision {
+		delay = s.precision
+	}
+	s.timingWheel.SetTimer(task, task, delay)
+}
+
+// Cancel cancels the task, it can't be resumed afterwards.
+func (t *ScheduledTask) Cancel() {
+	atomic.StoreInt32(&t.canceled, 1)
+	t.scheduler.timingWheel.RemoveTimer(t)
+}
+
+// Pause pauses the task, the schedule keeps ticking but fn is not called.
+func (t *ScheduledTask) Pause() {
+	atomic.StoreInt32(&t.paused, 1)
+}
+
+// Resume resumes a paused task.
+func (t *ScheduledTask) Resume() {
+	atomic.StoreInt32(&t.paused, 0)
+}
+
+func (t *ScheduledTask) run() {
+	if atomic.LoadInt32(&t.canceled) != 0 {
+		return
+	}
+
+	// advance from the scheduled time, not the actual fire time,
+	// so the execution delays don't accumulate as drift.
+	t.lock.Lock()
+	next := t.schedule.Next(t.next)
+	now := time.Now()
+	if !next.After(now) {
+		// the fires were missed, realign with the wall clock
+		next = t.schedule.Next(now)
+	}
+	t.next = next
+	t.lock.Unlock()
+
+	if !next.IsZero() {
+		t.scheduler.setTimer(t, time.Until(next))
+	}
+
+	if atomic.LoadInt32(&t.paused) == 0 {
+		t.fn()
+	}
+}
+
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+// ParseCron parses a standard 5-field cron expression,
+// the fields are minute, hour, day of month, month and day of week,
+// each supports "*", lists, ranges and steps, like "*/15 8-18 * * 1-5".
+func ParseCron(expr string) (Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != cronFields {
+		return nil, fmt.Errorf("cron expression %q should have %d fields", expr, cronFields)
+	}
+
+	var schedule cronSchedule
+	var err error
+	if schedule.minutes, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, err
+	}
+	if schedule.hours, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, err
+	}
+	if schedule.doms, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, err
+	}
+	if schedule.months, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, err
+	}
+	if schedule.dows, err = parseCronField(fields[4], 0, 6); err != nil {
+		return nil, err
+	}
+	schedule.domStar = fields[2] == "*"
+	schedule.dowStar = fields[4] == "*"
+
+	return schedule, nil
+}
+
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rangeExpr := part
+		step := 1
+		if index := strings.Index(part, "/"); index >= 0 {
+			rangeExpr = part[:index]
+			val, err := strconv.Atoi(part[index+1:])
+			if err != nil || val < 1 {
+				return 0, fmt.Errorf("bad cron step in %q", part)
+			}
+			step = val
+		}
+
+		lo, hi := min, max
+		switch {
+		case rangeExpr == "*":
+		case strings.Contains(rangeExpr, "-"):
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			val, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				return 0, fmt.Errorf("bad cron range in %q", part)
+			}
+			lo = val
+			if val, err = strconv.Atoi(bounds[1]); err != nil {
+				return 0, fmt.Errorf("bad cron range in %q", part)
+			}
+			hi = val
+		default:
+			val, err := strconv.Atoi(rangeExpr)
+			if err != nil {
+				return 0, fmt.Errorf("bad cron value in %q", part)
+			}
+			lo = val
+			if strings.Contains(part, "/") {
+				// "n/step" means starting from n, like "0/5"
+				hi = max
+			} else {
+				hi = val
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("cron value %q out of range [%d, %d]", part, min, max)
+		}
+
+		for i := lo; i <= hi; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, nil
+}
+
+func (s cronSchedule) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := next.AddDate(cronSearchYears, 0, 0)
+
+	for next.Before(limit) {
+		if s.months&(1<<uint(next.Month())) == 0 {
+			// jump to the first day of the next month
+			next = time.Date(next.Year(), next.Month(), 1, 0, 0, 0, 0, next.Location())
+			next = next.AddDate(0, 1, 0)
+			continue
+		}
+		if !s.dayMatches(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, next.Location())
+			next = next.AddDate(0, 0, 1)
+			continue
+		}
+		if s.hours&(1<<uint(next.Hour())) == 0 {
+			next = next.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if s.minutes&(1<<uint(next.Minute())) == 0 {
+			next = next.Add(time.Minute)
+			continue
+		}
+
+		return next
+	}
+
+	return time.Time{}
+}
+
+func (s cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.doms&(1<<uint(t.Day())) != 0
+	dowMatch := s.dows&(1<<uint(t.Weekday())) != 0
+	// standard cron, either matches when both fields are restricted
+	if !s.domStar && !s.dowStar {
+		return domMatch || dowMatch
+	}
+
+	return domMatch && dowMatch
+}
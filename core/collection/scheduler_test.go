@@ -0,0 +1,126 @@
+package collection
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCron(t *testing.T) {
+	_, err := ParseCron("* * * *")
+	assert.NotNil(t, err)
+	_, err = ParseCron("61 * * * *")
+	assert.NotNil(t, err)
+	_, err = ParseCron("* * * * a")
+	assert.NotNil(t, err)
+	_, err = ParseCron("5-2 * * * *")
+	assert.NotNil(t, err)
+	_, err = ParseCron("*/0 * * * *")
+	assert.NotNil(t, err)
+
+	tests := []struct {
+		expr string
+		from time.Time
+		next time.Time
+	}{
+		{
+			expr: "*/15 * * * *",
+			from: time.Date(2021, 1, 1, 10, 7, 30, 0, time.UTC),
+			next: time.Date(2021, 1, 1, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			expr: "0 0 * * *",
+			from: time.Date(2021, 1, 1, 10, 7, 0, 0, time.UTC),
+			next: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			expr: "30 8 1 * *",
+			from: time.Date(2021, 1, 15, 10, 0, 0, 0, time.UTC),
+			next: time.Date(2021, 2, 1, 8, 30, 0, 0, time.UTC),
+		},
+		{
+			// 2021-01-01 is a Friday
+			expr: "0 9 * * 1-5",
+			from: time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC),
+			next: time.Date(2021, 1, 4, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			expr: "0,30 9 * * *",
+			from: time.Date(2021, 1, 1, 9, 10, 0, 0, time.UTC),
+			next: time.Date(2021, 1, 1, 9, 30, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			schedule, err := ParseCron(test.expr)
+			assert.Nil(t, err)
+			assert.Equal(t, test.next, schedule.Next(test.from))
+		})
+	}
+}
+
+func TestCronNever(t *testing.T) {
+	schedule, err := ParseCron("* * 30 2 *")
+	assert.Nil(t, err)
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+
+	scheduler, err := NewScheduler(time.Millisecond*10, 30)
+	assert.Nil(t, err)
+	defer scheduler.Stop()
+
+	_, err = scheduler.Cron("* * 30 2 *", func() {})
+	assert.Equal(t, ErrScheduleNever, err)
+}
+
+func TestSchedulerEvery(t *testing.T) {
+	scheduler, err := NewScheduler(time.Millisecond*10, 30)
+	assert.Nil(t, err)
+	defer scheduler.Stop()
+
+	_, err = scheduler.Every(0, func() {})
+	assert.NotNil(t, err)
+
+	var count int32
+	task, err := scheduler.Every(time.Millisecond*50, func() {
+		atomic.AddInt32(&count, 1)
+	})
+	assert.Nil(t, err)
+
+	time.Sleep(time.Millisecond * 300)
+	task.Cancel()
+	assert.True(t, atomic.LoadInt32(&count) >= 2)
+
+	// no more fires after cancel
+	final := atomic.LoadInt32(&count)
+	time.Sleep(time.Millisecond * 200)
+	assert.True(t, atomic.LoadInt32(&count) <= final+1)
+}
+
+func TestSchedulerPauseResume(t *testing.T) {
+	scheduler, err := NewScheduler(time.Millisecond*10, 30)
+	assert.Nil(t, err)
+	defer scheduler.Stop()
+
+	var count int32
+	task, err := scheduler.Every(time.Millisecond*50, func() {
+		atomic.AddInt32(&count, 1)
+	})
+	assert.Nil(t, err)
+	defer task.Cancel()
+
+	task.Pause()
+	time.Sleep(time.Millisecond * 200)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&count))
+
+	task.Resume()
+	time.Sleep(time.Millisecond * 300)
+	assert.True(t, atomic.LoadInt32(&count) >= 2)
+}
+
+func TestSchedulerBadPrecision(t *testing.T) {
+	_, err := NewScheduler(0, 30)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,202 @@
+package collection
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/tal-tech/go-zero/core/lang"
+)
+
+// A SetG is the generic variant of Set, it's not thread-safe,
+// use ConcurrentSetG for concurrent use.
+type SetG[T comparable] struct {
+	data map[T]lang.PlaceholderType
+}
+
+// NewSetG returns a SetG with the given items.
+func NewSetG[T comparable](items ...T) *SetG[T] {
+	set := &SetG[T]{
+		data: make(map[T]lang.PlaceholderType, len(items)),
+	}
+	set.Add(items...)
+
+	return set
+}
+
+// Add adds items into s.
+func (s *SetG[T]) Add(items ...T) {
+	for _, item := range items {
+		s.data[item] = lang.Placeholder
+	}
+}
+
+// Contains checks if s contains item.
+func (s *SetG[T]) Contains(item T) bool {
+	_, ok := s.data[item]
+	return ok
+}
+
+// Count returns the number of items in s.
+func (s *SetG[T]) Count() int {
+	return len(s.data)
+}
+
+// Diff returns a new set with the items in s but not in other.
+func (s *SetG[T]) Diff(other *SetG[T]) *SetG[T] {
+	result := NewSetG[T]()
+	for item := range s.data {
+		if !other.Contains(item) {
+			result.Add(item)
+		}
+	}
+
+	return result
+}
+
+// Intersect returns a new set with the items both in s and other.
+func (s *SetG[T]) Intersect(other *SetG[T]) *SetG[T] {
+	result := NewSetG[T]()
+	for item := range s.data {
+		if other.Contains(item) {
+			result.Add(item)
+		}
+	}
+
+	return result
+}
+
+// Keys returns the items in s, in no particular order.
+func (s *SetG[T]) Keys() []T {
+	keys := make([]T, 0, len(s.data))
+	for item := range s.data {
+		keys = append(keys, item)
+	}
+
+	return keys
+}
+
+// Remove removes item from s.
+func (s *SetG[T]) Remove(item T) {
+	delete(s.data, item)
+}
+
+// Union returns a new set with the items either in s or other.
+func (s *SetG[T]) Union(other *SetG[T]) *SetG[T] {
+	result := NewSetG(s.Keys()...)
+	result.Add(other.Keys()...)
+
+	return result
+}
+
+// MarshalJSON marshals s as a JSON array, in no particular order.
+func (s *SetG[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Keys())
+}
+
+// UnmarshalJSON unmarshals a JSON array into s.
+func (s *SetG[T]) UnmarshalJSON(data []byte) error {
+	var keys []T
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+
+	if s.data == nil {
+		s.data = make(map[T]lang.PlaceholderType, len(keys))
+	}
+	s.Add(keys...)
+
+	return nil
+}
+
+// A ConcurrentSetG is the thread-safe variant of SetG.
+type ConcurrentSetG[T comparable] struct {
+	lock sync.RWMutex
+	set  *SetG[T]
+}
+
+// NewConcurrentSetG returns a ConcurrentSetG with the given items.
+func NewConcurrentSetG[T comparable](items ...T) *ConcurrentSetG[T] {
+	return &ConcurrentSetG[T]{
+		set: NewSetG(items...),
+	}
+}
+
+// Add adds items into s.
+func (s *ConcurrentSetG[T]) Add(items ...T) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.set.Add(items...)
+}
+
+// Contains checks if s contains item.
+func (s *ConcurrentSetG[T]) Contains(item T) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.Contains(item)
+}
+
+// Count returns the number of items in s.
+func (s *ConcurrentSetG[T]) Count() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.Count()
+}
+
+// Diff returns a new set with the items in s but not in other.
+func (s *ConcurrentSetG[T]) Diff(other *ConcurrentSetG[T]) *SetG[T] {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	other.lock.RLock()
+	defer other.lock.RUnlock()
+	return s.set.Diff(other.set)
+}
+
+// Intersect returns a new set with the items both in s and other.
+func (s *ConcurrentSetG[T]) Intersect(other *ConcurrentSetG[T]) *SetG[T] {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	other.lock.RLock()
+	defer other.lock.RUnlock()
+	return s.set.Intersect(other.set)
+}
+
+// Keys returns the items in s, in no particular order.
+func (s *ConcurrentSetG[T]) Keys() []T {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.Keys()
+}
+
+// Remove removes item from s.
+func (s *ConcurrentSetG[T]) Remove(item T) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.set.Remove(item)
+}
+
+// Union returns a new set with the items either in s or other.
+func (s *ConcurrentSetG[T]) Union(other *ConcurrentSetG[T]) *SetG[T] {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	other.lock.RLock()
+	defer other.lock.RUnlock()
+	return s.set.Union(other.set)
+}
+
+// MarshalJSON marshals s as a JSON array, in no particular order.
+func (s *ConcurrentSetG[T]) MarshalJSON() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.MarshalJSON()
+}
+
+// UnmarshalJSON unmarshals a JSON array into s.
+func (s *ConcurrentSetG[T]) UnmarshalJSON(data []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.set == nil {
+		s.set = NewSetG[T]()
+	}
+	return s.set.UnmarshalJSON(data)
+}
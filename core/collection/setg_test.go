@@ -0,0 +1,80 @@
+package collection
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetG(t *testing.T) {
+	set := NewSetG(1, 2, 3, 2)
+	assert.Equal(t, 3, set.Count())
+	assert.True(t, set.Contains(2))
+	assert.False(t, set.Contains(4))
+
+	set.Add(4)
+	assert.True(t, set.Contains(4))
+	set.Remove(4)
+	assert.False(t, set.Contains(4))
+	assert.ElementsMatch(t, []int{1, 2, 3}, set.Keys())
+}
+
+func TestSetGOperations(t *testing.T) {
+	a := NewSetG(1, 2, 3)
+	b := NewSetG(2, 3, 4)
+
+	assert.ElementsMatch(t, []int{1, 2, 3, 4}, a.Union(b).Keys())
+	assert.ElementsMatch(t, []int{2, 3}, a.Intersect(b).Keys())
+	assert.ElementsMatch(t, []int{1}, a.Diff(b).Keys())
+	assert.ElementsMatch(t, []int{4}, b.Diff(a).Keys())
+}
+
+func TestSetGJson(t *testing.T) {
+	set := NewSetG("a", "b", "c")
+	data, err := json.Marshal(set)
+	assert.Nil(t, err)
+
+	var actual SetG[string]
+	assert.Nil(t, json.Unmarshal(data, &actual))
+	assert.ElementsMatch(t, set.Keys(), actual.Keys())
+}
+
+func TestConcurrentSetG(t *testing.T) {
+	set := NewConcurrentSetG[int]()
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			set.Add(v)
+			set.Contains(v)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 100, set.Count())
+	set.Remove(0)
+	assert.Equal(t, 99, set.Count())
+	assert.False(t, set.Contains(0))
+}
+
+func TestConcurrentSetGOperations(t *testing.T) {
+	a := NewConcurrentSetG(1, 2, 3)
+	b := NewConcurrentSetG(2, 3, 4)
+
+	assert.ElementsMatch(t, []int{1, 2, 3, 4}, a.Union(b).Keys())
+	assert.ElementsMatch(t, []int{2, 3}, a.Intersect(b).Keys())
+	assert.ElementsMatch(t, []int{1}, a.Diff(b).Keys())
+}
+
+func TestConcurrentSetGJson(t *testing.T) {
+	set := NewConcurrentSetG(1, 2, 3)
+	data, err := json.Marshal(set)
+	assert.Nil(t, err)
+
+	var actual ConcurrentSetG[int]
+	assert.Nil(t, json.Unmarshal(data, &actual))
+	assert.ElementsMatch(t, set.Keys(), actual.Keys())
+}
@@ -65,6 +65,17 @@ func NewTimingWheel(interval time.Duration, numSlots int, execute Execute) (*Tim
 	return newTimingWheelWithClock(interval, numSlots, execute, timex.NewTicker(interval))
 }
 
+// NewTimingWheelWithTicker returns a TimingWheel driven by the given ticker,
+// which makes the wheel testable with a fake ticker.
+func NewTimingWheelWithTicker(interval time.Duration, numSlots int, execute Execute,
+	ticker timex.Ticker) (*TimingWheel, error) {
+	if interval <= 0 || numSlots <= 0 || execute == nil {
+		return nil, fmt.Errorf("interval: %v, slots: %d, execute: %p", interval, numSlots, execute)
+	}
+
+	return newTimingWheelWithClock(interval, numSlots, execute, ticker)
+}
+
 func newTimingWheelWithClock(interval time.Duration, numSlots int, execute Execute, ticker timex.Ticker) (
 	*TimingWheel, error) {
 	tw := &TimingWheel{
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
-	"os"
 	"path"
 
 	"github.com/tal-tech/go-zero/core/mapping"
@@ -34,10 +33,18 @@ func LoadConfig(file string, v interface{}, opts ...Option) error {
 	}
 
 	if opt.env {
-		return loader([]byte(os.ExpandEnv(string(content))), v)
+		content = []byte(expandEnv(string(content)))
 	}
 
-	return loader(content, v)
+	if err := loader(content, v); err != nil {
+		return err
+	}
+
+	if opt.envOverride {
+		return fillWithEnvOverrides(v)
+	}
+
+	return nil
 }
 
 // LoadConfigFromJsonBytes loads config into v from content json bytes.
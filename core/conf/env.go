@@ -0,0 +1,149 @@
+package conf
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	envDefaultSep = ":"
+	envKeySep     = "_"
+	jsonTagKey    = "json"
+	jsonTagSep    = ','
+)
+
+// expandEnv replaces ${var} or $var in s with the values of the environment variables,
+// ${var:default} falls back to default when var is not set.
+func expandEnv(s string) string {
+	return os.Expand(s, func(name string) string {
+		key := name
+		var def string
+		var hasDef bool
+		if index := strings.Index(name, envDefaultSep); index >= 0 {
+			key = name[:index]
+			def = name[index+1:]
+			hasDef = true
+		}
+
+		if value, ok := os.LookupEnv(key); ok {
+			return value
+		}
+		if hasDef {
+			return def
+		}
+
+		return ""
+	})
+}
+
+// fillWithEnvOverrides overrides the fields of v with matching environment variables,
+// the variable name is the uppercase field path joined with underscores,
+// e.g. REDIS_PASS overrides the Redis.Pass field.
+func fillWithEnvOverrides(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("env overrides: a pointer to struct expected, got %T", v)
+	}
+
+	return overrideStruct(rv.Elem(), nil)
+}
+
+func overrideStruct(val reflect.Value, path []string) error {
+	tp := val.Type()
+	for i := 0; i < tp.NumField(); i++ {
+		field := tp.Field(i)
+		if len(field.PkgPath) > 0 {
+			// unexported fields
+			continue
+		}
+
+		value := val.Field(i)
+		if field.Anonymous && value.Kind() == reflect.Struct {
+			// embedded fields are inlined, like in json
+			if err := overrideStruct(value, path); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		fieldPath := append(path, fieldTagName(field))
+		if value.Kind() == reflect.Struct {
+			if err := overrideStruct(value, fieldPath); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		key := strings.ToUpper(strings.Join(fieldPath, envKeySep))
+		env, ok := os.LookupEnv(key)
+		if !ok {
+			continue
+		}
+
+		if err := setFieldValue(value, env); err != nil {
+			return fmt.Errorf("env overrides: %s: %s", key, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func fieldTagName(field reflect.StructField) string {
+	tag, ok := field.Tag.Lookup(jsonTagKey)
+	if !ok {
+		return field.Name
+	}
+
+	if index := strings.IndexByte(tag, jsonTagSep); index >= 0 {
+		tag = tag[:index]
+	}
+	if len(tag) == 0 {
+		return field.Name
+	}
+
+	return tag
+}
+
+func setFieldValue(value reflect.Value, env string) error {
+	if !value.CanSet() {
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(env)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(env)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(env, 10, 64)
+		if err != nil {
+			return err
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(env, 10, 64)
+		if err != nil {
+			return err
+		}
+		value.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(env, 64)
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	default:
+		// slices, maps and pointers are not overridable from a single variable
+	}
+
+	return nil
+}
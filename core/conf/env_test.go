@@ -0,0 +1,104 @@
+package conf
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandEnv(t *testing.T) {
+	os.Setenv("CONF_EXPAND_FOO", "foo")
+	defer os.Unsetenv("CONF_EXPAND_FOO")
+
+	assert.Equal(t, "foo", expandEnv("${CONF_EXPAND_FOO}"))
+	assert.Equal(t, "foo", expandEnv("${CONF_EXPAND_FOO:bar}"))
+	assert.Equal(t, "bar", expandEnv("${CONF_EXPAND_NOT_SET:bar}"))
+	assert.Equal(t, "", expandEnv("${CONF_EXPAND_NOT_SET}"))
+	assert.Equal(t, "localhost:6379", expandEnv("${CONF_EXPAND_NOT_SET:localhost:6379}"))
+}
+
+func TestLoadConfigWithDefault(t *testing.T) {
+	text := `{
+	"a": "${CONF_DEFAULT_NOT_SET:foo}",
+	"b": "${CONF_DEFAULT_B:1}"
+}`
+	os.Setenv("CONF_DEFAULT_B", "2")
+	defer os.Unsetenv("CONF_DEFAULT_B")
+	tmpfile, err := createTempFile(".json", text)
+	assert.Nil(t, err)
+	defer os.Remove(tmpfile)
+
+	var val struct {
+		A string `json:"a"`
+		B string `json:"b"`
+	}
+	assert.Nil(t, LoadConfig(tmpfile, &val, UseEnv()))
+	assert.Equal(t, "foo", val.A)
+	assert.Equal(t, "2", val.B)
+}
+
+func TestLoadConfigWithEnvOverrides(t *testing.T) {
+	text := `{
+	"name": "foo",
+	"redis": {
+		"host": "localhost",
+		"pass": ""
+	}
+}`
+	os.Setenv("REDIS_PASS", "secret")
+	os.Setenv("NAME", "bar")
+	defer func() {
+		os.Unsetenv("REDIS_PASS")
+		os.Unsetenv("NAME")
+	}()
+	tmpfile, err := createTempFile(".json", text)
+	assert.Nil(t, err)
+	defer os.Remove(tmpfile)
+
+	var val struct {
+		Name  string `json:"name"`
+		Redis struct {
+			Host string `json:"host"`
+			Pass string `json:"pass"`
+		} `json:"redis"`
+	}
+	assert.Nil(t, LoadConfig(tmpfile, &val, UseEnvOverrides()))
+	assert.Equal(t, "bar", val.Name)
+	assert.Equal(t, "localhost", val.Redis.Host)
+	assert.Equal(t, "secret", val.Redis.Pass)
+}
+
+func TestFillWithEnvOverridesEmbedded(t *testing.T) {
+	type inner struct {
+		Port int `json:",default=8080"`
+	}
+	var val struct {
+		inner2 struct{} // unexported fields are skipped
+		Inner  inner    `json:"inner"`
+		Conf   struct {
+			Mode string `json:",optional"`
+		}
+	}
+	os.Setenv("INNER_PORT", "9090")
+	os.Setenv("CONF_MODE", "pro")
+	defer func() {
+		os.Unsetenv("INNER_PORT")
+		os.Unsetenv("CONF_MODE")
+	}()
+
+	assert.Nil(t, fillWithEnvOverrides(&val))
+	assert.Equal(t, 9090, val.Inner.Port)
+	assert.Equal(t, "pro", val.Conf.Mode)
+}
+
+func TestFillWithEnvOverridesBadValue(t *testing.T) {
+	var val struct {
+		Port int `json:"port"`
+	}
+	os.Setenv("PORT", "not-a-number")
+	defer os.Unsetenv("PORT")
+
+	assert.NotNil(t, fillWithEnvOverrides(&val))
+	assert.NotNil(t, fillWithEnvOverrides(val))
+}
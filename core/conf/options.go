@@ -5,13 +5,24 @@ type (
 	Option func(opt *options)
 
 	options struct {
-		env bool
+		env         bool
+		envOverride bool
 	}
 )
 
-// UseEnv customizes the config to use environment variables.
+// UseEnv customizes the config to use environment variables,
+// ${var} and ${var:default} in the config file are substituted at load time.
 func UseEnv() Option {
 	return func(opt *options) {
 		opt.env = true
 	}
 }
+
+// UseEnvOverrides customizes the config to override fields from environment variables
+// after loading, the variable name is the uppercase field path joined with underscores,
+// e.g. REDIS_PASS overrides the Redis.Pass field.
+func UseEnvOverrides() Option {
+	return func(opt *options) {
+		opt.envOverride = true
+	}
+}
@@ -2,7 +2,6 @@ package conf
 
 import (
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 	"sync"
@@ -57,7 +56,7 @@ func LoadProperties(filename string, opts ...Option) (Properties, error) {
 		key := strings.TrimSpace(pair[0])
 		value := strings.TrimSpace(pair[1])
 		if opt.env {
-			raw[key] = os.ExpandEnv(value)
+			raw[key] = expandEnv(value)
 		} else {
 			raw[key] = value
 		}
@@ -57,6 +57,11 @@ func (be *BulkExecutor) Wait() {
 	be.executor.Wait()
 }
 
+// WaitForFlush flushes the pending tasks and waits up to timeout for the executions.
+func (be *BulkExecutor) WaitForFlush(timeout time.Duration) bool {
+	return be.executor.WaitForFlush(timeout)
+}
+
 // WithBulkTasks customizes a BulkExecutor with given tasks limit.
 func WithBulkTasks(tasks int) BulkOption {
 	return func(options *bulkOptions) {
@@ -60,6 +60,11 @@ func (ce *ChunkExecutor) Wait() {
 	ce.executor.Wait()
 }
 
+// WaitForFlush flushes the pending tasks and waits up to timeout for the executions.
+func (ce *ChunkExecutor) WaitForFlush(timeout time.Duration) bool {
+	return ce.executor.WaitForFlush(timeout)
+}
+
 // WithChunkBytes customizes a ChunkExecutor with the given chunk size.
 func WithChunkBytes(size int) ChunkOption {
 	return func(options *chunkOptions) {
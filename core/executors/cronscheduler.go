@@ -0,0 +1,226 @@
+package executors
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/collection"
+	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/threading"
+	"github.com/tal-tech/go-zero/core/timex"
+)
+
+const (
+	defaultCronPrecision = time.Second
+	cronSlots            = 300
+)
+
+// OverlapPolicy defines what to do when a job fires while
+// its previous run is still going.
+type OverlapPolicy int
+
+const (
+	// OverlapSkip drops the fire, it's the default.
+	OverlapSkip OverlapPolicy = iota
+	// OverlapQueue runs once more right after the current run,
+	// at most one run is queued.
+	OverlapQueue
+)
+
+type (
+	// CronJobOption defines the method to customize a cron job.
+	CronJobOption func(job *cronJob)
+
+	// CronSchedulerOption defines the method to customize a CronScheduler.
+	CronSchedulerOption func(scheduler *CronScheduler)
+
+	// A CronScheduler runs the registered jobs on cron expressions or
+	// fixed intervals, it implements service.Service so it can be added
+	// into a service.ServiceGroup.
+	CronScheduler struct {
+		precision time.Duration
+		scheduler *collection.Scheduler
+		lock      sync.Mutex
+		jobs      []*cronJob
+		started   bool
+		doneChan  chan lang.PlaceholderType
+		stopOnce  sync.Once
+	}
+
+	cronJob struct {
+		name     string
+		schedule collection.Schedule
+		run      func() error
+		overlap  OverlapPolicy
+		jitter   time.Duration
+		running  int32
+		queued   int32
+	}
+)
+
+// NewCronScheduler returns a CronScheduler.
+func NewCronScheduler(opts ...CronSchedulerOption) (*CronScheduler, error) {
+	scheduler := &CronScheduler{
+		precision: defaultCronPrecision,
+		doneChan:  make(chan lang.PlaceholderType),
+	}
+	for _, opt := range opts {
+		opt(scheduler)
+	}
+
+	inner, err := collection.NewScheduler(scheduler.precision, cronSlots)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduler.scheduler = inner
+	return scheduler, nil
+}
+
+// WithCronPrecision customizes a CronScheduler with the given fire precision.
+func WithCronPrecision(precision time.Duration) CronSchedulerOption {
+	return func(scheduler *CronScheduler) {
+		if precision > 0 {
+			scheduler.precision = precision
+		}
+	}
+}
+
+// WithJobJitter delays each fire by a random duration up to jitter,
+// so the jobs on many instances don't hit the downstreams together.
+func WithJobJitter(jitter time.Duration) CronJobOption {
+	return func(job *cronJob) {
+		if jitter > 0 {
+			job.jitter = jitter
+		}
+	}
+}
+
+// WithOverlapPolicy customizes a job with the given overlap policy.
+func WithOverlapPolicy(policy OverlapPolicy) CronJobOption {
+	return func(job *cronJob) {
+		job.overlap = policy
+	}
+}
+
+// AddCronJob registers fn to run on the given cron expression.
+func (s *CronScheduler) AddCronJob(name, expr string, fn func() error, opts ...CronJobOption) error {
+	schedule, err := collection.ParseCron(expr)
+	if err != nil {
+		return err
+	}
+
+	return s.addJob(name, schedule, fn, opts...)
+}
+
+// AddIntervalJob registers fn to run with the given fixed interval.
+func (s *CronScheduler) AddIntervalJob(name string, interval time.Duration, fn func() error,
+	opts ...CronJobOption) error {
+	if interval <= 0 {
+		return fmt.Errorf("interval %v not positive", interval)
+	}
+
+	return s.addJob(name, collection.IntervalSchedule(interval), fn, opts...)
+}
+
+// Start starts s, it blocks until Stop is called,
+// as required by service.ServiceGroup.
+func (s *CronScheduler) Start() {
+	s.lock.Lock()
+	if !s.started {
+		s.started = true
+		for _, job := range s.jobs {
+			s.armJob(job)
+		}
+	}
+	s.lock.Unlock()
+
+	<-s.doneChan
+}
+
+// Stop stops s and all its jobs.
+func (s *CronScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		s.scheduler.Stop()
+		close(s.doneChan)
+	})
+}
+
+func (s *CronScheduler) addJob(name string, schedule collection.Schedule, fn func() error,
+	opts ...CronJobOption) error {
+	job := &cronJob{
+		name:     name,
+		schedule: schedule,
+		run:      fn,
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.jobs = append(s.jobs, job)
+	if s.started {
+		return s.armJob(job)
+	}
+
+	return nil
+}
+
+func (s *CronScheduler) armJob(job *cronJob) error {
+	_, err := s.scheduler.Schedule(job.schedule, func() {
+		threading.GoSafe(job.fire)
+	})
+	if err != nil {
+		logx.Errorf("cron job %s not scheduled: %v", job.name, err)
+	}
+
+	return err
+}
+
+func (j *cronJob) execute() {
+	start := timex.Now()
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		err = j.run()
+	}()
+
+	metricCronRuns.Inc(j.name)
+	metricCronDuration.Observe(int64(timex.Since(start)/time.Millisecond), j.name)
+	if err != nil {
+		metricCronFails.Inc(j.name)
+		logx.Errorf("cron job %s failed: %v", j.name, err)
+	}
+}
+
+func (j *cronJob) fire() {
+	if j.jitter > 0 {
+		time.Sleep(time.Duration(rand.Int63n(int64(j.jitter))))
+	}
+
+	if !atomic.CompareAndSwapInt32(&j.running, 0, 1) {
+		if j.overlap == OverlapQueue {
+			// at most one run is queued, the extra fires are dropped
+			atomic.StoreInt32(&j.queued, 1)
+		}
+		return
+	}
+
+	for {
+		j.execute()
+		if !atomic.CompareAndSwapInt32(&j.queued, 1, 0) {
+			break
+		}
+	}
+	atomic.StoreInt32(&j.running, 0)
+}
@@ -0,0 +1,115 @@
+package executors
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronSchedulerBadExpr(t *testing.T) {
+	scheduler, err := NewCronScheduler()
+	assert.Nil(t, err)
+	defer scheduler.Stop()
+
+	assert.NotNil(t, scheduler.AddCronJob("bad", "* * *", func() error {
+		return nil
+	}))
+	assert.NotNil(t, scheduler.AddIntervalJob("bad", 0, func() error {
+		return nil
+	}))
+}
+
+func TestCronSchedulerIntervalJob(t *testing.T) {
+	scheduler, err := NewCronScheduler(WithCronPrecision(time.Millisecond * 10))
+	assert.Nil(t, err)
+
+	var count int32
+	assert.Nil(t, scheduler.AddIntervalJob("counter", time.Millisecond*50, func() error {
+		atomic.AddInt32(&count, 1)
+		return nil
+	}))
+
+	go scheduler.Start()
+	time.Sleep(time.Millisecond * 300)
+	scheduler.Stop()
+
+	assert.True(t, atomic.LoadInt32(&count) >= 2)
+}
+
+func TestCronSchedulerOverlapSkip(t *testing.T) {
+	scheduler, err := NewCronScheduler(WithCronPrecision(time.Millisecond * 10))
+	assert.Nil(t, err)
+
+	var count int32
+	assert.Nil(t, scheduler.AddIntervalJob("slow", time.Millisecond*50, func() error {
+		atomic.AddInt32(&count, 1)
+		time.Sleep(time.Millisecond * 500)
+		return nil
+	}))
+
+	go scheduler.Start()
+	time.Sleep(time.Millisecond * 300)
+	scheduler.Stop()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+}
+
+func TestCronSchedulerOverlapQueue(t *testing.T) {
+	scheduler, err := NewCronScheduler(WithCronPrecision(time.Millisecond * 10))
+	assert.Nil(t, err)
+
+	var count int32
+	assert.Nil(t, scheduler.AddIntervalJob("slow", time.Millisecond*50, func() error {
+		atomic.AddInt32(&count, 1)
+		time.Sleep(time.Millisecond * 120)
+		return nil
+	}, WithOverlapPolicy(OverlapQueue)))
+
+	go scheduler.Start()
+	time.Sleep(time.Millisecond * 400)
+	scheduler.Stop()
+	time.Sleep(time.Millisecond * 200)
+
+	// the queued run executes right after the previous one
+	assert.True(t, atomic.LoadInt32(&count) >= 3)
+}
+
+func TestCronSchedulerPanicRecovery(t *testing.T) {
+	scheduler, err := NewCronScheduler(WithCronPrecision(time.Millisecond * 10))
+	assert.Nil(t, err)
+
+	var count int32
+	assert.Nil(t, scheduler.AddIntervalJob("panicky", time.Millisecond*50, func() error {
+		atomic.AddInt32(&count, 1)
+		panic("mocked panic")
+	}))
+
+	go scheduler.Start()
+	time.Sleep(time.Millisecond * 300)
+	scheduler.Stop()
+
+	// the panics don't kill the schedule
+	assert.True(t, atomic.LoadInt32(&count) >= 2)
+}
+
+func TestCronSchedulerAddAfterStart(t *testing.T) {
+	scheduler, err := NewCronScheduler(WithCronPrecision(time.Millisecond * 10))
+	assert.Nil(t, err)
+
+	go scheduler.Start()
+	time.Sleep(time.Millisecond * 50)
+
+	var count int32
+	assert.Nil(t, scheduler.AddIntervalJob("late", time.Millisecond*50, func() error {
+		atomic.AddInt32(&count, 1)
+		return errors.New("dummy")
+	}, WithJobJitter(time.Millisecond)))
+
+	time.Sleep(time.Millisecond * 300)
+	scheduler.Stop()
+
+	assert.True(t, atomic.LoadInt32(&count) >= 2)
+}
@@ -0,0 +1,194 @@
+package executors
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultRetryDelay   = time.Minute
+	defaultClaimBatch   = 100
+
+	// KEYS[1] as tasks zset, KEYS[2] as payloads hash,
+	// ARGV[1] as due time, ARGV[2] as task id, ARGV[3] as payload.
+	// NX keeps the earliest schedule, so a duplicate id is not re-delayed.
+	delaySubmitScript = `local added = redis.call("zadd", KEYS[1], "NX", ARGV[1], ARGV[2])
+if added == 1 then
+    redis.call("hset", KEYS[2], ARGV[2], ARGV[3])
+end
+return added`
+	// KEYS[1] as tasks zset, KEYS[2] as payloads hash,
+	// ARGV[1] as now, ARGV[2] as retry time, ARGV[3] as batch limit.
+	// The due tasks are re-scored instead of removed, so a crash between
+	// claiming and acking re-delivers them, which makes it at-least-once.
+	delayClaimScript = `local due = redis.call("zrangebyscore", KEYS[1], "-inf", ARGV[1], "LIMIT", 0, tonumber(ARGV[3]))
+local result = {}
+for _, id in ipairs(due) do
+    redis.call("zadd", KEYS[1], "XX", ARGV[2], id)
+    result[#result+1] = id
+    result[#result+1] = redis.call("hget", KEYS[2], id) or ""
+end
+return result`
+	// KEYS[1] as tasks zset, KEYS[2] as payloads hash, ARGV[1] as task id.
+	delayAckScript = `redis.call("zrem", KEYS[1], ARGV[1])
+redis.call("hdel", KEYS[2], ARGV[1])
+return 1`
+)
+
+type (
+	// A DelayStore is the storage that a DurableDelayExecutor persists
+	// the tasks into, *redis.Redis satisfies it. It's declared here
+	// instead of taking *redis.Redis, because stores/redis imports
+	// executors back through core/stat. Other stores can be plugged in
+	// as long as they run the scripts atomically.
+	DelayStore interface {
+		Eval(script string, keys []string, args ...interface{}) (interface{}, error)
+	}
+
+	// DurableDelayOption defines the method to customize a DurableDelayExecutor.
+	DurableDelayOption func(executor *DurableDelayExecutor)
+
+	// A DurableDelayExecutor is a DelayExecutor variant that persists the
+	// delayed tasks into redis, so the pending tasks survive restarts,
+	// the poller picks them up again on startup. The execution is
+	// at-least-once, the task ids let the handlers deduplicate after
+	// a crash recovery.
+	DurableDelayExecutor struct {
+		store      DelayStore
+		taskKey    string
+		payloadKey string
+		handler    func(id, payload string) error
+		interval   time.Duration
+		retryDelay time.Duration
+		batch      int
+		doneChan   chan lang.PlaceholderType
+		startOnce  sync.Once
+		stopOnce   sync.Once
+	}
+)
+
+// NewDurableDelayExecutor returns a DurableDelayExecutor with the given
+// store and key prefix, handler is called for each due task.
+func NewDurableDelayExecutor(store DelayStore, keyPrefix string,
+	handler func(id, payload string) error, opts ...DurableDelayOption) *DurableDelayExecutor {
+	executor := &DurableDelayExecutor{
+		store:      store,
+		taskKey:    keyPrefix + ":tasks",
+		payloadKey: keyPrefix + ":payloads",
+		handler:    handler,
+		interval:   defaultPollInterval,
+		retryDelay: defaultRetryDelay,
+		batch:      defaultClaimBatch,
+		doneChan:   make(chan lang.PlaceholderType),
+	}
+	for _, opt := range opts {
+		opt(executor)
+	}
+
+	return executor
+}
+
+// WithPollInterval customizes a DurableDelayExecutor with the given poll interval.
+func WithPollInterval(interval time.Duration) DurableDelayOption {
+	return func(executor *DurableDelayExecutor) {
+		if interval > 0 {
+			executor.interval = interval
+		}
+	}
+}
+
+// WithRetryDelay customizes how long a claimed but unacked task waits
+// before it's re-delivered.
+func WithRetryDelay(delay time.Duration) DurableDelayOption {
+	return func(executor *DurableDelayExecutor) {
+		if delay > 0 {
+			executor.retryDelay = delay
+		}
+	}
+}
+
+// Delay persists the task with the given id and payload to run after delay.
+// It returns false if a task with the same id is already pending,
+// the original schedule is kept in that case.
+func (de *DurableDelayExecutor) Delay(id, payload string, delay time.Duration) (bool, error) {
+	due := time.Now().Add(delay).UnixNano() / int64(time.Millisecond)
+	resp, err := de.store.Eval(delaySubmitScript, []string{de.taskKey, de.payloadKey},
+		strconv.FormatInt(due, 10), id, payload)
+	if err != nil {
+		return false, err
+	}
+
+	added, ok := resp.(int64)
+	return ok && added == 1, nil
+}
+
+// Start starts the background poller, safe to call more than once.
+func (de *DurableDelayExecutor) Start() {
+	de.startOnce.Do(func() {
+		threading.GoSafe(de.pollLoop)
+	})
+}
+
+// Stop stops the background poller.
+func (de *DurableDelayExecutor) Stop() {
+	de.stopOnce.Do(func() {
+		close(de.doneChan)
+	})
+}
+
+func (de *DurableDelayExecutor) ack(id string) {
+	if _, err := de.store.Eval(delayAckScript, []string{de.taskKey, de.payloadKey}, id); err != nil {
+		logx.Errorf("durable delay executor ack %s: %v", id, err)
+	}
+}
+
+func (de *DurableDelayExecutor) poll() {
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	retry := now + int64(de.retryDelay/time.Millisecond)
+	resp, err := de.store.Eval(delayClaimScript, []string{de.taskKey, de.payloadKey},
+		strconv.FormatInt(now, 10), strconv.FormatInt(retry, 10), strconv.Itoa(de.batch))
+	if err != nil {
+		logx.Errorf("durable delay executor poll: %v", err)
+		return
+	}
+
+	pairs, ok := resp.([]interface{})
+	if !ok {
+		return
+	}
+
+	for i := 0; i+1 < len(pairs); i += 2 {
+		id, ok := pairs[i].(string)
+		if !ok {
+			continue
+		}
+		payload, _ := pairs[i+1].(string)
+
+		if err := de.handler(id, payload); err != nil {
+			// leave the task claimed, it's re-delivered after the retry delay
+			logx.Errorf("durable delay executor task %s: %v", id, err)
+		} else {
+			de.ack(id)
+		}
+	}
+}
+
+func (de *DurableDelayExecutor) pollLoop() {
+	ticker := time.NewTicker(de.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-de.doneChan:
+			return
+		case <-ticker.C:
+			de.poll()
+		}
+	}
+}
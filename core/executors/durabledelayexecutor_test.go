@@ -0,0 +1,121 @@
+// an external test package, the in-package tests can't import redistest,
+// stores/redis imports executors back through core/stat
+package executors_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/executors"
+	"github.com/tal-tech/go-zero/core/stores/redis/redistest"
+)
+
+func TestDurableDelayExecutorDedup(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	executor := executors.NewDurableDelayExecutor(store, "delay", func(id, payload string) error {
+		return nil
+	})
+	defer executor.Stop()
+
+	added, err := executor.Delay("task1", "payload", time.Minute)
+	assert.Nil(t, err)
+	assert.True(t, added)
+
+	added, err = executor.Delay("task1", "other", time.Second)
+	assert.Nil(t, err)
+	assert.False(t, added)
+}
+
+func TestDurableDelayExecutorExecutes(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	var count int32
+	done := make(chan struct{})
+	executor := executors.NewDurableDelayExecutor(store, "delay", func(id, payload string) error {
+		assert.Equal(t, "task1", id)
+		assert.Equal(t, "payload", payload)
+		if atomic.AddInt32(&count, 1) == 1 {
+			close(done)
+		}
+		return nil
+	}, executors.WithPollInterval(time.Millisecond*10))
+	defer executor.Stop()
+
+	_, err = executor.Delay("task1", "payload", 0)
+	assert.Nil(t, err)
+	executor.Start()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 2):
+		t.Fatal("timeout waiting for execution")
+	}
+
+	// acked tasks are not re-delivered
+	time.Sleep(time.Millisecond * 100)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+}
+
+func TestDurableDelayExecutorRetry(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	var count int32
+	done := make(chan struct{})
+	executor := executors.NewDurableDelayExecutor(store, "delay", func(id, payload string) error {
+		if atomic.AddInt32(&count, 1) == 1 {
+			return errors.New("transient")
+		}
+
+		close(done)
+		return nil
+	}, executors.WithPollInterval(time.Millisecond*10), executors.WithRetryDelay(time.Millisecond*50))
+	defer executor.Stop()
+
+	_, err = executor.Delay("task1", "payload", 0)
+	assert.Nil(t, err)
+	executor.Start()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 2):
+		t.Fatal("timeout waiting for redelivery")
+	}
+}
+
+func TestDurableDelayExecutorRehydrates(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	// the first executor persists the task but never runs it
+	stopped := executors.NewDurableDelayExecutor(store, "delay", func(id, payload string) error {
+		return nil
+	})
+	_, err = stopped.Delay("task1", "payload", 0)
+	assert.Nil(t, err)
+	stopped.Stop()
+
+	done := make(chan struct{})
+	executor := executors.NewDurableDelayExecutor(store, "delay", func(id, payload string) error {
+		close(done)
+		return nil
+	}, executors.WithPollInterval(time.Millisecond*10))
+	defer executor.Stop()
+	executor.Start()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 2):
+		t.Fatal("timeout waiting for rehydrated task")
+	}
+}
@@ -0,0 +1,30 @@
+package executors
+
+import "github.com/tal-tech/go-zero/core/metric"
+
+const cronNamespace = "cron"
+
+var (
+	metricCronRuns = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: cronNamespace,
+		Subsystem: "jobs",
+		Name:      "run_total",
+		Help:      "cron scheduler job run count.",
+		Labels:    []string{"job"},
+	})
+	metricCronFails = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: cronNamespace,
+		Subsystem: "jobs",
+		Name:      "fail_total",
+		Help:      "cron scheduler job failure count.",
+		Labels:    []string{"job"},
+	})
+	metricCronDuration = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: cronNamespace,
+		Subsystem: "jobs",
+		Name:      "duration_ms",
+		Help:      "cron scheduler job run duration, in ms.",
+		Labels:    []string{"job"},
+		Buckets:   []float64{5, 10, 25, 50, 100, 250, 500, 1000, 5000},
+	})
+)
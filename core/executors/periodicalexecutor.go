@@ -13,9 +13,17 @@ import (
 	"github.com/tal-tech/go-zero/core/timex"
 )
 
-const idleRound = 10
+const (
+	idleRound = 10
+	// the shutdown listeners run within proc's wrap-up window,
+	// don't stall the other listeners on a hanging execution
+	defaultFlushTimeout = time.Second
+)
 
 type (
+	// PeriodicalExecutorOption defines the method to customize a PeriodicalExecutor.
+	PeriodicalExecutorOption func(executor *PeriodicalExecutor)
+
 	// TaskContainer interface defines a type that can be used as the underlying
 	// container that used to do periodical executions.
 	TaskContainer interface {
@@ -45,7 +53,8 @@ type (
 )
 
 // NewPeriodicalExecutor returns a PeriodicalExecutor with given interval and container.
-func NewPeriodicalExecutor(interval time.Duration, container TaskContainer) *PeriodicalExecutor {
+func NewPeriodicalExecutor(interval time.Duration, container TaskContainer,
+	opts ...PeriodicalExecutorOption) *PeriodicalExecutor {
 	executor := &PeriodicalExecutor{
 		// buffer 1 to let the caller go quickly
 		commander:   make(chan interface{}, 1),
@@ -56,13 +65,26 @@ func NewPeriodicalExecutor(interval time.Duration, container TaskContainer) *Per
 			return timex.NewTicker(d)
 		},
 	}
+	for _, opt := range opts {
+		opt(executor)
+	}
 	proc.AddShutdownListener(func() {
-		executor.Flush()
+		// flush the buffered tasks and wait for the in-flight executions,
+		// so the pending items are not dropped on graceful shutdown
+		executor.WaitForFlush(defaultFlushTimeout)
 	})
 
 	return executor
 }
 
+// WithTicker customizes a PeriodicalExecutor to create its tickers with
+// newTicker, which makes the executor testable with a fake ticker.
+func WithTicker(newTicker func(d time.Duration) timex.Ticker) PeriodicalExecutorOption {
+	return func(executor *PeriodicalExecutor) {
+		executor.newTicker = newTicker
+	}
+}
+
 // Add adds tasks into pe.
 func (pe *PeriodicalExecutor) Add(task interface{}) {
 	if vals, ok := pe.addAndCheck(task); ok {
@@ -96,6 +118,26 @@ func (pe *PeriodicalExecutor) Wait() {
 	})
 }
 
+// WaitForFlush flushes the pending tasks and waits for the executions to be done,
+// it gives up and returns false if the executions don't finish within timeout.
+func (pe *PeriodicalExecutor) WaitForFlush(timeout time.Duration) bool {
+	done := make(chan lang.PlaceholderType)
+	threading.GoSafe(func() {
+		pe.Wait()
+		close(done)
+	})
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
 func (pe *PeriodicalExecutor) addAndCheck(task interface{}) (interface{}, bool) {
 	pe.lock.Lock()
 	defer func() {
@@ -140,6 +140,28 @@ func TestPeriodicalExecutor_WaitFast(t *testing.T) {
 	assert.Equal(t, total, cnt)
 }
 
+func TestPeriodicalExecutor_WaitForFlush(t *testing.T) {
+	var count int32
+	executor := NewBulkExecutor(func(tasks []interface{}) {
+		atomic.AddInt32(&count, int32(len(tasks)))
+	}, WithBulkTasks(10), WithBulkInterval(time.Minute))
+	for i := 0; i < 3; i++ {
+		executor.Add(i)
+	}
+
+	assert.True(t, executor.WaitForFlush(time.Second))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&count))
+}
+
+func TestPeriodicalExecutor_WaitForFlushTimeout(t *testing.T) {
+	executor := NewBulkExecutor(func(tasks []interface{}) {
+		time.Sleep(time.Second)
+	}, WithBulkTasks(10), WithBulkInterval(time.Minute))
+	executor.Add(1)
+
+	assert.False(t, executor.WaitForFlush(time.Millisecond*50))
+}
+
 func TestPeriodicalExecutor_Deadlock(t *testing.T) {
 	executor := NewBulkExecutor(func(tasks []interface{}) {
 	}, WithBulkTasks(1), WithBulkInterval(time.Millisecond))
@@ -169,3 +191,32 @@ func BenchmarkExecutor(b *testing.B) {
 		executor.Add(1)
 	}
 }
+
+func TestPeriodicalExecutor_WithTicker(t *testing.T) {
+	ticker := timex.NewFakeTicker()
+	var lock sync.Mutex
+	var vals []int
+	exec := NewPeriodicalExecutor(time.Second, newContainer(time.Millisecond, func(tasks interface{}) {
+		lock.Lock()
+		vals = append(vals, tasks.([]int)...)
+		lock.Unlock()
+	}), WithTicker(func(d time.Duration) timex.Ticker {
+		return ticker
+	}))
+
+	exec.Add(1)
+	ticker.Tick()
+	for i := 0; i < 100; i++ {
+		lock.Lock()
+		flushed := len(vals) > 0
+		lock.Unlock()
+		if flushed {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	lock.Lock()
+	assert.ElementsMatch(t, []int{1}, vals)
+	lock.Unlock()
+}
@@ -0,0 +1,156 @@
+package executors
+
+import (
+	"container/heap"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/threading"
+	"github.com/tal-tech/go-zero/core/timex"
+)
+
+// ErrExecutorStopped indicates that the executor is stopped.
+var ErrExecutorStopped = errors.New("executor stopped")
+
+type (
+	// PriorityExecutorOption defines the method to customize a PriorityExecutor.
+	PriorityExecutorOption func(executor *PriorityExecutor)
+
+	// A PriorityExecutor executes the submitted tasks with a bounded number
+	// of workers, the higher priority tasks run first. With aging enabled,
+	// the waiting tasks gain priority over time, so the low priority tasks
+	// are not starved by a steady stream of high priority ones.
+	PriorityExecutor struct {
+		lock    sync.Mutex
+		cond    *sync.Cond
+		tasks   priorityTasks
+		aging   time.Duration
+		seq     uint64
+		stopped bool
+	}
+
+	priorityTask struct {
+		key int64
+		seq uint64
+		run func()
+	}
+
+	priorityTasks []priorityTask
+)
+
+// NewPriorityExecutor returns a PriorityExecutor with the given number of workers.
+func NewPriorityExecutor(workers int, opts ...PriorityExecutorOption) *PriorityExecutor {
+	if workers < 1 {
+		workers = 1
+	}
+
+	executor := new(PriorityExecutor)
+	executor.cond = sync.NewCond(&executor.lock)
+	for _, opt := range opts {
+		opt(executor)
+	}
+
+	for i := 0; i < workers; i++ {
+		threading.GoSafe(executor.workLoop)
+	}
+
+	return executor
+}
+
+// WithTaskAging enables aging with the given interval, a waiting task gains
+// one priority every interval, so it eventually outranks the fresh tasks.
+func WithTaskAging(interval time.Duration) PriorityExecutorOption {
+	return func(executor *PriorityExecutor) {
+		if interval > 0 {
+			executor.aging = interval
+		}
+	}
+}
+
+// Stop stops e, the submitted tasks still run to completion,
+// the following submits are rejected.
+func (e *PriorityExecutor) Stop() {
+	e.lock.Lock()
+	e.stopped = true
+	e.lock.Unlock()
+	e.cond.Broadcast()
+}
+
+// Submit submits task with the given priority, the higher runs first.
+func (e *PriorityExecutor) Submit(priority int, task func()) error {
+	e.lock.Lock()
+	if e.stopped {
+		e.lock.Unlock()
+		return ErrExecutorStopped
+	}
+
+	e.seq++
+	heap.Push(&e.tasks, priorityTask{
+		key: e.taskKey(priority),
+		seq: e.seq,
+		run: task,
+	})
+	e.lock.Unlock()
+	e.cond.Signal()
+
+	return nil
+}
+
+// taskKey folds the submit time into the priority. Because all the waiting
+// tasks age at the same rate, the relative order of two keys never changes,
+// so the heap invariant holds even though priorities effectively grow.
+func (e *PriorityExecutor) taskKey(priority int) int64 {
+	if e.aging <= 0 {
+		return int64(priority)
+	}
+
+	return int64(priority)*int64(e.aging) - int64(timex.Now())
+}
+
+func (e *PriorityExecutor) workLoop() {
+	for {
+		e.lock.Lock()
+		for len(e.tasks) == 0 && !e.stopped {
+			e.cond.Wait()
+		}
+		if len(e.tasks) == 0 {
+			e.lock.Unlock()
+			return
+		}
+
+		task := heap.Pop(&e.tasks).(priorityTask)
+		e.lock.Unlock()
+
+		threading.RunSafe(task.run)
+	}
+}
+
+func (t priorityTasks) Len() int {
+	return len(t)
+}
+
+func (t priorityTasks) Less(i, j int) bool {
+	if t[i].key != t[j].key {
+		return t[i].key > t[j].key
+	}
+
+	// FIFO within the same priority
+	return t[i].seq < t[j].seq
+}
+
+func (t priorityTasks) Swap(i, j int) {
+	t[i], t[j] = t[j], t[i]
+}
+
+func (t *priorityTasks) Push(x interface{}) {
+	*t = append(*t, x.(priorityTask))
+}
+
+func (t *priorityTasks) Pop() interface{} {
+	old := *t
+	n := len(old)
+	task := old[n-1]
+	*t = old[:n-1]
+	return task
+}
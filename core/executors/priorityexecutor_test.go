@@ -0,0 +1,127 @@
+package executors
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityExecutorOrder(t *testing.T) {
+	executor := NewPriorityExecutor(1)
+	defer executor.Stop()
+
+	var lock sync.Mutex
+	var order []int
+	block := make(chan struct{})
+	var wg sync.WaitGroup
+
+	// hold the only worker, so the following tasks queue up
+	assert.Nil(t, executor.Submit(0, func() {
+		<-block
+	}))
+
+	for _, priority := range []int{1, 3, 2} {
+		priority := priority
+		wg.Add(1)
+		assert.Nil(t, executor.Submit(priority, func() {
+			lock.Lock()
+			order = append(order, priority)
+			lock.Unlock()
+			wg.Done()
+		}))
+	}
+
+	close(block)
+	wg.Wait()
+	assert.Equal(t, []int{3, 2, 1}, order)
+}
+
+func TestPriorityExecutorFifoWithinPriority(t *testing.T) {
+	executor := NewPriorityExecutor(1)
+	defer executor.Stop()
+
+	var lock sync.Mutex
+	var order []int
+	block := make(chan struct{})
+	var wg sync.WaitGroup
+
+	assert.Nil(t, executor.Submit(0, func() {
+		<-block
+	}))
+
+	for i := 0; i < 5; i++ {
+		i := i
+		wg.Add(1)
+		assert.Nil(t, executor.Submit(1, func() {
+			lock.Lock()
+			order = append(order, i)
+			lock.Unlock()
+			wg.Done()
+		}))
+	}
+
+	close(block)
+	wg.Wait()
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, order)
+}
+
+func TestPriorityExecutorAging(t *testing.T) {
+	executor := NewPriorityExecutor(1, WithTaskAging(time.Millisecond))
+	defer executor.Stop()
+
+	var lock sync.Mutex
+	var order []string
+	block := make(chan struct{})
+	var wg sync.WaitGroup
+
+	assert.Nil(t, executor.Submit(0, func() {
+		<-block
+	}))
+
+	wg.Add(2)
+	assert.Nil(t, executor.Submit(0, func() {
+		lock.Lock()
+		order = append(order, "old low")
+		lock.Unlock()
+		wg.Done()
+	}))
+	// let the low priority task age beyond the one priority gap
+	time.Sleep(time.Millisecond * 100)
+	assert.Nil(t, executor.Submit(1, func() {
+		lock.Lock()
+		order = append(order, "fresh high")
+		lock.Unlock()
+		wg.Done()
+	}))
+
+	close(block)
+	wg.Wait()
+	assert.Equal(t, []string{"old low", "fresh high"}, order)
+}
+
+func TestPriorityExecutorConcurrency(t *testing.T) {
+	executor := NewPriorityExecutor(4)
+	defer executor.Stop()
+
+	var count int32
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		assert.Nil(t, executor.Submit(i%3, func() {
+			atomic.AddInt32(&count, 1)
+			wg.Done()
+		}))
+	}
+
+	wg.Wait()
+	assert.Equal(t, int32(100), atomic.LoadInt32(&count))
+}
+
+func TestPriorityExecutorStop(t *testing.T) {
+	executor := NewPriorityExecutor(0)
+	executor.Stop()
+	assert.Equal(t, ErrExecutorStopped, executor.Submit(1, func() {}))
+}
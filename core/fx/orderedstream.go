@@ -0,0 +1,92 @@
+package fx
+
+import "github.com/tal-tech/go-zero/core/lang"
+
+type (
+	indexedItem struct {
+		index int
+		item  interface{}
+	}
+
+	indexedResult struct {
+		index   int
+		results []interface{}
+	}
+)
+
+// MapOrdered converts each item to another corresponding item with concurrent
+// workers, and emits downstream in the input order.
+func (p Stream) MapOrdered(fn MapFunc, opts ...Option) Stream {
+	return p.WalkOrdered(func(item interface{}, pipe chan<- interface{}) {
+		pipe <- fn(item)
+	}, opts...)
+}
+
+// WalkOrdered lets the callers handle each item with concurrent workers,
+// and emits downstream in the input order. The out-of-order buffering is
+// bounded by the number of workers.
+func (p Stream) WalkOrdered(fn WalkFunc, opts ...Option) Stream {
+	option := buildOptions(opts...)
+
+	// tag each element with its input index
+	indexedSource := make(chan interface{})
+	go func() {
+		defer close(indexedSource)
+		var index int
+		for item := range p.source {
+			indexedSource <- indexedItem{
+				index: index,
+				item:  item,
+			}
+			index++
+		}
+	}()
+
+	// run fn concurrently, collecting the outputs per element
+	walked := Range(indexedSource).Walk(func(item interface{}, pipe chan<- interface{}) {
+		indexed := item.(indexedItem)
+		itemPipe := make(chan interface{})
+		var results []interface{}
+		done := make(chan lang.PlaceholderType)
+		go func() {
+			for each := range itemPipe {
+				results = append(results, each)
+			}
+			close(done)
+		}()
+		fn(indexed.item, itemPipe)
+		close(itemPipe)
+		<-done
+		pipe <- indexedResult{
+			index:   indexed.index,
+			results: results,
+		}
+	}, opts...)
+
+	// emit the buffered results in the input order
+	pipe := make(chan interface{}, option.workers)
+	go func() {
+		defer close(pipe)
+
+		pending := make(map[int][]interface{})
+		var next int
+		for v := range walked.source {
+			result := v.(indexedResult)
+			pending[result.index] = result.results
+			for {
+				results, ok := pending[next]
+				if !ok {
+					break
+				}
+
+				for _, item := range results {
+					pipe <- item
+				}
+				delete(pending, next)
+				next++
+			}
+		}
+	}()
+
+	return Range(pipe)
+}
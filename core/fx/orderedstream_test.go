@@ -0,0 +1,47 @@
+package fx
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapOrdered(t *testing.T) {
+	var result []int
+	From(func(source chan<- interface{}) {
+		for i := 0; i < 10; i++ {
+			source <- i
+		}
+	}).MapOrdered(func(item interface{}) interface{} {
+		time.Sleep(time.Millisecond * time.Duration(rand.Intn(10)))
+		return item.(int) * item.(int)
+	}, WithWorkers(4)).ForEach(func(item interface{}) {
+		result = append(result, item.(int))
+	})
+
+	assert.Equal(t, []int{0, 1, 4, 9, 16, 25, 36, 49, 64, 81}, result)
+}
+
+func TestWalkOrdered(t *testing.T) {
+	var result []int
+	Just(1, 2, 3, 4, 5).WalkOrdered(func(item interface{}, pipe chan<- interface{}) {
+		time.Sleep(time.Millisecond * time.Duration(rand.Intn(10)))
+		v := item.(int)
+		if v%2 != 0 {
+			pipe <- v
+			pipe <- v * 10
+		}
+	}, WithWorkers(3)).ForEach(func(item interface{}) {
+		result = append(result, item.(int))
+	})
+
+	assert.Equal(t, []int{1, 10, 3, 30, 5, 50}, result)
+}
+
+func TestWalkOrderedEmpty(t *testing.T) {
+	assert.Equal(t, 0, Just().WalkOrdered(func(item interface{}, pipe chan<- interface{}) {
+		pipe <- item
+	}).Count())
+}
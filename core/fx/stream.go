@@ -1,8 +1,10 @@
 package fx
 
 import (
+	"context"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/tal-tech/go-zero/core/collection"
 	"github.com/tal-tech/go-zero/core/lang"
@@ -22,6 +24,8 @@ type (
 
 	// FilterFunc defines the method to filter a Stream.
 	FilterFunc func(item interface{}) bool
+	// FlatMapFunc defines the method to map one element to many in a Stream.
+	FlatMapFunc func(item interface{}) []interface{}
 	// ForAllFunc defines the method to handle all elements in a Stream.
 	ForAllFunc func(pipe <-chan interface{})
 	// ForEachFunc defines the method to handle each element in a Stream.
@@ -43,6 +47,12 @@ type (
 	// WalkFunc defines the method to walk through all the elements in a Stream.
 	WalkFunc func(item interface{}, pipe chan<- interface{})
 
+	// A Pair is a zipped element of two streams.
+	Pair struct {
+		First  interface{}
+		Second interface{}
+	}
+
 	// A Stream is a stream that can be used to do stream processing.
 	Stream struct {
 		source <-chan interface{}
@@ -61,6 +71,46 @@ func From(generate GenerateFunc) Stream {
 	return Range(source)
 }
 
+// FromWithContext constructs a Stream from the given GenerateFunc,
+// the Stream ends when ctx is canceled, and the generate goroutine
+// is drained so it doesn't leak. The source channel is unbuffered,
+// so a slow consumer naturally backpressures the generator.
+func FromWithContext(ctx context.Context, generate GenerateFunc) Stream {
+	source := make(chan interface{})
+
+	threading.GoSafe(func() {
+		defer close(source)
+		generate(source)
+	})
+
+	pipe := make(chan interface{})
+	go func() {
+		defer close(pipe)
+
+		for {
+			select {
+			case <-ctx.Done():
+				// unblock the generator, the items are discarded
+				go Range(source).Done()
+				return
+			case item, ok := <-source:
+				if !ok {
+					return
+				}
+
+				select {
+				case <-ctx.Done():
+					go Range(source).Done()
+					return
+				case pipe <- item:
+				}
+			}
+		}
+	}()
+
+	return Range(pipe)
+}
+
 // Just converts the given arbitrary items to a Stream.
 func Just(items ...interface{}) Stream {
 	source := make(chan interface{}, len(items))
@@ -79,6 +129,36 @@ func Range(source <-chan interface{}) Stream {
 	}
 }
 
+// RangeWithContext converts the given channel to a Stream,
+// the Stream ends when ctx is canceled even if source is not closed.
+// The remaining items in source are left to the channel owner.
+func RangeWithContext(ctx context.Context, source <-chan interface{}) Stream {
+	pipe := make(chan interface{})
+
+	go func() {
+		defer close(pipe)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-source:
+				if !ok {
+					return
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case pipe <- item:
+				}
+			}
+		}
+	}()
+
+	return Range(pipe)
+}
+
 // Buffer buffers the items into a queue with size n.
 // It can balance the producer and the consumer if their processing throughput don't match.
 func (p Stream) Buffer(n int) Stream {
@@ -140,6 +220,15 @@ func (p Stream) Filter(fn FilterFunc, opts ...Option) Stream {
 	}, opts...)
 }
 
+// FlatMap converts each item to zero or more items, which means it's a 1:N model.
+func (p Stream) FlatMap(fn FlatMapFunc, opts ...Option) Stream {
+	return p.Walk(func(item interface{}, pipe chan<- interface{}) {
+		for _, each := range fn(item) {
+			pipe <- each
+		}
+	}, opts...)
+}
+
 // ForAll handles the streaming elements from the source and no later streams.
 func (p Stream) ForAll(fn ForAllFunc) {
 	fn(p.source)
@@ -309,6 +398,91 @@ func (p Stream) Tail(n int64) Stream {
 	return Range(source)
 }
 
+// Window groups the elements into chunks of up to size elements,
+// a chunk is also emitted when interval elapses, so tailing elements
+// don't wait for a full chunk on near-real-time aggregations.
+// A non-positive interval means count-based chunking only.
+func (p Stream) Window(size int, interval time.Duration) Stream {
+	if size < 1 {
+		panic("size should be greater than 0")
+	}
+
+	source := make(chan interface{})
+	go func() {
+		defer close(source)
+
+		var chunk []interface{}
+		emit := func() {
+			if len(chunk) > 0 {
+				source <- chunk
+				chunk = nil
+			}
+		}
+
+		if interval <= 0 {
+			for item := range p.source {
+				chunk = append(chunk, item)
+				if len(chunk) == size {
+					emit()
+				}
+			}
+			emit()
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case item, ok := <-p.source:
+				if !ok {
+					emit()
+					return
+				}
+
+				chunk = append(chunk, item)
+				if len(chunk) == size {
+					emit()
+				}
+			case <-ticker.C:
+				emit()
+			}
+		}
+	}()
+
+	return Range(source)
+}
+
+// Zip zips the elements of a and b into pairs,
+// it ends when either stream ends, the other one is drained.
+func Zip(a, b Stream) Stream {
+	source := make(chan interface{})
+	go func() {
+		defer close(source)
+
+		for {
+			av, ok := <-a.source
+			if !ok {
+				break
+			}
+			bv, ok := <-b.source
+			if !ok {
+				break
+			}
+			source <- Pair{
+				First:  av,
+				Second: bv,
+			}
+		}
+
+		// drain both streams to avoid blocking the producers
+		go a.Done()
+		go b.Done()
+	}()
+
+	return Range(source)
+}
+
 // Walk lets the callers handle each item, the caller may write zero, one or more items base on the given item.
 func (p Stream) Walk(fn WalkFunc, opts ...Option) Stream {
 	option := buildOptions(opts...)
@@ -1,6 +1,7 @@
 package fx
 
 import (
+	"context"
 	"io/ioutil"
 	"log"
 	"runtime"
@@ -125,6 +126,110 @@ func TestFilter(t *testing.T) {
 	assert.Equal(t, 6, result)
 }
 
+func TestFlatMap(t *testing.T) {
+	var result int32
+	Just(1, 2, 3).FlatMap(func(item interface{}) []interface{} {
+		v := item.(int)
+		return []interface{}{v, v * 10}
+	}).ForEach(func(item interface{}) {
+		atomic.AddInt32(&result, int32(item.(int)))
+	})
+
+	assert.Equal(t, int32(66), atomic.LoadInt32(&result))
+}
+
+func TestWindowByCount(t *testing.T) {
+	var chunks [][]interface{}
+	Just(1, 2, 3, 4, 5).Window(2, 0).ForEach(func(item interface{}) {
+		chunks = append(chunks, item.([]interface{}))
+	})
+
+	assert.Equal(t, 3, len(chunks))
+	assert.Equal(t, 2, len(chunks[0]))
+	assert.Equal(t, 2, len(chunks[1]))
+	assert.Equal(t, 1, len(chunks[2]))
+}
+
+func TestWindowByTime(t *testing.T) {
+	var chunks [][]interface{}
+	From(func(source chan<- interface{}) {
+		source <- 1
+		source <- 2
+		time.Sleep(time.Millisecond * 50)
+		source <- 3
+	}).Window(10, time.Millisecond*10).ForEach(func(item interface{}) {
+		chunks = append(chunks, item.([]interface{}))
+	})
+
+	// the interval flushes the first chunk before the stream ends
+	assert.True(t, len(chunks) >= 2)
+	var total int
+	for _, chunk := range chunks {
+		total += len(chunk)
+	}
+	assert.Equal(t, 3, total)
+}
+
+func TestZip(t *testing.T) {
+	var pairs []Pair
+	Zip(Just(1, 2, 3), Just("a", "b")).ForEach(func(item interface{}) {
+		pairs = append(pairs, item.(Pair))
+	})
+
+	assert.Equal(t, []Pair{
+		{First: 1, Second: "a"},
+		{First: 2, Second: "b"},
+	}, pairs)
+}
+
+func TestFromWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var count int32
+	FromWithContext(ctx, func(source chan<- interface{}) {
+		for i := 0; ; i++ {
+			source <- i
+		}
+	}).ForEach(func(item interface{}) {
+		if atomic.AddInt32(&count, 1) == 3 {
+			cancel()
+		}
+	})
+
+	assert.True(t, atomic.LoadInt32(&count) >= 3)
+}
+
+func TestFromWithContextDone(t *testing.T) {
+	var result int
+	FromWithContext(context.Background(), func(source chan<- interface{}) {
+		for i := 1; i < 5; i++ {
+			source <- i
+		}
+	}).ForEach(func(item interface{}) {
+		result += item.(int)
+	})
+
+	assert.Equal(t, 10, result)
+}
+
+func TestRangeWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	source := make(chan interface{})
+	defer close(source)
+
+	go func() {
+		source <- 1
+		source <- 2
+		cancel()
+	}()
+
+	var count int
+	RangeWithContext(ctx, source).ForEach(func(item interface{}) {
+		count++
+	})
+
+	assert.True(t, count <= 2)
+}
+
 func TestForAll(t *testing.T) {
 	var result int
 	Just(1, 2, 3, 4).Filter(func(item interface{}) bool {
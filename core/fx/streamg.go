@@ -0,0 +1,174 @@
+package fx
+
+import (
+	"sync"
+
+	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+// A StreamG is the generic variant of Stream, it avoids boxing the items
+// and the type assertions in every operator.
+type StreamG[T any] struct {
+	source <-chan T
+}
+
+// FromG constructs a StreamG from the given generate func.
+func FromG[T any](generate func(source chan<- T)) StreamG[T] {
+	source := make(chan T)
+
+	threading.GoSafe(func() {
+		defer close(source)
+		generate(source)
+	})
+
+	return RangeG(source)
+}
+
+// JustG converts the given items to a StreamG.
+func JustG[T any](items ...T) StreamG[T] {
+	source := make(chan T, len(items))
+	for _, item := range items {
+		source <- item
+	}
+	close(source)
+
+	return RangeG(source)
+}
+
+// RangeG converts the given channel to a StreamG.
+func RangeG[T any](source <-chan T) StreamG[T] {
+	return StreamG[T]{
+		source: source,
+	}
+}
+
+// MapG converts each item of s to another corresponding item with given fn,
+// it's a package level func because methods can't have extra type parameters.
+func MapG[T, U any](s StreamG[T], fn func(item T) U, opts ...Option) StreamG[U] {
+	pipe := make(chan U, buildOptions(opts...).workers)
+
+	go func() {
+		defer close(pipe)
+		for item := range s.source {
+			pipe <- fn(item)
+		}
+	}()
+
+	return RangeG(pipe)
+}
+
+// ReduceG reduces all items of s into one value with given fn.
+func ReduceG[T, U any](s StreamG[T], fn func(pipe <-chan T) (U, error)) (U, error) {
+	return fn(s.source)
+}
+
+// Buffer buffers the items into a queue with size n.
+// It can balance the producer and the consumer if their processing throughput don't match.
+func (p StreamG[T]) Buffer(n int) StreamG[T] {
+	if n < 0 {
+		n = 0
+	}
+
+	source := make(chan T, n)
+	go func() {
+		for item := range p.source {
+			source <- item
+		}
+		close(source)
+	}()
+
+	return RangeG(source)
+}
+
+// Count counts the number of elements in the result.
+func (p StreamG[T]) Count() (count int) {
+	for range p.source {
+		count++
+	}
+	return
+}
+
+// DistinctBy removes the duplicated items base on the given key fn.
+func (p StreamG[T]) DistinctBy(fn func(item T) interface{}) StreamG[T] {
+	source := make(chan T)
+
+	threading.GoSafe(func() {
+		defer close(source)
+
+		keys := make(map[interface{}]lang.PlaceholderType)
+		for item := range p.source {
+			key := fn(item)
+			if _, ok := keys[key]; !ok {
+				source <- item
+				keys[key] = lang.Placeholder
+			}
+		}
+	})
+
+	return RangeG(source)
+}
+
+// Done waits all upstreaming operations to be done.
+func (p StreamG[T]) Done() {
+	for range p.source {
+	}
+}
+
+// Filter filters the items by the given fn.
+func (p StreamG[T]) Filter(fn func(item T) bool, opts ...Option) StreamG[T] {
+	return p.Walk(func(item T, pipe chan<- T) {
+		if fn(item) {
+			pipe <- item
+		}
+	}, opts...)
+}
+
+// ForAll handles the streaming elements from the source and no later streams.
+func (p StreamG[T]) ForAll(fn func(pipe <-chan T)) {
+	fn(p.source)
+}
+
+// ForEach seals the StreamG with fn on each item, no successive operations.
+func (p StreamG[T]) ForEach(fn func(item T)) {
+	for item := range p.source {
+		fn(item)
+	}
+}
+
+// Walk lets the callers handle each item, the caller may write zero,
+// one or more items base on the given item.
+func (p StreamG[T]) Walk(fn func(item T, pipe chan<- T), opts ...Option) StreamG[T] {
+	option := buildOptions(opts...)
+	pipe := make(chan T, option.workers)
+
+	go func() {
+		var wg sync.WaitGroup
+		pool := make(chan lang.PlaceholderType, option.workers)
+
+		for {
+			pool <- lang.Placeholder
+			item, ok := <-p.source
+			if !ok {
+				<-pool
+				break
+			}
+
+			wg.Add(1)
+			// better to safely run caller defined method
+			threading.GoSafe(func() {
+				defer func() {
+					wg.Done()
+					<-pool
+				}()
+
+				fn(item, pipe)
+			})
+		}
+
+		wg.Wait()
+		close(pipe)
+	}()
+
+	return RangeG(pipe)
+}
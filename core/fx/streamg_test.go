@@ -0,0 +1,111 @@
+package fx
+
+import (
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamGFrom(t *testing.T) {
+	count := FromG(func(source chan<- int) {
+		for i := 0; i < 10; i++ {
+			source <- i
+		}
+	}).Count()
+
+	assert.Equal(t, 10, count)
+}
+
+func TestStreamGJust(t *testing.T) {
+	var values []int
+	JustG(1, 2, 3, 4).ForEach(func(item int) {
+		values = append(values, item)
+	})
+
+	assert.Equal(t, []int{1, 2, 3, 4}, values)
+}
+
+func TestStreamGMap(t *testing.T) {
+	var result []string
+	MapG(JustG(1, 2, 3), func(item int) string {
+		return string(rune('a' + item - 1))
+	}).ForEach(func(item string) {
+		result = append(result, item)
+	})
+
+	assert.Equal(t, []string{"a", "b", "c"}, result)
+}
+
+func TestStreamGFilter(t *testing.T) {
+	var sum int32
+	JustG(1, 2, 3, 4, 5, 6).Filter(func(item int) bool {
+		return item%2 == 0
+	}).ForEach(func(item int) {
+		atomic.AddInt32(&sum, int32(item))
+	})
+
+	assert.Equal(t, int32(12), atomic.LoadInt32(&sum))
+}
+
+func TestStreamGDistinctBy(t *testing.T) {
+	var values []int
+	JustG(1, 2, 3, 4, 5, 6).DistinctBy(func(item int) interface{} {
+		return item % 3
+	}).ForEach(func(item int) {
+		values = append(values, item)
+	})
+
+	assert.Equal(t, []int{1, 2, 3}, values)
+}
+
+func TestStreamGReduce(t *testing.T) {
+	result, err := ReduceG(JustG(1, 2, 3, 4), func(pipe <-chan int) (int, error) {
+		var sum int
+		for item := range pipe {
+			sum += item
+		}
+		return sum, nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 10, result)
+}
+
+func TestStreamGWalk(t *testing.T) {
+	var values []int
+	JustG(1, 2, 3).Walk(func(item int, pipe chan<- int) {
+		pipe <- item
+		pipe <- item * 10
+	}, WithWorkers(3)).ForEach(func(item int) {
+		values = append(values, item)
+	})
+
+	sort.Ints(values)
+	assert.Equal(t, []int{1, 2, 3, 10, 20, 30}, values)
+}
+
+func TestStreamGBuffer(t *testing.T) {
+	assert.Equal(t, 3, JustG(1, 2, 3).Buffer(-1).Count())
+}
+
+func TestStreamGDone(t *testing.T) {
+	var count int32
+	JustG(1, 2, 3).Walk(func(item int, pipe chan<- int) {
+		atomic.AddInt32(&count, 1)
+		pipe <- item
+	}).Done()
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&count))
+}
+
+func TestStreamGForAll(t *testing.T) {
+	JustG(1, 2, 3).ForAll(func(pipe <-chan int) {
+		var count int
+		for range pipe {
+			count++
+		}
+		assert.Equal(t, 3, count)
+	})
+}
@@ -0,0 +1,45 @@
+package limit
+
+import (
+	"context"
+	"time"
+
+	xrate "golang.org/x/time/rate"
+)
+
+// A LocalLimiter is an in-process token bucket limiter with the same API shape
+// as TokenLimiter, for single-instance services that don't want a Redis dependency.
+type LocalLimiter struct {
+	limiter *xrate.Limiter
+}
+
+// NewLocalLimiter returns a new LocalLimiter that allows events up to rate per second
+// and permits bursts of at most burst tokens.
+func NewLocalLimiter(rate, burst int) *LocalLimiter {
+	return &LocalLimiter{
+		limiter: xrate.NewLimiter(xrate.Every(time.Second/time.Duration(rate)), burst),
+	}
+}
+
+// Allow is shorthand for AllowN(time.Now(), 1).
+func (lim *LocalLimiter) Allow() bool {
+	return lim.AllowN(time.Now(), 1)
+}
+
+// AllowN reports whether n events may happen at time now.
+// Use this method if you intend to drop / skip events that exceed the rate.
+// Otherwise use Wait.
+func (lim *LocalLimiter) AllowN(now time.Time, n int) bool {
+	return lim.limiter.AllowN(now, n)
+}
+
+// Wait is shorthand for WaitN(ctx, 1).
+func (lim *LocalLimiter) Wait(ctx context.Context) error {
+	return lim.WaitN(ctx, 1)
+}
+
+// WaitN blocks until n tokens are available or ctx is done,
+// and returns the ctx error in the latter case.
+func (lim *LocalLimiter) WaitN(ctx context.Context, n int) error {
+	return lim.limiter.WaitN(ctx, n)
+}
@@ -0,0 +1,43 @@
+package limit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalLimit_Take(t *testing.T) {
+	const (
+		total = 100
+		rate  = 5
+		burst = 10
+	)
+	l := NewLocalLimiter(rate, burst)
+	var allowed int
+	for i := 0; i < total; i++ {
+		if l.Allow() {
+			allowed++
+		}
+	}
+
+	assert.True(t, allowed >= burst)
+	assert.True(t, allowed < total)
+}
+
+func TestLocalLimit_Wait(t *testing.T) {
+	l := NewLocalLimiter(100, 1)
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, l.Wait(context.Background()))
+	}
+}
+
+func TestLocalLimit_WaitTimeout(t *testing.T) {
+	l := NewLocalLimiter(1, 1)
+	assert.True(t, l.Allow())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	assert.NotNil(t, l.WaitN(ctx, 1))
+}
@@ -0,0 +1,95 @@
+package limit
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/tal-tech/go-zero/core/stores/redis"
+)
+
+const (
+	// to be compatible with aliyun redis, we cannot use `local key = KEYS[1]` to reuse the key
+	quotaScript = `local n = tonumber(ARGV[1])
+for i = 1, n do
+    local quota = tonumber(ARGV[2*i])
+    local current = tonumber(redis.call("GET", KEYS[i]) or "0")
+    if current >= quota then
+        return i
+    end
+end
+for i = 1, n do
+    local window = tonumber(ARGV[2*i+1])
+    local current = redis.call("INCRBY", KEYS[i], 1)
+    if current == 1 then
+        redis.call("expire", KEYS[i], window)
+    end
+end
+return 0`
+
+	// NoTierExceeded is returned as the tier index when the request is allowed.
+	NoTierExceeded = -1
+)
+
+// ErrNoTiers is an error that indicates a QuotaLimiter is built without tiers.
+var ErrNoTiers = errors.New("no tiers provided")
+
+type (
+	// A Tier is a period/quota pair evaluated by a QuotaLimiter,
+	// e.g. 10 requests per second together with 10000 requests per day.
+	Tier struct {
+		// Period is the window length in seconds.
+		Period int
+		// Quota is the max requests allowed in Period.
+		Quota int
+	}
+
+	// A QuotaLimiter limits requests against multiple period/quota tiers
+	// for the same key, all tiers are evaluated atomically in one call.
+	QuotaLimiter struct {
+		tiers      []Tier
+		limitStore *redis.Redis
+		keyPrefix  string
+	}
+)
+
+// NewQuotaLimiter returns a QuotaLimiter with given tiers.
+func NewQuotaLimiter(tiers []Tier, limitStore *redis.Redis, keyPrefix string) *QuotaLimiter {
+	return &QuotaLimiter{
+		tiers:      tiers,
+		limitStore: limitStore,
+		keyPrefix:  keyPrefix,
+	}
+}
+
+// Take requests a permit against all tiers, a permit is only taken if every
+// tier has quota left. It returns whether the request is allowed, and the index
+// of the first exceeded tier, NoTierExceeded if allowed.
+func (h *QuotaLimiter) Take(key string) (bool, int, error) {
+	if len(h.tiers) == 0 {
+		return false, NoTierExceeded, ErrNoTiers
+	}
+
+	keys := make([]string, 0, len(h.tiers))
+	args := make([]string, 0, len(h.tiers)*2+1)
+	args = append(args, strconv.Itoa(len(h.tiers)))
+	for _, tier := range h.tiers {
+		keys = append(keys, h.keyPrefix+key+":"+strconv.Itoa(tier.Period))
+		args = append(args, strconv.Itoa(tier.Quota), strconv.Itoa(tier.Period))
+	}
+
+	resp, err := h.limitStore.Eval(quotaScript, keys, args)
+	if err != nil {
+		return false, NoTierExceeded, err
+	}
+
+	code, ok := resp.(int64)
+	if !ok {
+		return false, NoTierExceeded, ErrUnknownCode
+	}
+
+	if code == 0 {
+		return true, NoTierExceeded, nil
+	}
+
+	return false, int(code) - 1, nil
+}
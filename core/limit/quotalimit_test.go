@@ -0,0 +1,84 @@
+package limit
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/stores/redis"
+	"github.com/tal-tech/go-zero/core/stores/redis/redistest"
+)
+
+func TestQuotaLimiter_Take(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	const total = 100
+	l := NewQuotaLimiter([]Tier{
+		{Period: 1, Quota: 20},
+		{Period: 86400, Quota: 5},
+	}, store, "quotalimit")
+
+	var allowed int
+	var exceeded int
+	for i := 0; i < total; i++ {
+		ok, tier, err := l.Take("first")
+		assert.Nil(t, err)
+		if ok {
+			allowed++
+			assert.Equal(t, NoTierExceeded, tier)
+		} else {
+			exceeded = tier
+		}
+	}
+
+	// the daily tier is the tight one
+	assert.Equal(t, 5, allowed)
+	assert.Equal(t, 1, exceeded)
+}
+
+func TestQuotaLimiter_TakeFirstTier(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	l := NewQuotaLimiter([]Tier{
+		{Period: 1, Quota: 5},
+		{Period: 86400, Quota: 1000},
+	}, store, "quotalimit")
+
+	for i := 0; i < 5; i++ {
+		ok, _, err := l.Take("first")
+		assert.Nil(t, err)
+		assert.True(t, ok)
+	}
+
+	ok, tier, err := l.Take("first")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 0, tier)
+}
+
+func TestQuotaLimiter_NoTiers(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	l := NewQuotaLimiter(nil, store, "quotalimit")
+	_, _, err = l.Take("first")
+	assert.Equal(t, ErrNoTiers, err)
+}
+
+func TestQuotaLimiter_RedisUnavailable(t *testing.T) {
+	s, err := miniredis.Run()
+	assert.Nil(t, err)
+
+	l := NewQuotaLimiter([]Tier{
+		{Period: 1, Quota: 5},
+	}, redis.NewRedis(s.Addr(), redis.NodeType), "quotalimit")
+	s.Close()
+	ok, _, err := l.Take("first")
+	assert.NotNil(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,97 @@
+package limit
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/stores/redis"
+	"github.com/tal-tech/go-zero/core/stringx"
+)
+
+const (
+	// to be compatible with aliyun redis, we cannot use `local key = KEYS[1]` to reuse the key
+	slidingWindowScript = `local quota = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], 0, now-window)
+local current = redis.call("ZCARD", KEYS[1])
+if current < quota then
+    redis.call("ZADD", KEYS[1], now, ARGV[4])
+    redis.call("PEXPIRE", KEYS[1], window)
+    return {1, quota-current-1, 0}
+end
+local earliest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+local retry = 0
+if earliest[2] ~= nil then
+    retry = tonumber(earliest[2])+window-now
+end
+return {0, 0, retry}`
+	memberLen = 8
+)
+
+type (
+	// A WindowState is the state of a SlidingWindowLimit after taking a permit.
+	WindowState struct {
+		// Allowed tells whether the request is allowed.
+		Allowed bool
+		// Remaining is the quota left in the current window.
+		Remaining int
+		// RetryAfter is the duration to wait before a permit may be available,
+		// only set on rejected requests.
+		RetryAfter time.Duration
+	}
+
+	// A SlidingWindowLimit is used to limit requests during a sliding period of time,
+	// without the boundary bursts that fixed windows allow.
+	SlidingWindowLimit struct {
+		period     int
+		quota      int
+		limitStore *redis.Redis
+		keyPrefix  string
+	}
+)
+
+// NewSlidingWindowLimit returns a SlidingWindowLimit that allows quota requests
+// in any window of period seconds.
+func NewSlidingWindowLimit(period, quota int, limitStore *redis.Redis,
+	keyPrefix string) *SlidingWindowLimit {
+	return &SlidingWindowLimit{
+		period:     period,
+		quota:      quota,
+		limitStore: limitStore,
+		keyPrefix:  keyPrefix,
+	}
+}
+
+// Take requests a permit, it returns the window state.
+func (h *SlidingWindowLimit) Take(key string) (WindowState, error) {
+	resp, err := h.limitStore.Eval(slidingWindowScript, []string{h.keyPrefix + key}, []string{
+		strconv.Itoa(h.quota),
+		strconv.FormatInt(int64(h.period)*1000, 10),
+		strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10),
+		stringx.Randn(memberLen),
+	})
+	if err != nil {
+		return WindowState{}, err
+	}
+
+	values, ok := resp.([]interface{})
+	if !ok || len(values) != 3 {
+		return WindowState{}, ErrUnknownCode
+	}
+
+	var codes [3]int64
+	for i, value := range values {
+		code, ok := value.(int64)
+		if !ok {
+			return WindowState{}, ErrUnknownCode
+		}
+		codes[i] = code
+	}
+
+	return WindowState{
+		Allowed:    codes[0] == 1,
+		Remaining:  int(codes[1]),
+		RetryAfter: time.Duration(codes[2]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,56 @@
+package limit
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/stores/redis"
+	"github.com/tal-tech/go-zero/core/stores/redis/redistest"
+)
+
+func TestSlidingWindowLimit_Take(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	const (
+		seconds = 1
+		total   = 100
+		quota   = 5
+	)
+	l := NewSlidingWindowLimit(seconds, quota, store, "slidingwindowlimit")
+	var allowed, rejected int
+	for i := 0; i < total; i++ {
+		state, err := l.Take("first")
+		if err != nil {
+			t.Error(err)
+		}
+		if state.Allowed {
+			allowed++
+			assert.Equal(t, quota-allowed, state.Remaining)
+		} else {
+			rejected++
+			assert.True(t, state.RetryAfter > 0)
+		}
+	}
+
+	assert.Equal(t, quota, allowed)
+	assert.Equal(t, total-quota, rejected)
+}
+
+func TestSlidingWindowLimit_RedisUnavailable(t *testing.T) {
+	s, err := miniredis.Run()
+	assert.Nil(t, err)
+
+	const (
+		seconds = 1
+		quota   = 5
+	)
+	l := NewSlidingWindowLimit(seconds, quota, redis.NewRedis(s.Addr(), redis.NodeType),
+		"slidingwindowlimit")
+	s.Close()
+	state, err := l.Take("first")
+	assert.NotNil(t, err)
+	assert.False(t, state.Allowed)
+}
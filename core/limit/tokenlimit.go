@@ -1,6 +1,7 @@
 package limit
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"sync"
@@ -44,6 +45,35 @@ redis.call("setex", KEYS[1], ttl, new_tokens)
 redis.call("setex", KEYS[2], ttl, now)
 
 return allowed`
+	// reserveScript debits the requested tokens unconditionally and returns
+	// the seconds to wait before they are available, 0 if available right away.
+	reserveScript = `local rate = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local requested = tonumber(ARGV[4])
+local fill_time = capacity/rate
+local ttl = math.floor(fill_time*2)
+local last_tokens = tonumber(redis.call("get", KEYS[1]))
+if last_tokens == nil then
+    last_tokens = capacity
+end
+
+local last_refreshed = tonumber(redis.call("get", KEYS[2]))
+if last_refreshed == nil then
+    last_refreshed = 0
+end
+
+local delta = math.max(0, now-last_refreshed)
+local filled_tokens = math.min(capacity, last_tokens+(delta*rate))
+local new_tokens = filled_tokens - requested
+
+redis.call("setex", KEYS[1], ttl, new_tokens)
+redis.call("setex", KEYS[2], ttl, now)
+
+if new_tokens >= 0 then
+    return 0
+end
+return math.ceil(-new_tokens/rate)`
 	tokenFormat     = "{%s}.tokens"
 	timestampFormat = "{%s}.ts"
 	pingInterval    = time.Millisecond * 100
@@ -91,6 +121,81 @@ func (lim *TokenLimiter) AllowN(now time.Time, n int) bool {
 	return lim.reserveN(now, n)
 }
 
+// Reserve is shorthand for ReserveN(time.Now(), 1).
+func (lim *TokenLimiter) Reserve() time.Duration {
+	return lim.ReserveN(time.Now(), 1)
+}
+
+// ReserveN debits n tokens and returns the duration to wait before acting on them.
+// The tokens are not given back if the caller gives up waiting.
+func (lim *TokenLimiter) ReserveN(now time.Time, n int) time.Duration {
+	if atomic.LoadUint32(&lim.redisAlive) == 0 {
+		return lim.rescueLimiter.ReserveN(now, n).DelayFrom(now)
+	}
+
+	resp, err := lim.store.Eval(
+		reserveScript,
+		[]string{
+			lim.tokenKey,
+			lim.timestampKey,
+		},
+		[]string{
+			strconv.Itoa(lim.rate),
+			strconv.Itoa(lim.burst),
+			strconv.FormatInt(now.Unix(), 10),
+			strconv.Itoa(n),
+		})
+	if err != nil {
+		logx.Errorf("fail to use rate limiter: %s, use in-process limiter for rescue", err)
+		lim.startMonitor()
+		return lim.rescueLimiter.ReserveN(now, n).DelayFrom(now)
+	}
+
+	seconds, ok := resp.(int64)
+	if !ok {
+		logx.Errorf("fail to eval redis script: %v, use in-process limiter for rescue", resp)
+		lim.startMonitor()
+		return lim.rescueLimiter.ReserveN(now, n).DelayFrom(now)
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// Wait is shorthand for WaitN(ctx, 1).
+func (lim *TokenLimiter) Wait(ctx context.Context) error {
+	return lim.WaitN(ctx, 1)
+}
+
+// WaitN blocks until n tokens are available or ctx is done,
+// and returns the ctx error in the latter case.
+// Use this method to smooth throughput instead of retrying on rejection.
+func (lim *TokenLimiter) WaitN(ctx context.Context, n int) error {
+	if n > lim.burst {
+		return fmt.Errorf("limit: WaitN(n=%d) exceeds limiter's burst %d", n, lim.burst)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	delay := lim.ReserveN(now, n)
+	if delay <= 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(delay)) {
+		return fmt.Errorf("limit: WaitN(n=%d) would exceed context deadline", n)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 func (lim *TokenLimiter) reserveN(now time.Time, n int) bool {
 	if atomic.LoadUint32(&lim.redisAlive) == 0 {
 		return lim.rescueLimiter.AllowN(now, n)
@@ -1,6 +1,7 @@
 package limit
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -66,6 +67,55 @@ func TestTokenLimit_Take(t *testing.T) {
 	assert.True(t, allowed >= burst+rate)
 }
 
+func TestTokenLimit_Wait(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	const (
+		rate  = 5
+		burst = 10
+	)
+	l := NewTokenLimiter(rate, burst, store, "tokenlimit")
+	// within burst, no waiting involved
+	for i := 0; i < burst; i++ {
+		assert.Nil(t, l.Wait(context.Background()))
+	}
+}
+
+func TestTokenLimit_WaitDeadline(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	const (
+		rate  = 1
+		burst = 1
+	)
+	l := NewTokenLimiter(rate, burst, store, "tokenlimit")
+	assert.True(t, l.Allow())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	assert.NotNil(t, l.Wait(ctx))
+	assert.NotNil(t, l.WaitN(context.Background(), burst+1))
+}
+
+func TestTokenLimit_Reserve(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	const (
+		rate  = 1
+		burst = 1
+	)
+	l := NewTokenLimiter(rate, burst, store, "tokenlimit")
+	assert.Equal(t, time.Duration(0), l.Reserve())
+	// the bucket is drained, the next reservation has to wait
+	assert.True(t, l.Reserve() > 0)
+}
+
 func TestTokenLimit_TakeBurst(t *testing.T) {
 	store, clean, err := redistest.CreateRedis()
 	assert.Nil(t, err)
@@ -15,6 +15,7 @@ import (
 )
 
 const (
+	defaultName    = "adaptive"
 	defaultBuckets = 50
 	defaultWindow  = time.Second * 5
 	// using 1000m notation, 900m is like 80%, keep it as var for unit test
@@ -57,14 +58,20 @@ type (
 	ShedderOption func(opts *shedderOptions)
 
 	shedderOptions struct {
+		name         string
 		window       time.Duration
 		buckets      int
 		cpuThreshold int64
+		minRt        float64
+		coolOff      time.Duration
 	}
 
 	adaptiveShedder struct {
+		name            string
 		cpuThreshold    int64
 		windows         int64
+		minRtBase       float64
+		coolOff         time.Duration
 		flying          int64
 		avgFlying       float64
 		avgFlyingLock   syncx.SpinLock
@@ -88,17 +95,23 @@ func NewAdaptiveShedder(opts ...ShedderOption) Shedder {
 	}
 
 	options := shedderOptions{
+		name:         defaultName,
 		window:       defaultWindow,
 		buckets:      defaultBuckets,
 		cpuThreshold: defaultCpuThreshold,
+		minRt:        defaultMinRt,
+		coolOff:      coolOffDuration,
 	}
 	for _, opt := range opts {
 		opt(&options)
 	}
 	bucketDuration := options.window / time.Duration(options.buckets)
 	return &adaptiveShedder{
+		name:            options.name,
 		cpuThreshold:    options.cpuThreshold,
 		windows:         int64(time.Second / bucketDuration),
+		minRtBase:       options.minRt,
+		coolOff:         options.coolOff,
 		dropTime:        syncx.NewAtomicDuration(),
 		droppedRecently: syncx.NewAtomicBool(),
 		passCounter: collection.NewRollingWindow(options.buckets, bucketDuration,
@@ -152,7 +165,9 @@ func (as *adaptiveShedder) maxFlight() int64 {
 	// maxQPS = maxPASS * windows
 	// minRT = min average response time in milliseconds
 	// maxQPS * minRT / milliseconds_per_second
-	return int64(math.Max(1, float64(as.maxPass()*as.windows)*(as.minRt()/1e3)))
+	maxFlight := int64(math.Max(1, float64(as.maxPass()*as.windows)*(as.minRt()/1e3)))
+	metricMaxFlight.Set(float64(maxFlight), as.name)
+	return maxFlight
 }
 
 func (as *adaptiveShedder) maxPass() int64 {
@@ -168,7 +183,7 @@ func (as *adaptiveShedder) maxPass() int64 {
 }
 
 func (as *adaptiveShedder) minRt() float64 {
-	var result = defaultMinRt
+	var result = as.minRtBase
 
 	as.rtCounter.Reduce(func(b *collection.Bucket) {
 		if b.Count <= 0 {
@@ -213,7 +228,7 @@ func (as *adaptiveShedder) stillHot() bool {
 		return false
 	}
 
-	hot := timex.Since(dropTime) < coolOffDuration
+	hot := timex.Since(dropTime) < as.coolOff
 	if !hot {
 		as.droppedRecently.Set(false)
 	}
@@ -232,6 +247,14 @@ func WithBuckets(buckets int) ShedderOption {
 	}
 }
 
+// WithCoolOffDuration customizes the Shedder with given cool off duration
+// after dropping requests.
+func WithCoolOffDuration(duration time.Duration) ShedderOption {
+	return func(opts *shedderOptions) {
+		opts.coolOff = duration
+	}
+}
+
 // WithCpuThreshold customizes the Shedder with given cpu threshold.
 func WithCpuThreshold(threshold int64) ShedderOption {
 	return func(opts *shedderOptions) {
@@ -239,6 +262,21 @@ func WithCpuThreshold(threshold int64) ShedderOption {
 	}
 }
 
+// WithName customizes the Shedder with given name, used as the metrics label.
+func WithName(name string) ShedderOption {
+	return func(opts *shedderOptions) {
+		opts.name = name
+	}
+}
+
+// WithMinRt customizes the Shedder with given min response time in milliseconds,
+// assumed when there are no finished requests in the window.
+func WithMinRt(minRt float64) ShedderOption {
+	return func(opts *shedderOptions) {
+		opts.minRt = minRt
+	}
+}
+
 // WithWindow customizes the Shedder with given
 func WithWindow(window time.Duration) ShedderOption {
 	return func(opts *shedderOptions) {
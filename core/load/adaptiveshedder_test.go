@@ -85,6 +85,7 @@ func TestAdaptiveShedderMinRt(t *testing.T) {
 	}
 	shedder := &adaptiveShedder{
 		rtCounter: rtCounter,
+		minRtBase: defaultMinRt,
 	}
 	assert.Equal(t, float64(6), shedder.minRt())
 
@@ -92,6 +93,7 @@ func TestAdaptiveShedderMinRt(t *testing.T) {
 	rtCounter = newRollingWindow()
 	shedder = &adaptiveShedder{
 		rtCounter:       rtCounter,
+		minRtBase:       defaultMinRt,
 		droppedRecently: syncx.NewAtomicBool(),
 	}
 	assert.Equal(t, defaultMinRt, shedder.minRt())
@@ -113,6 +115,7 @@ func TestAdaptiveShedderMaxFlight(t *testing.T) {
 		passCounter:     passCounter,
 		rtCounter:       rtCounter,
 		windows:         buckets,
+		minRtBase:       defaultMinRt,
 		droppedRecently: syncx.NewAtomicBool(),
 	}
 	assert.Equal(t, int64(54), shedder.maxFlight())
@@ -135,6 +138,8 @@ func TestAdaptiveShedderShouldDrop(t *testing.T) {
 		passCounter:     passCounter,
 		rtCounter:       rtCounter,
 		windows:         buckets,
+		minRtBase:       defaultMinRt,
+		coolOff:         coolOffDuration,
 		dropTime:        syncx.NewAtomicDuration(),
 		droppedRecently: syncx.NewAtomicBool(),
 	}
@@ -189,6 +194,8 @@ func TestAdaptiveShedderStillHot(t *testing.T) {
 		passCounter:     passCounter,
 		rtCounter:       rtCounter,
 		windows:         buckets,
+		minRtBase:       defaultMinRt,
+		coolOff:         coolOffDuration,
 		dropTime:        syncx.NewAtomicDuration(),
 		droppedRecently: syncx.ForAtomicBool(true),
 	}
@@ -0,0 +1,35 @@
+package load
+
+import "time"
+
+// A ShedderConf is the tuning config for an adaptive Shedder,
+// zero values keep the built-in defaults.
+type ShedderConf struct {
+	// Window is the duration of the stats rolling window.
+	Window time.Duration `json:",optional"`
+	// Buckets is the number of buckets in the rolling window.
+	Buckets int `json:",optional"`
+	// MinRt is the min response time assumed when there are no finished requests.
+	MinRt time.Duration `json:",optional"`
+	// CoolOff is the duration to stay conservative after dropping requests.
+	CoolOff time.Duration `json:",optional"`
+}
+
+// Options converts c into ShedderOptions, unset fields are skipped.
+func (c ShedderConf) Options() []ShedderOption {
+	var opts []ShedderOption
+	if c.Window > 0 {
+		opts = append(opts, WithWindow(c.Window))
+	}
+	if c.Buckets > 0 {
+		opts = append(opts, WithBuckets(c.Buckets))
+	}
+	if c.MinRt > 0 {
+		opts = append(opts, WithMinRt(float64(c.MinRt)/float64(time.Millisecond)))
+	}
+	if c.CoolOff > 0 {
+		opts = append(opts, WithCoolOffDuration(c.CoolOff))
+	}
+
+	return opts
+}
@@ -0,0 +1,42 @@
+package load
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShedderConfOptions(t *testing.T) {
+	assert.Nil(t, ShedderConf{}.Options())
+
+	opts := ShedderConf{
+		Window:  time.Second * 10,
+		Buckets: 100,
+		MinRt:   time.Millisecond * 500,
+		CoolOff: time.Second * 2,
+	}.Options()
+	assert.Equal(t, 4, len(opts))
+
+	options := shedderOptions{
+		window:       defaultWindow,
+		buckets:      defaultBuckets,
+		cpuThreshold: defaultCpuThreshold,
+		minRt:        defaultMinRt,
+		coolOff:      coolOffDuration,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	assert.Equal(t, time.Second*10, options.window)
+	assert.Equal(t, 100, options.buckets)
+	assert.Equal(t, float64(500), options.minRt)
+	assert.Equal(t, time.Second*2, options.coolOff)
+}
+
+func TestShedderConfPartialOptions(t *testing.T) {
+	opts := ShedderConf{
+		CoolOff: time.Second * 2,
+	}.Options()
+	assert.Equal(t, 1, len(opts))
+}
@@ -0,0 +1,39 @@
+package load
+
+import "github.com/tal-tech/go-zero/core/metric"
+
+const shedderNamespace = "shedding"
+
+var (
+	metricSheddingTotal = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: shedderNamespace,
+		Subsystem: "requests",
+		Name:      "total",
+		Help:      "shedding requests count.",
+		Labels:    []string{"name"},
+	})
+
+	metricSheddingPass = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: shedderNamespace,
+		Subsystem: "requests",
+		Name:      "pass_total",
+		Help:      "shedding passed requests count.",
+		Labels:    []string{"name"},
+	})
+
+	metricSheddingDrop = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: shedderNamespace,
+		Subsystem: "requests",
+		Name:      "drop_total",
+		Help:      "shedding dropped requests count.",
+		Labels:    []string{"name"},
+	})
+
+	metricMaxFlight = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: shedderNamespace,
+		Subsystem: "state",
+		Name:      "max_flight",
+		Help:      "shedding estimated max concurrency.",
+		Labels:    []string{"name"},
+	})
+)
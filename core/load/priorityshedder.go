@@ -0,0 +1,80 @@
+package load
+
+import "context"
+
+// use 1000m to represent 100%
+const maxCpuUsage = 1000
+
+// Priorities for requests, lower priorities are shed first during overload.
+const (
+	// PriorityLow is for sheddable traffic like batch crawlers and prefetch.
+	PriorityLow Priority = iota
+	// PriorityNormal is the default priority.
+	PriorityNormal
+	// PriorityHigh is for interactive traffic that should be shed last.
+	PriorityHigh
+
+	numPriorities = 3
+)
+
+type (
+	// A Priority is the priority of a request, used by PriorityShedder.
+	Priority int
+
+	// A PriorityShedder sheds lower priority requests first as pressure rises.
+	PriorityShedder interface {
+		Shedder
+
+		// AllowFor is like Shedder.Allow, with given priority applied.
+		AllowFor(priority Priority) (Promise, error)
+	}
+
+	priorityShedder struct {
+		shedders [numPriorities]Shedder
+	}
+
+	priorityKey struct{}
+)
+
+// NewPriorityShedder returns a PriorityShedder with cpuThreshold for the lowest
+// priority, higher priorities get evenly higher thresholds up to full cpu usage.
+func NewPriorityShedder(cpuThreshold int64, opts ...ShedderOption) PriorityShedder {
+	var ps priorityShedder
+	span := (maxCpuUsage - cpuThreshold) / numPriorities
+	for i := 0; i < numPriorities; i++ {
+		threshold := cpuThreshold + span*int64(i)
+		ps.shedders[i] = NewAdaptiveShedder(append(opts, WithCpuThreshold(threshold))...)
+	}
+
+	return &ps
+}
+
+// Allow implements Shedder.Allow with PriorityNormal.
+func (ps *priorityShedder) Allow() (Promise, error) {
+	return ps.AllowFor(PriorityNormal)
+}
+
+// AllowFor implements PriorityShedder.AllowFor.
+func (ps *priorityShedder) AllowFor(priority Priority) (Promise, error) {
+	if priority < PriorityLow {
+		priority = PriorityLow
+	} else if priority > PriorityHigh {
+		priority = PriorityHigh
+	}
+
+	return ps.shedders[priority].Allow()
+}
+
+// WithPriority returns a new ctx that tags the requests with given priority.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFrom returns the priority tagged in ctx, PriorityNormal if untagged.
+func PriorityFrom(ctx context.Context) Priority {
+	if priority, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return priority
+	}
+
+	return PriorityNormal
+}
@@ -0,0 +1,53 @@
+package load
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityFromContext(t *testing.T) {
+	assert.Equal(t, PriorityNormal, PriorityFrom(context.Background()))
+
+	ctx := WithPriority(context.Background(), PriorityLow)
+	assert.Equal(t, PriorityLow, PriorityFrom(ctx))
+	ctx = WithPriority(ctx, PriorityHigh)
+	assert.Equal(t, PriorityHigh, PriorityFrom(ctx))
+}
+
+func TestPriorityShedderDropsLowFirst(t *testing.T) {
+	prev := enabled.True()
+	enabled.Set(true)
+	defer enabled.Set(prev)
+
+	shedder := NewPriorityShedder(defaultCpuThreshold)
+	ps, ok := shedder.(*priorityShedder)
+	assert.True(t, ok)
+
+	var thresholds []int64
+	for _, s := range ps.shedders {
+		as, ok := s.(*adaptiveShedder)
+		assert.True(t, ok)
+		thresholds = append(thresholds, as.cpuThreshold)
+	}
+	assert.Equal(t, numPriorities, len(thresholds))
+	for i := 1; i < len(thresholds); i++ {
+		assert.True(t, thresholds[i] > thresholds[i-1])
+	}
+}
+
+func TestPriorityShedderAllow(t *testing.T) {
+	shedder := NewPriorityShedder(defaultCpuThreshold)
+	promise, err := shedder.Allow()
+	assert.Nil(t, err)
+	promise.Pass()
+
+	// out of range priorities are clamped
+	promise, err = shedder.AllowFor(PriorityLow - 1)
+	assert.Nil(t, err)
+	promise.Pass()
+	promise, err = shedder.AllowFor(PriorityHigh + 1)
+	assert.Nil(t, err)
+	promise.Pass()
+}
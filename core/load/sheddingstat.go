@@ -36,16 +36,19 @@ func NewSheddingStat(name string) *SheddingStat {
 // IncrementTotal increments the total requests.
 func (s *SheddingStat) IncrementTotal() {
 	atomic.AddInt64(&s.total, 1)
+	metricSheddingTotal.Inc(s.name)
 }
 
 // IncrementPass increments the passed requests.
 func (s *SheddingStat) IncrementPass() {
 	atomic.AddInt64(&s.pass, 1)
+	metricSheddingPass.Inc(s.name)
 }
 
 // IncrementDrop increments the dropped requests.
 func (s *SheddingStat) IncrementDrop() {
 	atomic.AddInt64(&s.drop, 1)
+	metricSheddingDrop.Inc(s.name)
 }
 
 func (s *SheddingStat) reset() snapshot {
@@ -0,0 +1,124 @@
+package logx
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/timex"
+)
+
+// frames between runtime.Caller and the caller of the logger methods.
+const callerLoggerDepth = 2
+
+type (
+	// CallerOption defines the method to customize the caller annotation.
+	CallerOption func(l *callerLogger)
+
+	callerLogger struct {
+		logEntry
+		skip       int
+		fullPath   bool
+		hideCaller bool
+	}
+)
+
+// WithCallerSkip returns a Logger that skips the given extra frames when
+// annotating the caller, so wrappers around logx report the real call site.
+func WithCallerSkip(skip int, opts ...CallerOption) Logger {
+	l := &callerLogger{
+		skip: skip,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// WithFullCallerPath customizes the logger to annotate the full file path of the caller,
+// instead of the trimmed filename.
+func WithFullCallerPath() CallerOption {
+	return func(l *callerLogger) {
+		l.fullPath = true
+	}
+}
+
+// WithoutCaller customizes the logger to not annotate the caller.
+func WithoutCaller() CallerOption {
+	return func(l *callerLogger) {
+		l.hideCaller = true
+	}
+}
+
+func (l *callerLogger) Error(v ...interface{}) {
+	if shouldLog(ErrorLevel) {
+		l.write(errorLog, levelError, l.formatCaller(fmt.Sprint(v...)))
+	}
+}
+
+func (l *callerLogger) Errorf(format string, v ...interface{}) {
+	if shouldLog(ErrorLevel) {
+		l.write(errorLog, levelError, l.formatCaller(fmt.Sprintf(format, v...)))
+	}
+}
+
+func (l *callerLogger) Info(v ...interface{}) {
+	if shouldLog(InfoLevel) {
+		l.write(infoLog, levelInfo, fmt.Sprint(v...))
+	}
+}
+
+func (l *callerLogger) Infof(format string, v ...interface{}) {
+	if shouldLog(InfoLevel) {
+		l.write(infoLog, levelInfo, fmt.Sprintf(format, v...))
+	}
+}
+
+func (l *callerLogger) Slow(v ...interface{}) {
+	if shouldLog(ErrorLevel) {
+		l.write(slowLog, levelSlow, fmt.Sprint(v...))
+	}
+}
+
+func (l *callerLogger) Slowf(format string, v ...interface{}) {
+	if shouldLog(ErrorLevel) {
+		l.write(slowLog, levelSlow, fmt.Sprintf(format, v...))
+	}
+}
+
+func (l *callerLogger) WithDuration(duration time.Duration) Logger {
+	l.Duration = timex.ReprOfDuration(duration)
+	return l
+}
+
+func (l *callerLogger) formatCaller(msg string) string {
+	if l.hideCaller {
+		return msg
+	}
+
+	_, file, line, ok := runtime.Caller(callerLoggerDepth + l.skip)
+	if !ok {
+		return msg
+	}
+
+	if !l.fullPath {
+		for i := len(file) - 1; i > 0; i-- {
+			if file[i] == '/' {
+				file = file[i+1:]
+				break
+			}
+		}
+	}
+
+	return fmt.Sprintf("%s:%d %s", file, line, msg)
+}
+
+func (l *callerLogger) write(writer io.Writer, level, content string) {
+	maybeAlert(level, content)
+	l.Timestamp = getTimestamp()
+	l.Level = level
+	l.Content = content
+	outputJson(writer, logEntry(l.logEntry))
+}
@@ -0,0 +1,88 @@
+package logx
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCallerSkip(t *testing.T) {
+	writer := new(mockWriter)
+	prevErrorLog := errorLog
+	errorLog = writer
+	prevInitialized := atomic.SwapUint32(&initialized, 1)
+	SetLevel(InfoLevel)
+	defer func() {
+		errorLog = prevErrorLog
+		atomic.StoreUint32(&initialized, prevInitialized)
+	}()
+
+	WithCallerSkip(0).Error("direct error")
+	assert.True(t, writer.Contains("callerlogger_test.go"))
+	assert.True(t, writer.Contains("direct error"))
+
+	writer.Reset()
+	logThroughWrapper(t, writer)
+}
+
+// logThroughWrapper emulates a logging helper wrapping logx.
+func logThroughWrapper(t *testing.T, writer *mockWriter) {
+	wrapperError := func(v ...interface{}) {
+		WithCallerSkip(1).Error(v...)
+	}
+	wrapperError("wrapped error")
+	assert.True(t, writer.Contains("callerlogger_test.go"))
+}
+
+func TestWithCallerSkipFullPath(t *testing.T) {
+	writer := new(mockWriter)
+	prevErrorLog := errorLog
+	errorLog = writer
+	prevInitialized := atomic.SwapUint32(&initialized, 1)
+	SetLevel(InfoLevel)
+	defer func() {
+		errorLog = prevErrorLog
+		atomic.StoreUint32(&initialized, prevInitialized)
+	}()
+
+	WithCallerSkip(0, WithFullCallerPath()).Error("full path error")
+	assert.True(t, writer.Contains("/callerlogger_test.go"))
+}
+
+func TestWithoutCaller(t *testing.T) {
+	writer := new(mockWriter)
+	prevErrorLog := errorLog
+	errorLog = writer
+	prevInitialized := atomic.SwapUint32(&initialized, 1)
+	SetLevel(InfoLevel)
+	defer func() {
+		errorLog = prevErrorLog
+		atomic.StoreUint32(&initialized, prevInitialized)
+	}()
+
+	WithCallerSkip(0, WithoutCaller()).Error("no caller")
+	assert.False(t, writer.Contains("callerlogger_test.go"))
+	assert.True(t, writer.Contains("no caller"))
+}
+
+func TestCallerLoggerInfoAndSlow(t *testing.T) {
+	writer := new(mockWriter)
+	prevInfoLog, prevSlowLog := infoLog, slowLog
+	infoLog = writer
+	slowLog = writer
+	prevInitialized := atomic.SwapUint32(&initialized, 1)
+	SetLevel(InfoLevel)
+	defer func() {
+		infoLog, slowLog = prevInfoLog, prevSlowLog
+		atomic.StoreUint32(&initialized, prevInitialized)
+	}()
+
+	logger := WithCallerSkip(0)
+	logger.Info("plain info")
+	logger.Slowf("slow %s", "call")
+	val := writer.String()
+	assert.True(t, strings.Contains(val, "plain info"))
+	assert.True(t, strings.Contains(val, "slow call"))
+}
@@ -0,0 +1,69 @@
+package logx
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCategoryOutputDefault(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "logx-category")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	output, err := createCategoryOutput(LogConf{Path: dir}, statCategory, statFilename)
+	assert.Nil(t, err)
+	defer output.Close()
+
+	_, err = os.Stat(path.Join(dir, statFilename))
+	assert.Nil(t, err)
+}
+
+func TestCreateCategoryOutputDisabled(t *testing.T) {
+	output, err := createCategoryOutput(LogConf{
+		Path: "whatever",
+		Categories: map[string]CategoryConf{
+			statCategory: {
+				Disabled: true,
+			},
+		},
+	}, statCategory, statFilename)
+	assert.Nil(t, err)
+	defer output.Close()
+
+	_, err = output.Write([]byte("dropped"))
+	assert.Nil(t, err)
+}
+
+func TestCreateCategoryOutputCustomPath(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "logx-category")
+	assert.Nil(t, err)
+	other, err := ioutil.TempDir(os.TempDir(), "logx-category-other")
+	assert.Nil(t, err)
+	defer func() {
+		os.RemoveAll(dir)
+		os.RemoveAll(other)
+	}()
+
+	output, err := createCategoryOutput(LogConf{
+		Path: dir,
+		Categories: map[string]CategoryConf{
+			slowCategory: {
+				Path:     other,
+				KeepDays: 3,
+				Rotation: rotationRuleSize,
+				MaxSize:  10,
+			},
+		},
+	}, slowCategory, slowFilename)
+	assert.Nil(t, err)
+	defer output.Close()
+
+	_, err = os.Stat(path.Join(other, slowFilename))
+	assert.Nil(t, err)
+	_, err = os.Stat(path.Join(dir, slowFilename))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -1,5 +1,22 @@
 package logx
 
+// A CategoryConf overrides the logging config of a single category
+// (access, error, severe, slow, stat), zero values inherit from LogConf.
+type CategoryConf struct {
+	// Disabled drops all the entries of the category.
+	Disabled bool `json:",optional"`
+	// Path is the directory to write the category log into.
+	Path string `json:",optional"`
+	// KeepDays overrides LogConf.KeepDays for the category.
+	KeepDays int `json:",optional"`
+	// MaxBackups overrides LogConf.MaxBackups for the category.
+	MaxBackups int `json:",optional"`
+	// MaxSize overrides LogConf.MaxSize for the category.
+	MaxSize int `json:",optional"`
+	// Rotation overrides LogConf.Rotation for the category.
+	Rotation string `json:",optional,options=|daily|size"`
+}
+
 // A LogConf is a logging config.
 type LogConf struct {
 	ServiceName         string `json:",optional"`
@@ -7,7 +24,45 @@ type LogConf struct {
 	TimeFormat          string `json:",optional"`
 	Path                string `json:",default=logs"`
 	Level               string `json:",default=info,options=info|error|severe"`
+	// Levels sets the logging levels of named loggers, name -> level,
+	// so one subsystem can log in debug level without flooding the logs of others.
+	Levels map[string]string `json:",optional"`
 	Compress            bool   `json:",optional"`
 	KeepDays            int    `json:",optional"`
 	StackCooldownMillis int    `json:",default=100"`
+	// MaxBackups represents how many backup log files will be kept. 0 means all files will be kept forever.
+	// Only take effect when Rotation is `size`.
+	// Even though `MaxBackups` sets 0, log files will still be removed
+	// if the `KeepDays` limitation is reached.
+	MaxBackups int `json:",default=0"`
+	// MaxSize represents how much space the writing log file takes up. 0 means no limit. The unit is `MB`.
+	// Only take effect when Rotation is `size`.
+	MaxSize int `json:",default=0"`
+	// Rotation represents the type of log rotation rule.
+	// daily: daily rotation.
+	// size: size limited rotation, combined with daily rotation.
+	Rotation string `json:",default=daily,options=daily|size"`
+	// SampleInitial is the number of entries per second of each severity
+	// that are always logged. 0 means no sampling.
+	SampleInitial int `json:",default=0"`
+	// SampleThereafter means 1 in SampleThereafter entries is logged
+	// after SampleInitial entries are logged within a second. 0 means no sampling.
+	SampleThereafter int `json:",default=0"`
+	// SuppressRepeated suppresses the duplicated messages within one second,
+	// a `last message repeated X times` summary is logged instead.
+	SuppressRepeated bool `json:",optional"`
+	// Encoding represents how the log entries are encoded.
+	// json: one json object per line.
+	// logfmt: key=value pairs.
+	// plain: human friendly console output.
+	Encoding string `json:",default=json,options=json|logfmt|plain"`
+	// Color enables colorized levels, only takes effect with plain encoding.
+	Color bool `json:",optional"`
+	// Categories overrides the settings of individual log categories,
+	// keyed by access, error, severe, slow or stat.
+	// Only takes effect with file or volume mode.
+	Categories map[string]CategoryConf `json:",optional"`
+	// Fields are the constant fields (service name, environment, region,
+	// build version etc.) appended to every entry in every category.
+	Fields map[string]string `json:",optional"`
 }
@@ -61,6 +61,7 @@ func (l *durationLogger) WithDuration(duration time.Duration) Logger {
 }
 
 func (l *durationLogger) write(writer io.Writer, level, content string) {
+	maybeAlert(level, content)
 	l.Timestamp = getTimestamp()
 	l.Level = level
 	l.Content = content
@@ -0,0 +1,202 @@
+package logx
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	jsonEncoding = iota
+	logfmtEncoding
+	plainEncoding
+)
+
+const (
+	jsonEncodingType   = "json"
+	logfmtEncodingType = "logfmt"
+	plainEncodingType  = "plain"
+
+	timestampKey = "@timestamp"
+	levelKey     = "level"
+	contentKey   = "content"
+)
+
+var (
+	// preferred order of the keys in logfmt and plain encodings.
+	orderedKeys = []string{timestampKey, levelKey, "duration", "name", "trace", "span"}
+
+	encoding     uint32 = jsonEncoding
+	coloredLevel uint32
+)
+
+// colors of the levels in colorized plain encoding.
+var levelColors = map[string]string{
+	levelInfo:   "\033[32m", // green
+	levelStat:   "\033[32m", // green
+	levelSlow:   "\033[33m", // yellow
+	levelError:  "\033[31m", // red
+	levelSevere: "\033[31m", // red
+	levelFatal:  "\033[31m", // red
+	levelAlert:  "\033[31m", // red
+}
+
+const colorReset = "\033[0m"
+
+func setupEncoding(c LogConf) {
+	switch c.Encoding {
+	case logfmtEncodingType:
+		atomic.StoreUint32(&encoding, logfmtEncoding)
+	case plainEncodingType:
+		atomic.StoreUint32(&encoding, plainEncoding)
+	default:
+		atomic.StoreUint32(&encoding, jsonEncoding)
+	}
+
+	if c.Color {
+		atomic.StoreUint32(&coloredLevel, 1)
+	} else {
+		atomic.StoreUint32(&coloredLevel, 0)
+	}
+}
+
+// encodeEntry encodes info with the configured encoding, without trailing newline.
+func encodeEntry(info interface{}) ([]byte, error) {
+	if fields := getGlobalFields(); len(fields) > 0 {
+		merged, err := entryFields(info)
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range fields {
+			// the entry fields take precedence
+			if _, ok := merged[k]; !ok {
+				merged[k] = v
+			}
+		}
+		info = merged
+	}
+
+	switch atomic.LoadUint32(&encoding) {
+	case logfmtEncoding:
+		return encodeLogfmt(info)
+	case plainEncoding:
+		return encodePlain(info)
+	default:
+		return json.Marshal(info)
+	}
+}
+
+func encodeLogfmt(info interface{}) ([]byte, error) {
+	fields, err := entryFields(info)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf strings.Builder
+	first := true
+	for _, key := range entryKeys(fields) {
+		if first {
+			first = false
+		} else {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		buf.WriteString(quoteLogfmt(fmt.Sprint(fields[key])))
+	}
+
+	return []byte(buf.String()), nil
+}
+
+func encodePlain(info interface{}) ([]byte, error) {
+	fields, err := entryFields(info)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf strings.Builder
+	buf.WriteString(fmt.Sprint(fields[timestampKey]))
+	level := fmt.Sprint(fields[levelKey])
+	buf.WriteString(" [")
+	if atomic.LoadUint32(&coloredLevel) == 1 {
+		if color, ok := levelColors[level]; ok {
+			buf.WriteString(color)
+			buf.WriteString(level)
+			buf.WriteString(colorReset)
+		} else {
+			buf.WriteString(level)
+		}
+	} else {
+		buf.WriteString(level)
+	}
+	buf.WriteString("] ")
+	buf.WriteString(fmt.Sprint(fields[contentKey]))
+
+	for _, key := range entryKeys(fields) {
+		switch key {
+		case timestampKey, levelKey, contentKey:
+			continue
+		}
+		buf.WriteByte(' ')
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		buf.WriteString(quoteLogfmt(fmt.Sprint(fields[key])))
+	}
+
+	return []byte(buf.String()), nil
+}
+
+// entryFields flattens info into key-value pairs, based on its json representation.
+func entryFields(info interface{}) (map[string]interface{}, error) {
+	content, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// entryKeys returns the keys of fields, preferred keys first, the rest sorted.
+func entryKeys(fields map[string]interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+
+	for _, key := range orderedKeys {
+		if _, ok := fields[key]; ok {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+
+	var rest []string
+	for key := range fields {
+		if !seen[key] && key != contentKey {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	keys = append(keys, rest...)
+
+	if _, ok := fields[contentKey]; ok {
+		keys = append(keys, contentKey)
+	}
+
+	return keys
+}
+
+func quoteLogfmt(value string) string {
+	if strings.ContainsAny(value, " =\"") {
+		return strconv.Quote(value)
+	}
+
+	return value
+}
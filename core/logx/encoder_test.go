@@ -0,0 +1,75 @@
+package logx
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetupEncoding(t *testing.T) {
+	defer setupEncoding(LogConf{})
+
+	setupEncoding(LogConf{Encoding: logfmtEncodingType})
+	assert.EqualValues(t, logfmtEncoding, atomic.LoadUint32(&encoding))
+	setupEncoding(LogConf{Encoding: plainEncodingType, Color: true})
+	assert.EqualValues(t, plainEncoding, atomic.LoadUint32(&encoding))
+	assert.EqualValues(t, 1, atomic.LoadUint32(&coloredLevel))
+	setupEncoding(LogConf{Encoding: jsonEncodingType})
+	assert.EqualValues(t, jsonEncoding, atomic.LoadUint32(&encoding))
+	assert.EqualValues(t, 0, atomic.LoadUint32(&coloredLevel))
+}
+
+func TestEncodeLogfmt(t *testing.T) {
+	content, err := encodeLogfmt(logEntry{
+		Timestamp: "2021-01-01T00:00:00.000Z",
+		Level:     levelInfo,
+		Duration:  "5.0ms",
+		Content:   "hello world",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, `@timestamp=2021-01-01T00:00:00.000Z level=info duration=5.0ms content="hello world"`,
+		string(content))
+}
+
+func TestEncodePlain(t *testing.T) {
+	content, err := encodePlain(logEntry{
+		Timestamp: "2021-01-01T00:00:00.000Z",
+		Level:     levelError,
+		Content:   "something failed",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "2021-01-01T00:00:00.000Z [error] something failed", string(content))
+}
+
+func TestEncodePlainColored(t *testing.T) {
+	atomic.StoreUint32(&coloredLevel, 1)
+	defer atomic.StoreUint32(&coloredLevel, 0)
+
+	content, err := encodePlain(logEntry{
+		Timestamp: "2021-01-01T00:00:00.000Z",
+		Level:     levelError,
+		Content:   "colored",
+	})
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(string(content), "\033[31merror\033[0m"))
+}
+
+func TestEncodeEntryWithTrace(t *testing.T) {
+	atomic.StoreUint32(&encoding, logfmtEncoding)
+	defer atomic.StoreUint32(&encoding, jsonEncoding)
+
+	content, err := encodeEntry(&traceLogger{
+		logEntry: logEntry{
+			Timestamp: "2021-01-01T00:00:00.000Z",
+			Level:     levelInfo,
+			Content:   "traced",
+		},
+		Trace: "trace-id",
+		Span:  "span-id",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "@timestamp=2021-01-01T00:00:00.000Z level=info trace=trace-id span=span-id content=traced",
+		string(content))
+}
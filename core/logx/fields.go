@@ -0,0 +1,36 @@
+package logx
+
+import "sync"
+
+var (
+	fieldsLock   sync.RWMutex
+	globalFields map[string]string
+)
+
+// SetGlobalFields sets the constant fields (like service name, environment,
+// region, build version) that are appended to every log entry in every category.
+// Nil or empty fields removes the previously set ones.
+func SetGlobalFields(fields map[string]string) {
+	fieldsLock.Lock()
+	defer fieldsLock.Unlock()
+
+	if len(fields) == 0 {
+		globalFields = nil
+		return
+	}
+
+	globalFields = make(map[string]string, len(fields))
+	for k, v := range fields {
+		globalFields[k] = v
+	}
+}
+
+func getGlobalFields() map[string]string {
+	fieldsLock.RLock()
+	defer fieldsLock.RUnlock()
+	return globalFields
+}
+
+func setupGlobalFields(c LogConf) {
+	SetGlobalFields(c.Fields)
+}
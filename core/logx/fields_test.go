@@ -0,0 +1,57 @@
+package logx
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetGlobalFields(t *testing.T) {
+	writer := new(mockWriter)
+	prevInfoLog := infoLog
+	infoLog = writer
+	prevInitialized := atomic.SwapUint32(&initialized, 1)
+	SetLevel(InfoLevel)
+	SetGlobalFields(map[string]string{
+		"service": "order",
+		"region":  "cn-east",
+	})
+	defer func() {
+		SetGlobalFields(nil)
+		infoLog = prevInfoLog
+		atomic.StoreUint32(&initialized, prevInitialized)
+	}()
+
+	Info("enriched entry")
+	assert.True(t, writer.Contains("enriched entry"))
+	assert.True(t, writer.Contains(`"service":"order"`))
+	assert.True(t, writer.Contains(`"region":"cn-east"`))
+}
+
+func TestGlobalFieldsDoNotOverrideEntry(t *testing.T) {
+	SetGlobalFields(map[string]string{
+		"level": "bogus",
+	})
+	defer SetGlobalFields(nil)
+
+	content, err := encodeEntry(logEntry{
+		Timestamp: "2021-01-01T00:00:00.000Z",
+		Level:     levelInfo,
+		Content:   "kept level",
+	})
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), `"level":"info"`)
+}
+
+func TestSetupGlobalFields(t *testing.T) {
+	setupGlobalFields(LogConf{
+		Fields: map[string]string{
+			"env": "test",
+		},
+	})
+	assert.Equal(t, "test", getGlobalFields()["env"])
+
+	setupGlobalFields(LogConf{})
+	assert.Nil(t, getGlobalFields())
+}
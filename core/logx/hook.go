@@ -0,0 +1,85 @@
+package logx
+
+import "sync"
+
+const defaultHookCooldownMillis = 100
+
+var (
+	hookLock   sync.RWMutex
+	alertHooks []*alertHook
+)
+
+type (
+	// An Entry is the structured log entry passed to the alert hooks.
+	Entry struct {
+		Timestamp string
+		Level     string
+		Content   string
+	}
+
+	// An AlertHookFunc is called with the entries at or above the registered level,
+	// typically to page through a webhook, sentry etc.
+	AlertHookFunc func(entry Entry)
+
+	alertHook struct {
+		level    uint32
+		executor *limitedExecutor
+		hook     AlertHookFunc
+	}
+)
+
+// RegisterAlertHook registers hook to be called for the entries at or above level.
+// The calls are rate limited to one per cooldownMillis to protect slow receivers,
+// non-positive cooldownMillis means the default cooldown of 100ms.
+func RegisterAlertHook(level uint32, cooldownMillis int, hook AlertHookFunc) {
+	if cooldownMillis <= 0 {
+		cooldownMillis = defaultHookCooldownMillis
+	}
+
+	hookLock.Lock()
+	defer hookLock.Unlock()
+	alertHooks = append(alertHooks, &alertHook{
+		level:    level,
+		executor: newLimitedExecutor(cooldownMillis),
+		hook:     hook,
+	})
+}
+
+func getAlertHooks() []*alertHook {
+	hookLock.RLock()
+	defer hookLock.RUnlock()
+	return alertHooks
+}
+
+func maybeAlert(level, msg string) {
+	hooks := getAlertHooks()
+	if len(hooks) == 0 {
+		return
+	}
+
+	severity := severityOf(level)
+	entry := Entry{
+		Timestamp: getTimestamp(),
+		Level:     level,
+		Content:   msg,
+	}
+	for _, h := range hooks {
+		if severity >= h.level {
+			hook := h.hook
+			h.executor.logOrDiscard(func() {
+				hook(entry)
+			})
+		}
+	}
+}
+
+func severityOf(level string) uint32 {
+	switch level {
+	case levelError, levelSlow:
+		return ErrorLevel
+	case levelSevere, levelFatal, levelAlert:
+		return SevereLevel
+	default:
+		return InfoLevel
+	}
+}
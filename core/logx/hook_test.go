@@ -0,0 +1,77 @@
+package logx
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterAlertHook(t *testing.T) {
+	writer := new(mockWriter)
+	prevInfoLog, prevErrorLog := infoLog, errorLog
+	infoLog = writer
+	errorLog = writer
+	prevInitialized := atomic.SwapUint32(&initialized, 1)
+	SetLevel(InfoLevel)
+	defer func() {
+		hookLock.Lock()
+		alertHooks = nil
+		hookLock.Unlock()
+		infoLog, errorLog = prevInfoLog, prevErrorLog
+		atomic.StoreUint32(&initialized, prevInitialized)
+	}()
+
+	var lock sync.Mutex
+	var entries []Entry
+	RegisterAlertHook(ErrorLevel, 1, func(entry Entry) {
+		lock.Lock()
+		entries = append(entries, entry)
+		lock.Unlock()
+	})
+
+	Info("info not hooked")
+	Error("error hooked")
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, 1, len(entries))
+	assert.Equal(t, levelError, entries[0].Level)
+	assert.Contains(t, entries[0].Content, "error hooked")
+}
+
+func TestAlertHookRateLimit(t *testing.T) {
+	writer := new(mockWriter)
+	prevErrorLog := errorLog
+	errorLog = writer
+	prevInitialized := atomic.SwapUint32(&initialized, 1)
+	SetLevel(InfoLevel)
+	defer func() {
+		hookLock.Lock()
+		alertHooks = nil
+		hookLock.Unlock()
+		errorLog = prevErrorLog
+		atomic.StoreUint32(&initialized, prevInitialized)
+	}()
+
+	var calls uint32
+	RegisterAlertHook(ErrorLevel, 1000, func(entry Entry) {
+		atomic.AddUint32(&calls, 1)
+	})
+
+	for i := 0; i < 100; i++ {
+		Errorf("burst %d", i)
+	}
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&calls))
+}
+
+func TestSeverityOf(t *testing.T) {
+	assert.Equal(t, uint32(InfoLevel), severityOf(levelInfo))
+	assert.Equal(t, uint32(InfoLevel), severityOf(levelStat))
+	assert.Equal(t, uint32(ErrorLevel), severityOf(levelError))
+	assert.Equal(t, uint32(ErrorLevel), severityOf(levelSlow))
+	assert.Equal(t, uint32(SevereLevel), severityOf(levelSevere))
+	assert.Equal(t, uint32(SevereLevel), severityOf(levelFatal))
+	assert.Equal(t, uint32(SevereLevel), severityOf(levelAlert))
+}
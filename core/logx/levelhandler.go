@@ -0,0 +1,50 @@
+package logx
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// LevelHandler returns the http handler to query or adjust the logging level at runtime.
+// A GET request responds with the current level, a POST or PUT request with the
+// `level` parameter set to info, error or severe adjusts the level,
+// and it takes effect immediately.
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprintf(w, `{"level":%q}`+"\n", levelName(Level()))
+		case http.MethodPost, http.MethodPut:
+			level := r.FormValue("level")
+			switch level {
+			case levelInfo:
+				SetLevel(InfoLevel)
+			case levelError:
+				SetLevel(ErrorLevel)
+			case levelSevere:
+				SetLevel(SevereLevel)
+			default:
+				http.Error(w, fmt.Sprintf("unknown level: %q", level), http.StatusBadRequest)
+				return
+			}
+
+			Alert("logging level set to " + level)
+			fmt.Fprintf(w, `{"level":%q}`+"\n", level)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func levelName(level uint32) string {
+	switch level {
+	case InfoLevel:
+		return levelInfo
+	case ErrorLevel:
+		return levelError
+	case SevereLevel:
+		return levelSevere
+	default:
+		return fmt.Sprint(level)
+	}
+}
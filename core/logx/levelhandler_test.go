@@ -0,0 +1,59 @@
+package logx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelHandlerGet(t *testing.T) {
+	SetLevel(ErrorLevel)
+	defer SetLevel(InfoLevel)
+
+	resp := httptest.NewRecorder()
+	LevelHandler().ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/loglevel", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `{"level":"error"}`+"\n", resp.Body.String())
+}
+
+func TestLevelHandlerSet(t *testing.T) {
+	defer SetLevel(InfoLevel)
+
+	resp := httptest.NewRecorder()
+	LevelHandler().ServeHTTP(resp, httptest.NewRequest(http.MethodPost,
+		"/loglevel?level=severe", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.EqualValues(t, SevereLevel, Level())
+
+	resp = httptest.NewRecorder()
+	LevelHandler().ServeHTTP(resp, httptest.NewRequest(http.MethodPut,
+		"/loglevel?level=bogus", nil))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.EqualValues(t, SevereLevel, Level())
+}
+
+func TestLevelHandlerMethodNotAllowed(t *testing.T) {
+	resp := httptest.NewRecorder()
+	LevelHandler().ServeHTTP(resp, httptest.NewRequest(http.MethodDelete, "/loglevel", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+}
+
+func TestToggleVerbose(t *testing.T) {
+	defer SetLevel(InfoLevel)
+
+	SetLevel(InfoLevel)
+	ToggleVerbose()
+	assert.EqualValues(t, ErrorLevel, Level())
+	ToggleVerbose()
+	assert.EqualValues(t, InfoLevel, Level())
+}
+
+func TestLevelName(t *testing.T) {
+	assert.Equal(t, levelInfo, levelName(InfoLevel))
+	assert.Equal(t, levelError, levelName(ErrorLevel))
+	assert.Equal(t, levelSevere, levelName(SevereLevel))
+	assert.True(t, strings.Contains(levelName(42), "42"))
+}
@@ -1,7 +1,6 @@
 package logx
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -38,6 +37,12 @@ const (
 	slowFilename   = "slow.log"
 	statFilename   = "stat.log"
 
+	accessCategory = "access"
+	errorCategory  = "error"
+	severeCategory = "severe"
+	slowCategory   = "slow"
+	statCategory   = "stat"
+
 	consoleMode = "console"
 	volumeMode  = "volume"
 
@@ -52,6 +57,9 @@ const (
 	backupFileDelimiter = "-"
 	callerInnerDepth    = 5
 	flags               = 0x0
+
+	rotationRuleDaily = "daily"
+	rotationRuleSize  = "size"
 )
 
 var (
@@ -89,6 +97,9 @@ type (
 		gzipEnabled           bool
 		logStackCooldownMills int
 		keepDays              int
+		maxBackups            int
+		maxSize               int
+		rotationRule          string
 	}
 
 	// LogOption defines the method to customize the logging.
@@ -120,6 +131,10 @@ func SetUp(c LogConf) error {
 		timeFormat = c.TimeFormat
 	}
 
+	setupSampling(c)
+	setupEncoding(c)
+	setupGlobalFields(c)
+
 	switch c.Mode {
 	case consoleMode:
 		setupWithConsole(c)
@@ -138,6 +153,9 @@ func Alert(v string) {
 
 // Close closes the logging.
 func Close() error {
+	// flush and close the custom writer if any
+	resetWriter()
+
 	if writeConsole {
 		return nil
 	}
@@ -247,11 +265,29 @@ func Must(err error) {
 	}
 }
 
+// Level returns the current logging level.
+func Level() uint32 {
+	return atomic.LoadUint32(&logLevel)
+}
+
 // SetLevel sets the logging level. It can be used to suppress some logs.
+// It's safe to call concurrently, and takes effect immediately.
 func SetLevel(level uint32) {
 	atomic.StoreUint32(&logLevel, level)
 }
 
+// ToggleVerbose toggles the logging level between InfoLevel and ErrorLevel,
+// so verbose logging can be enabled on a live instance without a restart.
+func ToggleVerbose() {
+	if Level() == InfoLevel {
+		SetLevel(ErrorLevel)
+		Alert("verbose logging disabled")
+	} else {
+		SetLevel(InfoLevel)
+		Alert("verbose logging enabled")
+	}
+}
+
 // Severe writes v into severe log.
 func Severe(v ...interface{}) {
 	severeSync(fmt.Sprint(v...))
@@ -296,6 +332,27 @@ func WithKeepDays(days int) LogOption {
 	}
 }
 
+// WithMaxBackups customizes how many log files backups will be kept.
+func WithMaxBackups(count int) LogOption {
+	return func(opts *logOptions) {
+		opts.maxBackups = count
+	}
+}
+
+// WithMaxSize customizes how much space the writing log file can take up.
+func WithMaxSize(size int) LogOption {
+	return func(opts *logOptions) {
+		opts.maxSize = size
+	}
+}
+
+// WithRotation customizes which log rotation rule to use.
+func WithRotation(r string) LogOption {
+	return func(opts *logOptions) {
+		opts.rotationRule = r
+	}
+}
+
 // WithGzip customizes logging to automatically gzip the log files.
 func WithGzip() LogOption {
 	return func(opts *logOptions) {
@@ -304,12 +361,56 @@ func WithGzip() LogOption {
 }
 
 func createOutput(path string) (io.WriteCloser, error) {
+	return createOutputWithOptions(path, options)
+}
+
+func createOutputWithOptions(path string, opts logOptions) (io.WriteCloser, error) {
 	if len(path) == 0 {
 		return nil, ErrLogPathNotSet
 	}
 
-	return NewLogger(path, DefaultRotateRule(path, backupFileDelimiter, options.keepDays,
-		options.gzipEnabled), options.gzipEnabled)
+	switch opts.rotationRule {
+	case rotationRuleSize:
+		return NewLogger(path, NewSizeLimitRotateRule(path, backupFileDelimiter, opts.keepDays,
+			opts.maxSize, opts.maxBackups, opts.gzipEnabled), opts.gzipEnabled)
+	default:
+		return NewLogger(path, DefaultRotateRule(path, backupFileDelimiter, opts.keepDays,
+			opts.gzipEnabled), opts.gzipEnabled)
+	}
+}
+
+// createCategoryOutput creates the output of the given category,
+// applying the category overrides in c if any.
+func createCategoryOutput(c LogConf, category, filename string) (io.WriteCloser, error) {
+	cc, ok := c.Categories[category]
+	if !ok {
+		return createOutput(path.Join(c.Path, filename))
+	}
+
+	if cc.Disabled {
+		return iox.NopCloser(ioutil.Discard), nil
+	}
+
+	opts := options
+	if cc.KeepDays > 0 {
+		opts.keepDays = cc.KeepDays
+	}
+	if cc.MaxBackups > 0 {
+		opts.maxBackups = cc.MaxBackups
+	}
+	if cc.MaxSize > 0 {
+		opts.maxSize = cc.MaxSize
+	}
+	if len(cc.Rotation) > 0 {
+		opts.rotationRule = cc.Rotation
+	}
+
+	dir := c.Path
+	if len(cc.Path) > 0 {
+		dir = cc.Path
+	}
+
+	return createOutputWithOptions(path.Join(dir, filename), opts)
 }
 
 func errorSync(msg string, callDepth int) {
@@ -369,12 +470,15 @@ func infoSync(msg string) {
 }
 
 func output(writer io.Writer, level, msg string) {
-	info := logEntry{
-		Timestamp: getTimestamp(),
-		Level:     level,
-		Content:   msg,
+	for _, content := range filterMessages(level, msg) {
+		maybeAlert(level, content)
+		info := logEntry{
+			Timestamp: getTimestamp(),
+			Level:     level,
+			Content:   content,
+		}
+		outputJson(writer, info)
 	}
-	outputJson(writer, info)
 }
 
 func outputError(writer io.Writer, msg string, callDepth int) {
@@ -383,8 +487,12 @@ func outputError(writer io.Writer, msg string, callDepth int) {
 }
 
 func outputJson(writer io.Writer, info interface{}) {
-	if content, err := json.Marshal(info); err != nil {
+	if content, err := encodeEntry(info); err != nil {
 		log.Println(err.Error())
+	} else if w := getWriter(); w != nil {
+		if err := w.Write(append(content, '\n')); err != nil {
+			log.Println(err.Error())
+		}
 	} else if atomic.LoadUint32(&initialized) == 0 || writer == nil {
 		log.Println(string(content))
 	} else {
@@ -401,6 +509,17 @@ func setupLogLevel(c LogConf) {
 	case levelSevere:
 		SetLevel(SevereLevel)
 	}
+
+	for name, level := range c.Levels {
+		switch level {
+		case levelInfo:
+			SetLevelFor(name, InfoLevel)
+		case levelError:
+			SetLevelFor(name, ErrorLevel)
+		case levelSevere:
+			SetLevelFor(name, SevereLevel)
+		}
+	}
 }
 
 func setupWithConsole(c LogConf) {
@@ -433,35 +552,36 @@ func setupWithFiles(c LogConf) error {
 	if c.KeepDays > 0 {
 		opts = append(opts, WithKeepDays(c.KeepDays))
 	}
-
-	accessFile := path.Join(c.Path, accessFilename)
-	errorFile := path.Join(c.Path, errorFilename)
-	severeFile := path.Join(c.Path, severeFilename)
-	slowFile := path.Join(c.Path, slowFilename)
-	statFile := path.Join(c.Path, statFilename)
+	if c.MaxBackups > 0 {
+		opts = append(opts, WithMaxBackups(c.MaxBackups))
+	}
+	if c.MaxSize > 0 {
+		opts = append(opts, WithMaxSize(c.MaxSize))
+	}
+	opts = append(opts, WithRotation(c.Rotation))
 
 	once.Do(func() {
 		atomic.StoreUint32(&initialized, 1)
 		handleOptions(opts)
 		setupLogLevel(c)
 
-		if infoLog, err = createOutput(accessFile); err != nil {
+		if infoLog, err = createCategoryOutput(c, accessCategory, accessFilename); err != nil {
 			return
 		}
 
-		if errorLog, err = createOutput(errorFile); err != nil {
+		if errorLog, err = createCategoryOutput(c, errorCategory, errorFilename); err != nil {
 			return
 		}
 
-		if severeLog, err = createOutput(severeFile); err != nil {
+		if severeLog, err = createCategoryOutput(c, severeCategory, severeFilename); err != nil {
 			return
 		}
 
-		if slowLog, err = createOutput(slowFile); err != nil {
+		if slowLog, err = createCategoryOutput(c, slowCategory, slowFilename); err != nil {
 			return
 		}
 
-		if statLog, err = createOutput(statFile); err != nil {
+		if statLog, err = createCategoryOutput(c, statCategory, statFilename); err != nil {
 			return
 		}
 
@@ -0,0 +1,90 @@
+package logx
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/timex"
+)
+
+// levels of named loggers, name -> level.
+var namedLevels sync.Map
+
+type namedLogger struct {
+	logEntry
+	Name string `json:"name,omitempty"`
+}
+
+// Named returns a Logger with the given name, like logx.Named("sqlx").
+// The logging level of a named logger can be adjusted independently
+// with SetLevelFor or the Levels field in LogConf,
+// and falls back to the global level if not set.
+func Named(name string) Logger {
+	return &namedLogger{
+		Name: name,
+	}
+}
+
+// SetLevelFor sets the logging level for the named logger with given name.
+func SetLevelFor(name string, level uint32) {
+	namedLevels.Store(name, level)
+}
+
+func (l *namedLogger) Error(v ...interface{}) {
+	if l.shouldLog(ErrorLevel) {
+		l.write(errorLog, levelError, formatWithCaller(fmt.Sprint(v...), durationCallerDepth))
+	}
+}
+
+func (l *namedLogger) Errorf(format string, v ...interface{}) {
+	if l.shouldLog(ErrorLevel) {
+		l.write(errorLog, levelError, formatWithCaller(fmt.Sprintf(format, v...), durationCallerDepth))
+	}
+}
+
+func (l *namedLogger) Info(v ...interface{}) {
+	if l.shouldLog(InfoLevel) {
+		l.write(infoLog, levelInfo, fmt.Sprint(v...))
+	}
+}
+
+func (l *namedLogger) Infof(format string, v ...interface{}) {
+	if l.shouldLog(InfoLevel) {
+		l.write(infoLog, levelInfo, fmt.Sprintf(format, v...))
+	}
+}
+
+func (l *namedLogger) Slow(v ...interface{}) {
+	if l.shouldLog(ErrorLevel) {
+		l.write(slowLog, levelSlow, fmt.Sprint(v...))
+	}
+}
+
+func (l *namedLogger) Slowf(format string, v ...interface{}) {
+	if l.shouldLog(ErrorLevel) {
+		l.write(slowLog, levelSlow, fmt.Sprintf(format, v...))
+	}
+}
+
+func (l *namedLogger) WithDuration(duration time.Duration) Logger {
+	l.Duration = timex.ReprOfDuration(duration)
+	return l
+}
+
+func (l *namedLogger) shouldLog(level uint32) bool {
+	if v, ok := namedLevels.Load(l.Name); ok {
+		return v.(uint32) <= level
+	}
+
+	return shouldLog(level)
+}
+
+func (l *namedLogger) write(writer io.Writer, level, content string) {
+	maybeAlert(level, content)
+	l.Timestamp = getTimestamp()
+	l.Level = level
+	l.Content = content
+	outputJson(writer, l)
+}
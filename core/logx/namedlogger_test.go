@@ -0,0 +1,71 @@
+package logx
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamedLogger(t *testing.T) {
+	writer := new(mockWriter)
+	infoLog = writer
+	atomic.StoreUint32(&initialized, 1)
+	SetLevel(InfoLevel)
+	defer writer.Reset()
+
+	logger := Named("sqlx")
+	logger.Info("named info")
+	assert.True(t, writer.Contains("named info"))
+	assert.True(t, writer.Contains(`"name":"sqlx"`))
+}
+
+func TestNamedLoggerLevel(t *testing.T) {
+	writer := new(mockWriter)
+	infoLog = writer
+	errorLog = writer
+	slowLog = writer
+	atomic.StoreUint32(&initialized, 1)
+	SetLevel(InfoLevel)
+	defer writer.Reset()
+
+	SetLevelFor("discov", ErrorLevel)
+	defer namedLevels.Delete("discov")
+
+	logger := Named("discov")
+	logger.Infof("unwanted %s", "info")
+	assert.False(t, writer.Contains("unwanted info"))
+	logger.Errorf("wanted %s", "error")
+	assert.True(t, writer.Contains("wanted error"))
+
+	// not configured, falls back to the global level
+	other := Named("other")
+	other.Slow("slow query")
+	assert.True(t, writer.Contains("slow query"))
+}
+
+func TestNamedLoggerWithDuration(t *testing.T) {
+	writer := new(mockWriter)
+	infoLog = writer
+	atomic.StoreUint32(&initialized, 1)
+	SetLevel(InfoLevel)
+	defer writer.Reset()
+
+	Named("mongo").WithDuration(time.Second).Info("timed")
+	assert.True(t, writer.Contains(`"duration":"1000.0ms"`))
+}
+
+func TestSetupLogLevels(t *testing.T) {
+	defer namedLevels.Delete("redis")
+
+	setupLogLevel(LogConf{
+		Level: levelInfo,
+		Levels: map[string]string{
+			"redis": levelSevere,
+		},
+	})
+	v, ok := namedLevels.Load("redis")
+	assert.True(t, ok)
+	assert.EqualValues(t, SevereLevel, v)
+}
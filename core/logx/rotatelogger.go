@@ -9,6 +9,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -20,10 +21,12 @@ import (
 
 const (
 	dateFormat      = "2006-01-02"
+	fileTimeFormat  = time.RFC3339
 	hoursPerDay     = 24
 	bufferSize      = 100
 	defaultDirMode  = 0755
 	defaultFileMode = 0600
+	megaBytes       = 1 << 20
 )
 
 // ErrLogFileClosed is an error that indicates the log file is already closed.
@@ -35,7 +38,7 @@ type (
 		BackupFileName() string
 		MarkRotated()
 		OutdatedFiles() []string
-		ShallRotate() bool
+		ShallRotate(size int64) bool
 	}
 
 	// A RotateLogger is a Logger that can rotate log files with given rules.
@@ -45,9 +48,10 @@ type (
 		fp       *os.File
 		channel  chan []byte
 		done     chan lang.PlaceholderType
-		rule     RotateRule
-		compress bool
-		keepDays int
+		rule        RotateRule
+		compress    bool
+		keepDays    int
+		currentSize int64
 		// can't use threading.RoutineGroup because of cycle import
 		waitGroup sync.WaitGroup
 		closeOnce sync.Once
@@ -61,6 +65,14 @@ type (
 		days        int
 		gzip        bool
 	}
+
+	// A SizeLimitRotateRule is a rule to rotate the log files on exceeding the size limit,
+	// besides the daily rotation, and keeps at most maxBackups backup files.
+	SizeLimitRotateRule struct {
+		DailyRotateRule
+		maxSize    int64
+		maxBackups int
+	}
 )
 
 // DefaultRotateRule is a default log rotating rule, currently DailyRotateRule.
@@ -122,10 +134,86 @@ func (r *DailyRotateRule) OutdatedFiles() []string {
 }
 
 // ShallRotate checks if the file should be rotated.
-func (r *DailyRotateRule) ShallRotate() bool {
+func (r *DailyRotateRule) ShallRotate(size int64) bool {
 	return len(r.rotatedTime) > 0 && getNowDate() != r.rotatedTime
 }
 
+// NewSizeLimitRotateRule returns the rotation rule with size limit.
+func NewSizeLimitRotateRule(filename, delimiter string, days, maxSize, maxBackups int, gzip bool) RotateRule {
+	return &SizeLimitRotateRule{
+		DailyRotateRule: DailyRotateRule{
+			rotatedTime: getNowDate(),
+			filename:    filename,
+			delimiter:   delimiter,
+			days:        days,
+			gzip:        gzip,
+		},
+		maxSize:    int64(maxSize) * megaBytes,
+		maxBackups: maxBackups,
+	}
+}
+
+// BackupFileName returns the backup filename on rotating.
+func (r *SizeLimitRotateRule) BackupFileName() string {
+	dateAndTime := time.Now().Format(fileTimeFormat)
+	return fmt.Sprintf("%s%s%s", r.filename, r.delimiter, dateAndTime)
+}
+
+// OutdatedFiles returns the files that exceeded the keeping days or the max backups.
+func (r *SizeLimitRotateRule) OutdatedFiles() []string {
+	var pattern string
+	if r.gzip {
+		pattern = fmt.Sprintf("%s%s*.gz", r.filename, r.delimiter)
+	} else {
+		pattern = fmt.Sprintf("%s%s*", r.filename, r.delimiter)
+	}
+
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		Errorf("failed to delete outdated log files, error: %s", err)
+		return nil
+	}
+
+	sort.Strings(files)
+
+	outdated := make(map[string]lang.PlaceholderType)
+
+	// test if too many backups
+	if r.maxBackups > 0 && len(files) > r.maxBackups {
+		for _, file := range files[:len(files)-r.maxBackups] {
+			outdated[file] = lang.Placeholder
+		}
+	}
+
+	// test if any too old backups
+	if r.days > 0 {
+		boundary := time.Now().Add(-time.Hour * time.Duration(hoursPerDay*r.days)).Format(fileTimeFormat)
+		var buf strings.Builder
+		fmt.Fprintf(&buf, "%s%s%s", r.filename, r.delimiter, boundary)
+		if r.gzip {
+			buf.WriteString(".gz")
+		}
+		boundaryFile := buf.String()
+		for _, file := range files {
+			if file < boundaryFile {
+				outdated[file] = lang.Placeholder
+			}
+		}
+	}
+
+	var result []string
+	for file := range outdated {
+		result = append(result, file)
+	}
+
+	return result
+}
+
+// ShallRotate checks if the file should be rotated.
+func (r *SizeLimitRotateRule) ShallRotate(size int64) bool {
+	return r.DailyRotateRule.ShallRotate(size) || (r.maxSize > 0 && r.maxSize < size)
+}
+
 // NewLogger returns a RotateLogger with given filename and rule, etc.
 func NewLogger(filename string, rule RotateRule, compress bool) (*RotateLogger, error) {
 	l := &RotateLogger{
@@ -193,8 +281,14 @@ func (l *RotateLogger) init() error {
 		if l.fp, err = os.Create(l.filename); err != nil {
 			return err
 		}
-	} else if l.fp, err = os.OpenFile(l.filename, os.O_APPEND|os.O_WRONLY, defaultFileMode); err != nil {
-		return err
+	} else {
+		if l.fp, err = os.OpenFile(l.filename, os.O_APPEND|os.O_WRONLY, defaultFileMode); err != nil {
+			return err
+		}
+
+		if fileInfo, err := os.Stat(l.filename); err == nil {
+			l.currentSize = fileInfo.Size()
+		}
 	}
 
 	fs.CloseOnExec(l.fp)
@@ -278,15 +372,17 @@ func (l *RotateLogger) startWorker() {
 }
 
 func (l *RotateLogger) write(v []byte) {
-	if l.rule.ShallRotate() {
+	if l.rule.ShallRotate(l.currentSize + int64(len(v))) {
 		if err := l.rotate(); err != nil {
 			log.Println(err)
 		} else {
 			l.rule.MarkRotated()
+			l.currentSize = 0
 		}
 	}
 	if l.fp != nil {
 		l.fp.Write(v)
+		l.currentSize += int64(len(v))
 	}
 }
 
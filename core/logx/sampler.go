@@ -0,0 +1,161 @@
+package logx
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const repeatedSummaryFormat = "last message repeated %d times"
+
+var (
+	samplingLock sync.RWMutex
+	sampler      *logSampler
+	dedupper     *logDedupper
+)
+
+type (
+	// a logSampler samples the entries per severity, the first initial entries
+	// in each second are logged, and 1 in thereafter of the rest.
+	logSampler struct {
+		initial    uint64
+		thereafter uint64
+		counters   sync.Map // level -> *sampleCounter
+	}
+
+	sampleCounter struct {
+		second int64
+		count  uint64
+	}
+
+	// a logDedupper suppresses the duplicated messages per severity,
+	// and emits a summary on how many times the message repeated.
+	logDedupper struct {
+		lock   sync.Mutex
+		states map[string]*repeatState // level -> state
+	}
+
+	repeatState struct {
+		msg      string
+		second   int64
+		repeated int
+	}
+)
+
+// SetSampling enables sampling on logging, the first initial entries per second
+// of each severity are logged, then 1 in thereafter of the rest.
+// Zero initial or thereafter disables sampling.
+func SetSampling(initial, thereafter int) {
+	samplingLock.Lock()
+	defer samplingLock.Unlock()
+
+	if initial > 0 && thereafter > 0 {
+		sampler = &logSampler{
+			initial:    uint64(initial),
+			thereafter: uint64(thereafter),
+		}
+	} else {
+		sampler = nil
+	}
+}
+
+// SetSuppressRepeated enables or disables the duplicate message suppression,
+// duplicated messages within one second are replaced with a repeated summary.
+func SetSuppressRepeated(on bool) {
+	samplingLock.Lock()
+	defer samplingLock.Unlock()
+
+	if on {
+		dedupper = &logDedupper{
+			states: make(map[string]*repeatState),
+		}
+	} else {
+		dedupper = nil
+	}
+}
+
+func getDedupper() *logDedupper {
+	samplingLock.RLock()
+	defer samplingLock.RUnlock()
+	return dedupper
+}
+
+func getSampler() *logSampler {
+	samplingLock.RLock()
+	defer samplingLock.RUnlock()
+	return sampler
+}
+
+func setupSampling(c LogConf) {
+	SetSampling(c.SampleInitial, c.SampleThereafter)
+	SetSuppressRepeated(c.SuppressRepeated)
+}
+
+// filterMessages applies duplicate suppression and sampling on msg,
+// and returns the messages that shall be written, in order.
+func filterMessages(level, msg string) []string {
+	var messages []string
+
+	if d := getDedupper(); d != nil {
+		emit, summary := d.filter(level, msg)
+		if len(summary) > 0 {
+			messages = append(messages, summary)
+		}
+		if !emit {
+			return messages
+		}
+	}
+
+	if s := getSampler(); s != nil && !s.shouldLog(level) {
+		return messages
+	}
+
+	return append(messages, msg)
+}
+
+func (d *logDedupper) filter(level, msg string) (bool, string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	state, ok := d.states[level]
+	if !ok {
+		state = new(repeatState)
+		d.states[level] = state
+	}
+
+	now := time.Now().Unix()
+	if msg == state.msg && now == state.second {
+		state.repeated++
+		return false, ""
+	}
+
+	var summary string
+	if state.repeated > 0 {
+		summary = fmt.Sprintf(repeatedSummaryFormat, state.repeated)
+	}
+
+	state.msg = msg
+	state.second = now
+	state.repeated = 0
+
+	return true, summary
+}
+
+func (s *logSampler) shouldLog(level string) bool {
+	val, _ := s.counters.LoadOrStore(level, new(sampleCounter))
+	counter := val.(*sampleCounter)
+
+	now := time.Now().Unix()
+	if atomic.LoadInt64(&counter.second) != now {
+		atomic.StoreInt64(&counter.second, now)
+		atomic.StoreUint64(&counter.count, 0)
+	}
+
+	count := atomic.AddUint64(&counter.count, 1)
+	if count <= s.initial {
+		return true
+	}
+
+	return (count-s.initial)%s.thereafter == 0
+}
@@ -0,0 +1,88 @@
+package logx
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSamplerShouldLog(t *testing.T) {
+	s := &logSampler{
+		initial:    2,
+		thereafter: 5,
+	}
+
+	var logged int
+	for i := 0; i < 12; i++ {
+		if s.shouldLog(levelError) {
+			logged++
+		}
+	}
+	// first 2, then 1 in 5 of the other 10
+	assert.Equal(t, 4, logged)
+	// severities are sampled independently
+	assert.True(t, s.shouldLog(levelInfo))
+}
+
+func TestDedupperFilter(t *testing.T) {
+	d := &logDedupper{
+		states: make(map[string]*repeatState),
+	}
+
+	emit, summary := d.filter(levelError, "boom")
+	assert.True(t, emit)
+	assert.Empty(t, summary)
+
+	for i := 0; i < 3; i++ {
+		emit, summary = d.filter(levelError, "boom")
+		assert.False(t, emit)
+		assert.Empty(t, summary)
+	}
+
+	emit, summary = d.filter(levelError, "another")
+	assert.True(t, emit)
+	assert.Equal(t, fmt.Sprintf(repeatedSummaryFormat, 3), summary)
+
+	// other severities are not affected
+	emit, summary = d.filter(levelInfo, "boom")
+	assert.True(t, emit)
+	assert.Empty(t, summary)
+}
+
+func TestOutputWithSuppressRepeated(t *testing.T) {
+	writer := new(mockWriter)
+	errorLog = writer
+	atomic.StoreUint32(&initialized, 1)
+	SetLevel(InfoLevel)
+	SetSuppressRepeated(true)
+	defer func() {
+		SetSuppressRepeated(false)
+		writer.Reset()
+	}()
+
+	for i := 0; i < 5; i++ {
+		Error("same error")
+	}
+	Error("different error")
+
+	assert.Equal(t, 1, strings.Count(writer.String(), "same error"))
+	assert.True(t, writer.Contains(fmt.Sprintf(repeatedSummaryFormat, 4)))
+	assert.True(t, writer.Contains("different error"))
+}
+
+func TestSetupSampling(t *testing.T) {
+	setupSampling(LogConf{
+		SampleInitial:    100,
+		SampleThereafter: 10,
+		SuppressRepeated: true,
+	})
+	assert.NotNil(t, getSampler())
+	assert.NotNil(t, getDedupper())
+
+	setupSampling(LogConf{})
+	assert.Nil(t, getSampler())
+	assert.Nil(t, getDedupper())
+}
@@ -59,6 +59,7 @@ func (l *traceLogger) WithDuration(duration time.Duration) Logger {
 }
 
 func (l *traceLogger) write(writer io.Writer, level, content string) {
+	maybeAlert(level, content)
 	l.Timestamp = getTimestamp()
 	l.Level = level
 	l.Content = content
@@ -0,0 +1,214 @@
+package logx
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/lang"
+)
+
+const (
+	defaultWriterBufferSize = 1024
+	defaultWriterBatchSize  = 32
+	defaultFlushInterval    = time.Second
+)
+
+var (
+	writerLock   sync.RWMutex
+	customWriter Writer
+)
+
+type (
+	// A Writer is implemented by log backends, like syslog, kafka, fluentd
+	// or any custom collector. Entries are passed as encoded log lines.
+	Writer interface {
+		Write(p []byte) error
+		Close() error
+	}
+
+	// WriterOption defines the method to customize the writer behavior.
+	WriterOption func(opts *writerOptions)
+
+	writerOptions struct {
+		bufferSize    int
+		batchSize     int
+		flushInterval time.Duration
+	}
+
+	// an asyncWriter decouples the logging hot path from the backend,
+	// batching entries and dropping on full buffer instead of blocking.
+	asyncWriter struct {
+		backend   Writer
+		channel   chan []byte
+		done      chan lang.PlaceholderType
+		interval  time.Duration
+		batchSize int
+		dropped   uint64
+		waitGroup sync.WaitGroup
+		closeOnce sync.Once
+	}
+)
+
+// SetWriter sets the writer as the log backend, instead of console or files.
+// The writes to given writer are batched, and the entries are dropped
+// if the internal buffer is full, to protect the logging hot path.
+// The previous writer will be closed if there is one.
+func SetWriter(writer Writer, opts ...WriterOption) {
+	var options writerOptions
+	options.bufferSize = defaultWriterBufferSize
+	options.batchSize = defaultWriterBatchSize
+	options.flushInterval = defaultFlushInterval
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	writerLock.Lock()
+	prev := customWriter
+	customWriter = newAsyncWriter(writer, options)
+	writerLock.Unlock()
+
+	if prev != nil {
+		if err := prev.Close(); err != nil {
+			log.Println(err.Error())
+		}
+	}
+}
+
+// WithWriterBufferSize customizes the size of the writer buffer,
+// entries are dropped on writing to a full buffer.
+func WithWriterBufferSize(size int) WriterOption {
+	return func(opts *writerOptions) {
+		opts.bufferSize = size
+	}
+}
+
+// WithWriterBatchSize customizes how many entries are flushed to the backend at most in one batch.
+func WithWriterBatchSize(size int) WriterOption {
+	return func(opts *writerOptions) {
+		opts.batchSize = size
+	}
+}
+
+// WithFlushInterval customizes the interval to flush the buffered entries to the backend.
+func WithFlushInterval(interval time.Duration) WriterOption {
+	return func(opts *writerOptions) {
+		opts.flushInterval = interval
+	}
+}
+
+func getWriter() Writer {
+	writerLock.RLock()
+	defer writerLock.RUnlock()
+	return customWriter
+}
+
+func resetWriter() {
+	writerLock.Lock()
+	prev := customWriter
+	customWriter = nil
+	writerLock.Unlock()
+
+	if prev != nil {
+		prev.Close()
+	}
+}
+
+func newAsyncWriter(backend Writer, options writerOptions) *asyncWriter {
+	w := &asyncWriter{
+		backend:   backend,
+		channel:   make(chan []byte, options.bufferSize),
+		done:      make(chan lang.PlaceholderType),
+		interval:  options.flushInterval,
+		batchSize: options.batchSize,
+	}
+	w.startWorker()
+
+	return w
+}
+
+// Close flushes the buffered entries and closes the backend.
+func (w *asyncWriter) Close() error {
+	var err error
+
+	w.closeOnce.Do(func() {
+		close(w.done)
+		w.waitGroup.Wait()
+		err = w.backend.Close()
+	})
+
+	return err
+}
+
+// Write puts p into the buffer, drops p if the buffer is full.
+func (w *asyncWriter) Write(p []byte) error {
+	select {
+	case w.channel <- p:
+		return nil
+	case <-w.done:
+		return ErrLogNotInitialized
+	default:
+		atomic.AddUint64(&w.dropped, 1)
+		return nil
+	}
+}
+
+func (w *asyncWriter) flush(batch [][]byte) [][]byte {
+	for _, entry := range batch {
+		if err := w.backend.Write(entry); err != nil {
+			log.Println(err.Error())
+		}
+	}
+
+	if dropped := atomic.SwapUint64(&w.dropped, 0); dropped > 0 {
+		entry := logEntry{
+			Timestamp: getTimestamp(),
+			Level:     levelError,
+			Content:   fmt.Sprintf("dropped %d log entries on full buffer", dropped),
+		}
+		if content, err := json.Marshal(entry); err == nil {
+			w.backend.Write(append(content, '\n'))
+		}
+	}
+
+	return batch[:0]
+}
+
+func (w *asyncWriter) startWorker() {
+	w.waitGroup.Add(1)
+
+	go func() {
+		defer w.waitGroup.Done()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		batch := make([][]byte, 0, w.batchSize)
+		for {
+			select {
+			case entry := <-w.channel:
+				batch = append(batch, entry)
+				if len(batch) >= w.batchSize {
+					batch = w.flush(batch)
+				}
+			case <-ticker.C:
+				if len(batch) > 0 {
+					batch = w.flush(batch)
+				}
+			case <-w.done:
+				for {
+					select {
+					case entry := <-w.channel:
+						batch = append(batch, entry)
+					default:
+						w.flush(batch)
+						return
+					}
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,115 @@
+package logx
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/lang"
+)
+
+type mockBackend struct {
+	lock    sync.Mutex
+	entries []string
+	closed  bool
+}
+
+func (mb *mockBackend) Write(p []byte) error {
+	mb.lock.Lock()
+	defer mb.lock.Unlock()
+	mb.entries = append(mb.entries, string(p))
+	return nil
+}
+
+func (mb *mockBackend) Close() error {
+	mb.lock.Lock()
+	defer mb.lock.Unlock()
+	mb.closed = true
+	return nil
+}
+
+func (mb *mockBackend) count() int {
+	mb.lock.Lock()
+	defer mb.lock.Unlock()
+	return len(mb.entries)
+}
+
+func (mb *mockBackend) contains(text string) bool {
+	mb.lock.Lock()
+	defer mb.lock.Unlock()
+	for _, entry := range mb.entries {
+		if strings.Contains(entry, text) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSetWriter(t *testing.T) {
+	backend := new(mockBackend)
+	SetWriter(backend)
+	defer resetWriter()
+
+	Info("writer test")
+	assert.Nil(t, getWriter().Close())
+	assert.True(t, backend.contains("writer test"))
+	assert.True(t, backend.closed)
+}
+
+func TestAsyncWriterBatching(t *testing.T) {
+	backend := new(mockBackend)
+	writer := newAsyncWriter(backend, writerOptions{
+		bufferSize:    10,
+		batchSize:     2,
+		flushInterval: time.Hour,
+	})
+
+	assert.Nil(t, writer.Write([]byte("first")))
+	assert.Nil(t, writer.Write([]byte("second")))
+	assert.Nil(t, writer.Close())
+	assert.Equal(t, 2, backend.count())
+	assert.True(t, backend.closed)
+}
+
+func TestAsyncWriterDropOnFull(t *testing.T) {
+	backend := new(mockBackend)
+	writer := &asyncWriter{
+		backend:   backend,
+		channel:   make(chan []byte, 1),
+		done:      make(chan lang.PlaceholderType),
+		interval:  time.Hour,
+		batchSize: 10,
+	}
+
+	assert.Nil(t, writer.Write([]byte("kept")))
+	assert.Nil(t, writer.Write([]byte("dropped")))
+	writer.startWorker()
+	assert.Nil(t, writer.Close())
+	assert.True(t, backend.contains("kept"))
+	assert.False(t, backend.contains(`"dropped"`))
+	assert.True(t, backend.contains("dropped 1 log entries"))
+}
+
+func TestAsyncWriterBackendError(t *testing.T) {
+	writer := newAsyncWriter(badBackend{}, writerOptions{
+		bufferSize:    10,
+		batchSize:     10,
+		flushInterval: time.Hour,
+	})
+
+	assert.Nil(t, writer.Write([]byte("any")))
+	assert.NotNil(t, writer.Close())
+}
+
+type badBackend struct{}
+
+func (badBackend) Write(p []byte) error {
+	return errors.New("write error")
+}
+
+func (badBackend) Close() error {
+	return errors.New("close error")
+}
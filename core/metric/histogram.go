@@ -14,6 +14,8 @@ type (
 		Help      string
 		Labels    []string
 		Buckets   []float64
+		// ConstLabels are attached to every sample of the metric.
+		ConstLabels map[string]string
 	}
 
 	// A HistogramVec interface represents a histogram vector.
@@ -35,11 +37,12 @@ func NewHistogramVec(cfg *HistogramVecOpts) HistogramVec {
 	}
 
 	vec := prom.NewHistogramVec(prom.HistogramOpts{
-		Namespace: cfg.Namespace,
-		Subsystem: cfg.Subsystem,
-		Name:      cfg.Name,
-		Help:      cfg.Help,
-		Buckets:   cfg.Buckets,
+		Namespace:   cfg.Namespace,
+		Subsystem:   cfg.Subsystem,
+		Name:        cfg.Name,
+		Help:        cfg.Help,
+		Buckets:     cfg.Buckets,
+		ConstLabels: cfg.ConstLabels,
 	}, cfg.Labels)
 	prom.MustRegister(vec)
 	hv := &promHistogramVec{
@@ -59,3 +62,14 @@ func (hv *promHistogramVec) Observe(v int64, labels ...string) {
 func (hv *promHistogramVec) close() bool {
 	return prom.Unregister(hv.histogram)
 }
+
+// ExponentialBuckets returns count buckets starting at start,
+// each bucket factor times the previous one.
+func ExponentialBuckets(start, factor float64, count int) []float64 {
+	return prom.ExponentialBuckets(start, factor, count)
+}
+
+// LinearBuckets returns count buckets starting at start, each width apart.
+func LinearBuckets(start, width float64, count int) []float64 {
+	return prom.LinearBuckets(start, width, count)
+}
@@ -7,4 +7,7 @@ type VectorOpts struct {
 	Name      string
 	Help      string
 	Labels    []string
+	// ConstLabels are attached to every sample of the metric,
+	// like the instance or cluster identity.
+	ConstLabels map[string]string
 }
@@ -0,0 +1,82 @@
+package metric
+
+import (
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/tal-tech/go-zero/core/proc"
+)
+
+type (
+	// A SummaryVecOpts is a summary vector options.
+	SummaryVecOpts struct {
+		Namespace string
+		Subsystem string
+		Name      string
+		Help      string
+		Labels    []string
+		// Objectives maps the tracked quantiles to their absolute errors,
+		// like {0.5: 0.05, 0.9: 0.01, 0.99: 0.001}.
+		Objectives map[float64]float64
+		// MaxAge is the sliding window the quantiles are computed over,
+		// zero means the prometheus default of 10 minutes.
+		MaxAge time.Duration
+		// AgeBuckets is the number of buckets the sliding window is divided
+		// into, zero means the prometheus default of 5.
+		AgeBuckets uint32
+		// ConstLabels are attached to every sample of the metric.
+		ConstLabels map[string]string
+	}
+
+	// A SummaryVec interface represents a summary vector.
+	SummaryVec interface {
+		// Observe adds observation v to labels.
+		Observe(v int64, labels ...string)
+		// ObserveFloat adds observation v to labels.
+		ObserveFloat(v float64, labels ...string)
+		close() bool
+	}
+
+	promSummaryVec struct {
+		summary *prom.SummaryVec
+	}
+)
+
+// NewSummaryVec returns a SummaryVec.
+func NewSummaryVec(cfg *SummaryVecOpts) SummaryVec {
+	if cfg == nil {
+		return nil
+	}
+
+	vec := prom.NewSummaryVec(prom.SummaryOpts{
+		Namespace:   cfg.Namespace,
+		Subsystem:   cfg.Subsystem,
+		Name:        cfg.Name,
+		Help:        cfg.Help,
+		Objectives:  cfg.Objectives,
+		MaxAge:      cfg.MaxAge,
+		AgeBuckets:  cfg.AgeBuckets,
+		ConstLabels: cfg.ConstLabels,
+	}, cfg.Labels)
+	prom.MustRegister(vec)
+	sv := &promSummaryVec{
+		summary: vec,
+	}
+	proc.AddShutdownListener(func() {
+		sv.close()
+	})
+
+	return sv
+}
+
+func (sv *promSummaryVec) Observe(v int64, labels ...string) {
+	sv.summary.WithLabelValues(labels...).Observe(float64(v))
+}
+
+func (sv *promSummaryVec) ObserveFloat(v float64, labels ...string) {
+	sv.summary.WithLabelValues(labels...).Observe(v)
+}
+
+func (sv *promSummaryVec) close() bool {
+	return prom.Unregister(sv.summary)
+}
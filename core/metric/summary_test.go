@@ -0,0 +1,56 @@
+package metric
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSummaryVec(t *testing.T) {
+	summaryVec := NewSummaryVec(&SummaryVecOpts{
+		Name: "latency_ms",
+		Help: "rpc client request latency(ms).",
+		Objectives: map[float64]float64{
+			0.5:  0.05,
+			0.99: 0.001,
+		},
+	})
+	defer summaryVec.close()
+	summaryVecNil := NewSummaryVec(nil)
+	assert.NotNil(t, summaryVec)
+	assert.Nil(t, summaryVecNil)
+}
+
+func TestSummaryObserve(t *testing.T) {
+	summaryVec := NewSummaryVec(&SummaryVecOpts{
+		Name:   "latencies",
+		Help:   "rpc client request latency(ms).",
+		Labels: []string{"method"},
+		ConstLabels: map[string]string{
+			"cluster": "test",
+		},
+	})
+	defer summaryVec.close()
+	sv, _ := summaryVec.(*promSummaryVec)
+	sv.Observe(2, "/Users")
+	sv.ObserveFloat(1.5, "/Users")
+
+	metadata := `
+		# HELP latencies rpc client request latency(ms).
+        # TYPE latencies summary
+`
+	val := `
+		latencies_sum{cluster="test",method="/Users"} 3.5
+		latencies_count{cluster="test",method="/Users"} 2
+`
+
+	err := testutil.CollectAndCompare(sv.summary, strings.NewReader(metadata+val))
+	assert.Nil(t, err)
+}
+
+func TestBuckets(t *testing.T) {
+	assert.Equal(t, []float64{1, 2, 4, 8}, ExponentialBuckets(1, 2, 4))
+	assert.Equal(t, []float64{1, 3, 5}, LinearBuckets(1, 2, 3))
+}
@@ -1,9 +1,12 @@
 package mr
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/tal-tech/go-zero/core/errorx"
 	"github.com/tal-tech/go-zero/core/lang"
@@ -43,7 +46,11 @@ type (
 	Option func(opts *mapReduceOptions)
 
 	mapReduceOptions struct {
-		workers int
+		ctx              context.Context
+		workers          int
+		continueOnError  bool
+		progressFn       ProgressFunc
+		progressInterval time.Duration
 	}
 
 	// Writer interface wraps Write method.
@@ -72,6 +79,26 @@ func Finish(fns ...func() error) error {
 	}, WithWorkers(len(fns)))
 }
 
+// FinishAll runs fns parallelly, it doesn't stop on errors,
+// and returns the aggregated errors with the index of each failed fn.
+func FinishAll(fns ...func() error) error {
+	if len(fns) == 0 {
+		return nil
+	}
+
+	return MapReduceVoid(func(source chan<- interface{}) {
+		for i := range fns {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer, cancel func(error)) {
+		if err := fns[item.(int)](); err != nil {
+			cancel(err)
+		}
+	}, func(pipe <-chan interface{}, cancel func(error)) {
+		drain(pipe)
+	}, WithWorkers(len(fns)), ContinueOnError())
+}
+
 // FinishVoid runs fns parallelly.
 func FinishVoid(fns ...func()) {
 	if len(fns) == 0 {
@@ -111,6 +138,11 @@ func MapReduce(generate GenerateFunc, mapper MapperFunc, reducer ReducerFunc, op
 func MapReduceWithSource(source <-chan interface{}, mapper MapperFunc, reducer ReducerFunc,
 	opts ...Option) (interface{}, error) {
 	options := buildOptions(opts...)
+	tracker := newProgressTracker(options)
+	if tracker != nil {
+		source = tracker.trackSource(source)
+		defer tracker.stop()
+	}
 	output := make(chan interface{})
 	collector := make(chan interface{}, options.workers)
 	done := syncx.NewDoneChan()
@@ -133,6 +165,23 @@ func MapReduceWithSource(source <-chan interface{}, mapper MapperFunc, reducer R
 		drain(source)
 		finish()
 	})
+	var errLock sync.Mutex
+	var batchErr errorx.BatchError
+	handleError := func(item interface{}) func(error) {
+		if !options.continueOnError {
+			return cancel
+		}
+
+		return func(err error) {
+			if err == nil {
+				return
+			}
+
+			errLock.Lock()
+			batchErr.Add(fmt.Errorf("item %v: %w", item, err))
+			errLock.Unlock()
+		}
+	}
 
 	go func() {
 		defer func() {
@@ -146,15 +195,40 @@ func MapReduceWithSource(source <-chan interface{}, mapper MapperFunc, reducer R
 		drain(collector)
 	}()
 
+	go func() {
+		select {
+		case <-options.ctx.Done():
+			cancel(options.ctx.Err())
+		case <-done.Done():
+		}
+	}()
+
 	go executeMappers(func(item interface{}, w Writer) {
-		mapper(item, w, cancel)
+		errFn := handleError(item)
+		if tracker == nil {
+			mapper(item, w, errFn)
+			return
+		}
+
+		var failed int32
+		mapper(item, w, func(err error) {
+			if err != nil {
+				atomic.StoreInt32(&failed, 1)
+			}
+			errFn(err)
+		})
+		if atomic.LoadInt32(&failed) != 0 {
+			tracker.markFailed()
+		} else {
+			tracker.markProcessed()
+		}
 	}, source, collector, done.Done(), options.workers)
 
 	value, ok := <-output
 	if err := retErr.Load(); err != nil {
 		return nil, err
 	} else if ok {
-		return value, nil
+		return value, batchErr.Err()
 	} else {
 		return nil, ErrReduceNoOutput
 	}
@@ -180,6 +254,25 @@ func MapVoid(generate GenerateFunc, mapper VoidMapFunc, opts ...Option) {
 	}, opts...))
 }
 
+// ContinueOnError returns an Option to let a mapreduce processing continue on
+// mapper errors. The errors that mappers pass to cancel don't stop the processing,
+// they are aggregated with the failed items and returned after all items are done.
+func ContinueOnError() Option {
+	return func(opts *mapReduceOptions) {
+		opts.continueOnError = true
+	}
+}
+
+// WithContext customizes a mapreduce processing with given ctx,
+// the processing is cancelled when ctx is done, and ctx.Err() is returned.
+func WithContext(ctx context.Context) Option {
+	return func(opts *mapReduceOptions) {
+		if ctx != nil {
+			opts.ctx = ctx
+		}
+	}
+}
+
 // WithWorkers customizes a mapreduce processing with given workers.
 func WithWorkers(workers int) Option {
 	return func(opts *mapReduceOptions) {
@@ -254,6 +347,7 @@ func executeMappers(mapper MapFunc, input <-chan interface{}, collector chan<- i
 
 func newOptions() *mapReduceOptions {
 	return &mapReduceOptions{
+		ctx:     context.Background(),
 		workers: defaultWorkers,
 	}
 }
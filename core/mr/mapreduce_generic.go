@@ -0,0 +1,226 @@
+package mr
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/tal-tech/go-zero/core/errorx"
+	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/core/syncx"
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+type (
+	// GenerateFuncG is the generic variant of GenerateFunc.
+	GenerateFuncG[T any] func(source chan<- T)
+	// MapFuncG is the generic variant of MapFunc.
+	MapFuncG[T, U any] func(item T, writer WriterG[U])
+	// MapperFuncG is the generic variant of MapperFunc.
+	MapperFuncG[T, U any] func(item T, writer WriterG[U], cancel func(error))
+	// ReducerFuncG is the generic variant of ReducerFunc.
+	ReducerFuncG[U, V any] func(pipe <-chan U, writer WriterG[V], cancel func(error))
+	// VoidReducerFuncG is the generic variant of VoidReducerFunc.
+	VoidReducerFuncG[U any] func(pipe <-chan U, cancel func(error))
+
+	// WriterG is the generic variant of Writer.
+	WriterG[T any] interface {
+		Write(v T)
+	}
+)
+
+// ForEach maps all elements generated from given generate but no output,
+// it's the generic variant of MapVoid.
+func ForEach[T any](generate GenerateFuncG[T], mapper func(item T), opts ...Option) {
+	drainG(MapG(generate, func(item T, writer WriterG[lang.PlaceholderType]) {
+		mapper(item)
+	}, opts...))
+}
+
+// MapG maps all elements generated from given generate func,
+// and returns an output channel, it's the generic variant of Map.
+func MapG[T, U any](generate GenerateFuncG[T], mapper MapFuncG[T, U], opts ...Option) chan U {
+	options := buildOptions(opts...)
+	source := buildSourceG(generate)
+	collector := make(chan U, options.workers)
+	done := syncx.NewDoneChan()
+
+	go executeMappersG(mapper, source, collector, done.Done(), options.workers)
+
+	return collector
+}
+
+// MapReduceG maps all elements generated from given generate func, and reduces
+// the output elements with given reducer, it's the generic variant of MapReduce.
+func MapReduceG[T, U, V any](generate GenerateFuncG[T], mapper MapperFuncG[T, U],
+	reducer ReducerFuncG[U, V], opts ...Option) (V, error) {
+	source := buildSourceG(generate)
+	return MapReduceWithSourceG(source, mapper, reducer, opts...)
+}
+
+// MapReduceWithSourceG maps all elements from source, and reduce the output
+// elements with given reducer, it's the generic variant of MapReduceWithSource.
+func MapReduceWithSourceG[T, U, V any](source <-chan T, mapper MapperFuncG[T, U],
+	reducer ReducerFuncG[U, V], opts ...Option) (V, error) {
+	options := buildOptions(opts...)
+	output := make(chan V)
+	collector := make(chan U, options.workers)
+	done := syncx.NewDoneChan()
+	writer := newGuardedWriterG(output, done.Done())
+	var closeOnce sync.Once
+	var retErr errorx.AtomicError
+	finish := func() {
+		closeOnce.Do(func() {
+			done.Close()
+			close(output)
+		})
+	}
+	cancel := once(func(err error) {
+		if err != nil {
+			retErr.Set(err)
+		} else {
+			retErr.Set(ErrCancelWithNil)
+		}
+
+		drainG(source)
+		finish()
+	})
+	var errLock sync.Mutex
+	var batchErr errorx.BatchError
+	handleError := func(item T) func(error) {
+		if !options.continueOnError {
+			return cancel
+		}
+
+		return func(err error) {
+			if err == nil {
+				return
+			}
+
+			errLock.Lock()
+			batchErr.Add(fmt.Errorf("item %v: %w", item, err))
+			errLock.Unlock()
+		}
+	}
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				cancel(fmt.Errorf("%v", r))
+			} else {
+				finish()
+			}
+		}()
+		reducer(collector, writer, cancel)
+		drainG(collector)
+	}()
+
+	go func() {
+		select {
+		case <-options.ctx.Done():
+			cancel(options.ctx.Err())
+		case <-done.Done():
+		}
+	}()
+
+	go executeMappersG(func(item T, w WriterG[U]) {
+		mapper(item, w, handleError(item))
+	}, source, collector, done.Done(), options.workers)
+
+	var zero V
+	value, ok := <-output
+	if err := retErr.Load(); err != nil {
+		return zero, err
+	} else if ok {
+		return value, batchErr.Err()
+	} else {
+		return zero, ErrReduceNoOutput
+	}
+}
+
+// MapReduceVoidG maps all elements generated from given generate, and reduce
+// the output elements with given reducer, it's the generic variant of MapReduceVoid.
+func MapReduceVoidG[T, U any](generate GenerateFuncG[T], mapper MapperFuncG[T, U],
+	reducer VoidReducerFuncG[U], opts ...Option) error {
+	_, err := MapReduceG(generate, mapper, func(input <-chan U,
+		writer WriterG[lang.PlaceholderType], cancel func(error)) {
+		reducer(input, cancel)
+		drainG(input)
+		// We need to write a placeholder to let MapReduceG to continue on reducer done,
+		// otherwise, all goroutines are waiting. The placeholder will be discarded by MapReduceG.
+		writer.Write(lang.Placeholder)
+	}, opts...)
+	return err
+}
+
+func buildSourceG[T any](generate GenerateFuncG[T]) chan T {
+	source := make(chan T)
+	threading.GoSafe(func() {
+		defer close(source)
+		generate(source)
+	})
+
+	return source
+}
+
+// drainG drains the channel.
+func drainG[T any](channel <-chan T) {
+	// drain the channel
+	for range channel {
+	}
+}
+
+func executeMappersG[T, U any](mapper MapFuncG[T, U], input <-chan T, collector chan<- U,
+	done <-chan lang.PlaceholderType, workers int) {
+	var wg sync.WaitGroup
+	defer func() {
+		wg.Wait()
+		close(collector)
+	}()
+
+	pool := make(chan lang.PlaceholderType, workers)
+	writer := newGuardedWriterG(collector, done)
+	for {
+		select {
+		case <-done:
+			return
+		case pool <- lang.Placeholder:
+			item, ok := <-input
+			if !ok {
+				<-pool
+				return
+			}
+
+			wg.Add(1)
+			// better to safely run caller defined method
+			threading.GoSafe(func() {
+				defer func() {
+					wg.Done()
+					<-pool
+				}()
+
+				mapper(item, writer)
+			})
+		}
+	}
+}
+
+type guardedWriterG[T any] struct {
+	channel chan<- T
+	done    <-chan lang.PlaceholderType
+}
+
+func newGuardedWriterG[T any](channel chan<- T, done <-chan lang.PlaceholderType) guardedWriterG[T] {
+	return guardedWriterG[T]{
+		channel: channel,
+		done:    done,
+	}
+}
+
+func (gw guardedWriterG[T]) Write(v T) {
+	select {
+	case <-gw.done:
+		return
+	default:
+		gw.channel <- v
+	}
+}
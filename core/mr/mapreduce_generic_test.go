@@ -0,0 +1,159 @@
+package mr
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEach(t *testing.T) {
+	var count uint32
+	ForEach(func(source chan<- int) {
+		for i := 0; i < 10; i++ {
+			source <- i
+		}
+	}, func(item int) {
+		atomic.AddUint32(&count, 1)
+	}, WithWorkers(-1))
+
+	assert.Equal(t, uint32(10), atomic.LoadUint32(&count))
+}
+
+func TestMapG(t *testing.T) {
+	channel := MapG(func(source chan<- int) {
+		for i := 1; i < 5; i++ {
+			source <- i
+		}
+	}, func(item int, writer WriterG[int]) {
+		writer.Write(item * item)
+	})
+
+	var result int
+	for v := range channel {
+		result += v
+	}
+
+	assert.Equal(t, 30, result)
+}
+
+func TestMapReduceG(t *testing.T) {
+	value, err := MapReduceG(func(source chan<- int) {
+		for i := 1; i < 5; i++ {
+			source <- i
+		}
+	}, func(item int, writer WriterG[int], cancel func(error)) {
+		writer.Write(item * item)
+	}, func(pipe <-chan int, writer WriterG[int], cancel func(error)) {
+		var result int
+		for v := range pipe {
+			result += v
+		}
+		writer.Write(result)
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 30, value)
+}
+
+func TestMapReduceGCancel(t *testing.T) {
+	value, err := MapReduceG(func(source chan<- int) {
+		for i := 0; i < defaultWorkers*2; i++ {
+			source <- i
+		}
+	}, func(item int, writer WriterG[int], cancel func(error)) {
+		if item == defaultWorkers/2 {
+			cancel(errDummy)
+		}
+		writer.Write(item)
+	}, func(pipe <-chan int, writer WriterG[int], cancel func(error)) {
+		var result int
+		for v := range pipe {
+			result += v
+		}
+		writer.Write(result)
+	})
+
+	assert.Equal(t, errDummy, err)
+	assert.Equal(t, 0, value)
+}
+
+func TestMapReduceGContinueOnError(t *testing.T) {
+	value, err := MapReduceG(func(source chan<- int) {
+		for i := 1; i < 5; i++ {
+			source <- i
+		}
+	}, func(item int, writer WriterG[int], cancel func(error)) {
+		if item%2 == 0 {
+			cancel(errDummy)
+			return
+		}
+		writer.Write(item * item)
+	}, func(pipe <-chan int, writer WriterG[int], cancel func(error)) {
+		var result int
+		for v := range pipe {
+			result += v
+		}
+		writer.Write(result)
+	}, ContinueOnError())
+
+	assert.Equal(t, 10, value)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "item 2")
+	assert.Contains(t, err.Error(), "item 4")
+}
+
+func TestMapReduceGWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := MapReduceG(func(source chan<- int) {
+		for i := 0; i < defaultWorkers*2; i++ {
+			source <- i
+		}
+	}, func(item int, writer WriterG[int], c func(error)) {
+		if item == 0 {
+			cancel()
+		}
+		time.Sleep(time.Millisecond)
+		writer.Write(item)
+	}, func(pipe <-chan int, writer WriterG[int], c func(error)) {
+		var result int
+		for v := range pipe {
+			result += v
+		}
+		writer.Write(result)
+	}, WithContext(ctx))
+
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestMapReduceGNoOutput(t *testing.T) {
+	_, err := MapReduceG(func(source chan<- int) {
+		source <- 1
+	}, func(item int, writer WriterG[int], cancel func(error)) {
+		writer.Write(item)
+	}, func(pipe <-chan int, writer WriterG[string], cancel func(error)) {
+		drainG(pipe)
+	})
+
+	assert.Equal(t, ErrReduceNoOutput, err)
+}
+
+func TestMapReduceVoidG(t *testing.T) {
+	var total uint32
+	err := MapReduceVoidG(func(source chan<- int) {
+		for i := 1; i < 5; i++ {
+			source <- i
+		}
+	}, func(item int, writer WriterG[int], cancel func(error)) {
+		writer.Write(item * item)
+	}, func(pipe <-chan int, cancel func(error)) {
+		for v := range pipe {
+			atomic.AddUint32(&total, uint32(v))
+		}
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(30), atomic.LoadUint32(&total))
+}
@@ -1,6 +1,7 @@
 package mr
 
 import (
+	"context"
 	"errors"
 	"io/ioutil"
 	"log"
@@ -16,6 +17,29 @@ import (
 
 var errDummy = errors.New("dummy")
 
+func TestMapReduceWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := MapReduce(func(source chan<- interface{}) {
+		for i := 0; i < defaultWorkers*2; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer, c func(error)) {
+		if item.(int) == 0 {
+			cancel()
+		}
+		time.Sleep(time.Millisecond)
+		writer.Write(item)
+	}, func(pipe <-chan interface{}, writer Writer, c func(error)) {
+		var result int
+		for v := range pipe {
+			result += v.(int)
+		}
+		writer.Write(result)
+	}, WithContext(ctx))
+
+	assert.Equal(t, context.Canceled, err)
+}
+
 func init() {
 	log.SetOutput(ioutil.Discard)
 }
@@ -61,6 +85,57 @@ func TestFinishErr(t *testing.T) {
 	assert.Equal(t, errDummy, err)
 }
 
+func TestFinishAll(t *testing.T) {
+	var total uint32
+	err := FinishAll(func() error {
+		atomic.AddUint32(&total, 2)
+		return errors.New("first")
+	}, func() error {
+		atomic.AddUint32(&total, 3)
+		return nil
+	}, func() error {
+		atomic.AddUint32(&total, 5)
+		return errors.New("third")
+	})
+
+	// all fns run regardless of errors
+	assert.Equal(t, uint32(10), atomic.LoadUint32(&total))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "first")
+	assert.Contains(t, err.Error(), "third")
+}
+
+func TestFinishAllNone(t *testing.T) {
+	assert.Nil(t, FinishAll())
+}
+
+func TestMapReduceContinueOnError(t *testing.T) {
+	value, err := MapReduce(func(source chan<- interface{}) {
+		for i := 1; i < 5; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer, cancel func(error)) {
+		v := item.(int)
+		if v%2 == 0 {
+			cancel(errDummy)
+			return
+		}
+		writer.Write(v * v)
+	}, func(pipe <-chan interface{}, writer Writer, cancel func(error)) {
+		var result int
+		for v := range pipe {
+			result += v.(int)
+		}
+		writer.Write(result)
+	}, ContinueOnError())
+
+	// odd items are still reduced
+	assert.Equal(t, 10, value)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "item 2")
+	assert.Contains(t, err.Error(), "item 4")
+}
+
 func TestFinishVoid(t *testing.T) {
 	var total uint32
 	FinishVoid(func() {
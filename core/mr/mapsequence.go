@@ -0,0 +1,85 @@
+package mr
+
+import "github.com/tal-tech/go-zero/core/syncx"
+
+type (
+	indexedItem struct {
+		index int
+		item  interface{}
+	}
+
+	indexedResult struct {
+		index   int
+		results []interface{}
+	}
+
+	// sliceWriter collects the outputs of one mapper call,
+	// only used within a single goroutine, no need to guard.
+	sliceWriter struct {
+		results *[]interface{}
+	}
+)
+
+func (w sliceWriter) Write(v interface{}) {
+	*w.results = append(*w.results, v)
+}
+
+// MapSequence maps all elements generated from given generate func with
+// concurrent mappers, and returns an output channel that emits the results
+// in the original generation order.
+func MapSequence(generate GenerateFunc, mapper MapFunc, opts ...Option) chan interface{} {
+	options := buildOptions(opts...)
+	source := buildSource(generate)
+	indexedSource := make(chan interface{})
+	go func() {
+		defer close(indexedSource)
+		var index int
+		for item := range source {
+			indexedSource <- indexedItem{
+				index: index,
+				item:  item,
+			}
+			index++
+		}
+	}()
+
+	collector := make(chan interface{}, options.workers)
+	done := syncx.NewDoneChan()
+	go executeMappers(func(item interface{}, w Writer) {
+		indexed := item.(indexedItem)
+		var results []interface{}
+		mapper(indexed.item, sliceWriter{
+			results: &results,
+		})
+		w.Write(indexedResult{
+			index:   indexed.index,
+			results: results,
+		})
+	}, indexedSource, collector, done.Done(), options.workers)
+
+	output := make(chan interface{}, options.workers)
+	go func() {
+		defer close(output)
+
+		pending := make(map[int][]interface{})
+		var next int
+		for v := range collector {
+			result := v.(indexedResult)
+			pending[result.index] = result.results
+			for {
+				results, ok := pending[next]
+				if !ok {
+					break
+				}
+
+				for _, item := range results {
+					output <- item
+				}
+				delete(pending, next)
+				next++
+			}
+		}
+	}()
+
+	return output
+}
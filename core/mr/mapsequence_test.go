@@ -0,0 +1,66 @@
+package mr
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapSequence(t *testing.T) {
+	const count = 100
+	channel := MapSequence(func(source chan<- interface{}) {
+		for i := 0; i < count; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer) {
+		// random delays shuffle the completion order
+		time.Sleep(time.Millisecond * time.Duration(rand.Intn(5)))
+		writer.Write(item.(int) * 2)
+	})
+
+	var values []int
+	for v := range channel {
+		values = append(values, v.(int))
+	}
+
+	assert.Equal(t, count, len(values))
+	for i, v := range values {
+		assert.Equal(t, i*2, v)
+	}
+}
+
+func TestMapSequenceSkippedItems(t *testing.T) {
+	channel := MapSequence(func(source chan<- interface{}) {
+		for i := 0; i < 10; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer) {
+		if item.(int)%2 == 0 {
+			return
+		}
+		writer.Write(item)
+	}, WithWorkers(4))
+
+	var values []int
+	for v := range channel {
+		values = append(values, v.(int))
+	}
+
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, values)
+}
+
+func TestMapSequenceEmpty(t *testing.T) {
+	channel := MapSequence(func(source chan<- interface{}) {
+	}, func(item interface{}, writer Writer) {
+		writer.Write(item)
+	})
+
+	var count int
+	for range channel {
+		count++
+	}
+
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,184 @@
+package mr
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/tal-tech/go-zero/core/errorx"
+	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/core/syncx"
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+// A Pool is a reusable worker pool for repeated mapreduce invocations,
+// it avoids spinning up and tearing down goroutines on each call.
+type Pool struct {
+	size  int
+	tasks chan func()
+}
+
+// NewPool returns a Pool with size long-lived workers.
+// Close the Pool to release the workers.
+func NewPool(size int) *Pool {
+	if size < minWorkers {
+		size = minWorkers
+	}
+
+	p := &Pool{
+		size:  size,
+		tasks: make(chan func(), size),
+	}
+	for i := 0; i < size; i++ {
+		go p.run()
+	}
+
+	return p
+}
+
+// Close releases the pool workers after the pending tasks are done.
+func (p *Pool) Close() {
+	close(p.tasks)
+}
+
+// ForEach maps all elements generated from given generate on the pool workers, no output.
+func (p *Pool) ForEach(generate GenerateFunc, mapper VoidMapFunc) {
+	source := buildSource(generate)
+	collector := make(chan interface{}, p.size)
+	done := syncx.NewDoneChan()
+
+	go p.executeMappers(func(item interface{}, w Writer) {
+		mapper(item)
+	}, source, collector, done.Done())
+
+	drain(collector)
+}
+
+// MapReduce maps all elements generated from given generate func on the pool
+// workers, and reduces the output elements with given reducer.
+// The WithWorkers option is ignored, concurrency is bounded by the pool size.
+func (p *Pool) MapReduce(generate GenerateFunc, mapper MapperFunc, reducer ReducerFunc,
+	opts ...Option) (interface{}, error) {
+	options := buildOptions(opts...)
+	source := buildSource(generate)
+	output := make(chan interface{})
+	collector := make(chan interface{}, p.size)
+	done := syncx.NewDoneChan()
+	writer := newGuardedWriter(output, done.Done())
+	var closeOnce sync.Once
+	var retErr errorx.AtomicError
+	finish := func() {
+		closeOnce.Do(func() {
+			done.Close()
+			close(output)
+		})
+	}
+	cancel := once(func(err error) {
+		if err != nil {
+			retErr.Set(err)
+		} else {
+			retErr.Set(ErrCancelWithNil)
+		}
+
+		drain(source)
+		finish()
+	})
+	var errLock sync.Mutex
+	var batchErr errorx.BatchError
+	handleError := func(item interface{}) func(error) {
+		if !options.continueOnError {
+			return cancel
+		}
+
+		return func(err error) {
+			if err == nil {
+				return
+			}
+
+			errLock.Lock()
+			batchErr.Add(fmt.Errorf("item %v: %w", item, err))
+			errLock.Unlock()
+		}
+	}
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				cancel(fmt.Errorf("%v", r))
+			} else {
+				finish()
+			}
+		}()
+		reducer(collector, writer, cancel)
+		drain(collector)
+	}()
+
+	go func() {
+		select {
+		case <-options.ctx.Done():
+			cancel(options.ctx.Err())
+		case <-done.Done():
+		}
+	}()
+
+	// wait for the dispatching to finish before returning,
+	// so that closing the pool doesn't race with in-flight task submissions.
+	dispatched := make(chan lang.PlaceholderType)
+	go func() {
+		defer close(dispatched)
+		p.executeMappers(func(item interface{}, w Writer) {
+			mapper(item, w, handleError(item))
+		}, source, collector, done.Done())
+	}()
+
+	value, ok := <-output
+	<-dispatched
+	if err := retErr.Load(); err != nil {
+		return nil, err
+	} else if ok {
+		return value, batchErr.Err()
+	} else {
+		return nil, ErrReduceNoOutput
+	}
+}
+
+func (p *Pool) executeMappers(mapper MapFunc, input <-chan interface{},
+	collector chan<- interface{}, done <-chan lang.PlaceholderType) {
+	var wg sync.WaitGroup
+	defer func() {
+		wg.Wait()
+		close(collector)
+	}()
+
+	writer := newGuardedWriter(collector, done)
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+
+		item, ok := <-input
+		if !ok {
+			return
+		}
+
+		wg.Add(1)
+		task := func() {
+			defer wg.Done()
+			mapper(item, writer)
+		}
+		select {
+		case <-done:
+			wg.Done()
+			return
+		case p.tasks <- task:
+		}
+	}
+}
+
+func (p *Pool) run() {
+	for task := range p.tasks {
+		// better to safely run caller defined method
+		threading.RunSafe(task)
+	}
+}
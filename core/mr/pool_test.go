@@ -0,0 +1,130 @@
+package mr
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolMapReduce(t *testing.T) {
+	pool := NewPool(4)
+	defer pool.Close()
+
+	value, err := pool.MapReduce(func(source chan<- interface{}) {
+		for i := 1; i < 5; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer, cancel func(error)) {
+		v := item.(int)
+		writer.Write(v * v)
+	}, func(pipe <-chan interface{}, writer Writer, cancel func(error)) {
+		var result int
+		for v := range pipe {
+			result += v.(int)
+		}
+		writer.Write(result)
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 30, value)
+}
+
+func TestPoolMapReduceReuse(t *testing.T) {
+	pool := NewPool(4)
+	defer pool.Close()
+
+	// repeated invocations share the same workers
+	for i := 0; i < 10; i++ {
+		value, err := pool.MapReduce(func(source chan<- interface{}) {
+			for i := 1; i < 5; i++ {
+				source <- i
+			}
+		}, func(item interface{}, writer Writer, cancel func(error)) {
+			writer.Write(item)
+		}, func(pipe <-chan interface{}, writer Writer, cancel func(error)) {
+			var result int
+			for v := range pipe {
+				result += v.(int)
+			}
+			writer.Write(result)
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 10, value)
+	}
+}
+
+func TestPoolMapReduceCancel(t *testing.T) {
+	pool := NewPool(4)
+	defer pool.Close()
+
+	_, err := pool.MapReduce(func(source chan<- interface{}) {
+		for i := 0; i < 10; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer, cancel func(error)) {
+		if item.(int) == 2 {
+			cancel(errDummy)
+			return
+		}
+		writer.Write(item)
+	}, func(pipe <-chan interface{}, writer Writer, cancel func(error)) {
+		var result int
+		for v := range pipe {
+			result += v.(int)
+		}
+		writer.Write(result)
+	})
+
+	assert.Equal(t, errDummy, err)
+}
+
+func TestPoolForEach(t *testing.T) {
+	pool := NewPool(4)
+	defer pool.Close()
+
+	var count uint32
+	pool.ForEach(func(source chan<- interface{}) {
+		for i := 0; i < 100; i++ {
+			source <- i
+		}
+	}, func(item interface{}) {
+		atomic.AddUint32(&count, 1)
+	})
+
+	assert.Equal(t, uint32(100), atomic.LoadUint32(&count))
+}
+
+func TestPoolBoundedConcurrency(t *testing.T) {
+	const size = 4
+	pool := NewPool(size)
+	defer pool.Close()
+
+	var inflight, peak int32
+	pool.ForEach(func(source chan<- interface{}) {
+		for i := 0; i < 100; i++ {
+			source <- i
+		}
+	}, func(item interface{}) {
+		cur := atomic.AddInt32(&inflight, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if cur <= old || atomic.CompareAndSwapInt32(&peak, old, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&inflight, -1)
+	})
+
+	assert.True(t, atomic.LoadInt32(&peak) <= size)
+}
+
+func TestPoolMinSize(t *testing.T) {
+	pool := NewPool(0)
+	defer pool.Close()
+
+	assert.Equal(t, minWorkers, pool.size)
+}
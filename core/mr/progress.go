@@ -0,0 +1,116 @@
+package mr
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/core/threading"
+	"github.com/tal-tech/go-zero/core/timex"
+)
+
+const defaultProgressInterval = time.Second
+
+type (
+	// A Progress is a snapshot of a mapreduce processing,
+	// generated counts the items sent into the source,
+	// processed and failed count the items the mappers finished.
+	Progress struct {
+		Generated int64
+		Processed int64
+		Failed    int64
+		Elapsed   time.Duration
+	}
+
+	// ProgressFunc is used to report the progress of a mapreduce processing.
+	ProgressFunc func(p Progress)
+
+	progressTracker struct {
+		fn        ProgressFunc
+		startTime time.Duration
+		generated int64
+		processed int64
+		failed    int64
+		stopChan  chan lang.PlaceholderType
+		stopOnce  sync.Once
+	}
+)
+
+// WithProgress customizes a mapreduce processing to report its progress with fn
+// every interval, and once more on finish. A non-positive interval defaults to
+// one second. The callers can wire fn to their loggers or metrics.
+func WithProgress(fn ProgressFunc, interval time.Duration) Option {
+	return func(opts *mapReduceOptions) {
+		if interval <= 0 {
+			interval = defaultProgressInterval
+		}
+		opts.progressFn = fn
+		opts.progressInterval = interval
+	}
+}
+
+func newProgressTracker(options *mapReduceOptions) *progressTracker {
+	if options.progressFn == nil {
+		return nil
+	}
+
+	tracker := &progressTracker{
+		fn:        options.progressFn,
+		startTime: timex.Now(),
+		stopChan:  make(chan lang.PlaceholderType),
+	}
+	threading.GoSafe(func() {
+		ticker := time.NewTicker(options.progressInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-tracker.stopChan:
+				return
+			case <-ticker.C:
+				tracker.report()
+			}
+		}
+	})
+
+	return tracker
+}
+
+func (t *progressTracker) markFailed() {
+	atomic.AddInt64(&t.failed, 1)
+}
+
+func (t *progressTracker) markProcessed() {
+	atomic.AddInt64(&t.processed, 1)
+}
+
+func (t *progressTracker) report() {
+	t.fn(Progress{
+		Generated: atomic.LoadInt64(&t.generated),
+		Processed: atomic.LoadInt64(&t.processed),
+		Failed:    atomic.LoadInt64(&t.failed),
+		Elapsed:   timex.Since(t.startTime),
+	})
+}
+
+func (t *progressTracker) stop() {
+	t.stopOnce.Do(func() {
+		close(t.stopChan)
+		t.report()
+	})
+}
+
+// trackSource wraps source to count the generated items.
+func (t *progressTracker) trackSource(source <-chan interface{}) <-chan interface{} {
+	pipe := make(chan interface{})
+	go func() {
+		defer close(pipe)
+		for item := range source {
+			atomic.AddInt64(&t.generated, 1)
+			pipe <- item
+		}
+	}()
+
+	return pipe
+}
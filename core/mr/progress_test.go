@@ -0,0 +1,91 @@
+package mr
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithProgress(t *testing.T) {
+	var lock sync.Mutex
+	var last Progress
+	result, err := MapReduce(func(source chan<- interface{}) {
+		for i := 0; i < 10; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer, cancel func(error)) {
+		writer.Write(item)
+	}, func(pipe <-chan interface{}, writer Writer, cancel func(error)) {
+		var count int
+		for range pipe {
+			count++
+		}
+		writer.Write(count)
+	}, WithProgress(func(p Progress) {
+		lock.Lock()
+		last = p
+		lock.Unlock()
+	}, time.Millisecond))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 10, result)
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, int64(10), last.Generated)
+	assert.Equal(t, int64(10), last.Processed)
+	assert.Equal(t, int64(0), last.Failed)
+	assert.True(t, last.Elapsed > 0)
+}
+
+func TestWithProgressFailed(t *testing.T) {
+	var lock sync.Mutex
+	var last Progress
+	err := MapReduceVoid(func(source chan<- interface{}) {
+		for i := 0; i < 10; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer, cancel func(error)) {
+		if item.(int)%2 == 0 {
+			cancel(errors.New("even"))
+		}
+	}, func(pipe <-chan interface{}, cancel func(error)) {
+		drain(pipe)
+	}, ContinueOnError(), WithProgress(func(p Progress) {
+		lock.Lock()
+		last = p
+		lock.Unlock()
+	}, 0))
+
+	assert.NotNil(t, err)
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, int64(10), last.Generated)
+	assert.Equal(t, int64(5), last.Processed)
+	assert.Equal(t, int64(5), last.Failed)
+}
+
+func TestWithProgressCancel(t *testing.T) {
+	var called int32
+	_, err := MapReduce(func(source chan<- interface{}) {
+		for i := 0; i < 100; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer, cancel func(error)) {
+		if item.(int) == 5 {
+			cancel(errors.New("anything"))
+		}
+		writer.Write(item)
+	}, func(pipe <-chan interface{}, writer Writer, cancel func(error)) {
+		drain(pipe)
+		writer.Write(0)
+	}, WithProgress(func(p Progress) {
+		atomic.AddInt32(&called, 1)
+	}, time.Millisecond))
+
+	assert.NotNil(t, err)
+	assert.True(t, atomic.LoadInt32(&called) > 0)
+}
@@ -4,15 +4,35 @@ package proc
 
 import "time"
 
+// The priorities that shutdown listeners run in, lower values run earlier.
+const (
+	ShutdownPriorityDeregister = 100
+	ShutdownPriorityDrain      = 200
+	ShutdownPriorityFlush      = 300
+	ShutdownPriorityClose      = 400
+)
+
 // AddShutdownListener returns fn itself on windows, lets callers call fn on their own.
 func AddShutdownListener(fn func()) func() {
 	return fn
 }
 
+// AddShutdownListenerWithPriority returns fn itself on windows, lets callers call fn on their own.
+func AddShutdownListenerWithPriority(fn func(), _ int) func() {
+	return fn
+}
+
 // AddWrapUpListener returns fn itself on windows, lets callers call fn on their own.
 func AddWrapUpListener(fn func()) func() {
 	return fn
 }
 
+func SetTimeToForceQuit(duration time.Duration) {
+}
+
 func SetTimeoutToForceQuit(duration time.Duration) {
 }
+
+// WaitForShutdownComplete does nothing on windows.
+func WaitForShutdownComplete() {
+}
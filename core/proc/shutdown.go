@@ -5,6 +5,7 @@ package proc
 import (
 	"os"
 	"os/signal"
+	"sort"
 	"sync"
 	"syscall"
 	"time"
@@ -18,22 +19,43 @@ const (
 	waitTime = 5500 * time.Millisecond
 )
 
+// The priorities that shutdown listeners run in, lower values run earlier.
+// They map the typical wind down sequence: deregister from service discovery,
+// drain the in-flight work, flush the buffers, then close the stores.
+const (
+	ShutdownPriorityDeregister = 100
+	ShutdownPriorityDrain      = 200
+	ShutdownPriorityFlush      = 300
+	ShutdownPriorityClose      = 400
+
+	defaultShutdownPriority = ShutdownPriorityDrain
+)
+
 var (
 	wrapUpListeners          = new(listenerManager)
 	shutdownListeners        = new(listenerManager)
 	delayTimeBeforeForceQuit = waitTime
+	shutdownCompleteOnce     sync.Once
+	shutdownCompleteChan     = make(chan struct{})
 )
 
 // AddShutdownListener adds fn as a shutdown listener.
 // The returned func can be used to wait for fn getting called.
 func AddShutdownListener(fn func()) (waitForCalled func()) {
-	return shutdownListeners.addListener(fn)
+	return shutdownListeners.addListener(fn, defaultShutdownPriority)
+}
+
+// AddShutdownListenerWithPriority adds fn as a shutdown listener with the given priority,
+// lower priorities run earlier, listeners with the same priority run in added order.
+// The returned func can be used to wait for fn getting called.
+func AddShutdownListenerWithPriority(fn func(), priority int) (waitForCalled func()) {
+	return shutdownListeners.addListener(fn, priority)
 }
 
 // AddWrapUpListener adds fn as a wrap up listener.
 // The returned func can be used to wait for fn getting called.
 func AddWrapUpListener(fn func()) (waitForCalled func()) {
-	return wrapUpListeners.addListener(fn)
+	return wrapUpListeners.addListener(fn, defaultShutdownPriority)
 }
 
 // SetTimeToForceQuit sets the waiting time before force quitting.
@@ -41,6 +63,12 @@ func SetTimeToForceQuit(duration time.Duration) {
 	delayTimeBeforeForceQuit = duration
 }
 
+// WaitForShutdownComplete blocks until all wrap up and shutdown listeners got called,
+// mainly for tests to assert the wind down sequence.
+func WaitForShutdownComplete() {
+	<-shutdownCompleteChan
+}
+
 func gracefulStop(signals chan os.Signal) {
 	signal.Stop(signals)
 
@@ -49,25 +77,42 @@ func gracefulStop(signals chan os.Signal) {
 
 	time.Sleep(wrapUpTime)
 	shutdownListeners.notifyListeners()
+	notifyShutdownComplete()
 
 	time.Sleep(delayTimeBeforeForceQuit - wrapUpTime)
 	logx.Infof("Still alive after %v, going to force kill the process...", delayTimeBeforeForceQuit)
 	syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
 }
 
-type listenerManager struct {
-	lock      sync.Mutex
-	waitGroup sync.WaitGroup
-	listeners []func()
+func notifyShutdownComplete() {
+	shutdownCompleteOnce.Do(func() {
+		close(shutdownCompleteChan)
+	})
 }
 
-func (lm *listenerManager) addListener(fn func()) (waitForCalled func()) {
+type (
+	prioritizedListener struct {
+		priority int
+		fn       func()
+	}
+
+	listenerManager struct {
+		lock      sync.Mutex
+		waitGroup sync.WaitGroup
+		listeners []prioritizedListener
+	}
+)
+
+func (lm *listenerManager) addListener(fn func(), priority int) (waitForCalled func()) {
 	lm.waitGroup.Add(1)
 
 	lm.lock.Lock()
-	lm.listeners = append(lm.listeners, func() {
-		defer lm.waitGroup.Done()
-		fn()
+	lm.listeners = append(lm.listeners, prioritizedListener{
+		priority: priority,
+		fn: func() {
+			defer lm.waitGroup.Done()
+			fn()
+		},
 	})
 	lm.lock.Unlock()
 
@@ -80,7 +125,10 @@ func (lm *listenerManager) notifyListeners() {
 	lm.lock.Lock()
 	defer lm.lock.Unlock()
 
+	sort.SliceStable(lm.listeners, func(i, j int) bool {
+		return lm.listeners[i].priority < lm.listeners[j].priority
+	})
 	for _, listener := range lm.listeners {
-		listener()
+		listener.fn()
 	}
 }
@@ -26,3 +26,45 @@ func TestShutdown(t *testing.T) {
 	called()
 	assert.Equal(t, 3, val)
 }
+
+func TestShutdownWithPriority(t *testing.T) {
+	var order []int
+	lm := new(listenerManager)
+	lm.addListener(func() {
+		order = append(order, ShutdownPriorityClose)
+	}, ShutdownPriorityClose)
+	lm.addListener(func() {
+		order = append(order, ShutdownPriorityDeregister)
+	}, ShutdownPriorityDeregister)
+	lm.addListener(func() {
+		order = append(order, ShutdownPriorityFlush)
+	}, ShutdownPriorityFlush)
+	called := lm.addListener(func() {
+		order = append(order, ShutdownPriorityDrain)
+	}, ShutdownPriorityDrain)
+
+	lm.notifyListeners()
+	called()
+	assert.Equal(t, []int{
+		ShutdownPriorityDeregister,
+		ShutdownPriorityDrain,
+		ShutdownPriorityFlush,
+		ShutdownPriorityClose,
+	}, order)
+}
+
+func TestWaitForShutdownComplete(t *testing.T) {
+	notifyShutdownComplete()
+
+	doneChan := make(chan struct{})
+	go func() {
+		WaitForShutdownComplete()
+		close(doneChan)
+	}()
+
+	select {
+	case <-doneChan:
+	case <-time.After(time.Second):
+		t.Fatal("WaitForShutdownComplete didn't return")
+	}
+}
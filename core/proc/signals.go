@@ -25,6 +25,8 @@ func init() {
 			switch v {
 			case syscall.SIGUSR1:
 				dumpGoroutines()
+				// allow enabling debug logging on a live instance
+				logx.ToggleVerbose()
 			case syscall.SIGUSR2:
 				if profiler == nil {
 					profiler = StartProfile()
@@ -1,6 +1,7 @@
 package prometheus
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"net/http"
 	"sync"
@@ -10,22 +11,98 @@ import (
 	"github.com/tal-tech/go-zero/core/threading"
 )
 
+const (
+	// the path to adjust the logging level on the metrics listener.
+	logLevelPath = "/loglevel"
+	defaultPath  = "/metrics"
+)
+
 var once sync.Once
 
+// Handler returns the metrics handler protected as the config says,
+// it can be mounted onto an existing rest server instead of
+// starting a second listener.
+func Handler(c Config) http.Handler {
+	return withAuth(c, promhttp.Handler())
+}
+
 // StartAgent starts a prometheus agent.
 func StartAgent(c Config) {
 	once.Do(func() {
+		if !c.RuntimeMetrics {
+			DisableRuntimeMetrics()
+		}
+		startPusher(c.Pushgateway)
 		if len(c.Host) == 0 {
 			return
 		}
 
 		threading.GoSafe(func() {
-			http.Handle(c.Path, promhttp.Handler())
+			path := c.Path
+			if len(path) == 0 {
+				path = defaultPath
+			}
+
+			// a dedicated mux, don't leak whatever is registered on the
+			// default mux through the metrics port
+			mux := http.NewServeMux()
+			mux.Handle(path, Handler(c))
+			mux.Handle(logLevelPath, withAuth(c, logx.LevelHandler()))
 			addr := fmt.Sprintf("%s:%d", c.Host, c.Port)
+			server := &http.Server{
+				Addr:    addr,
+				Handler: mux,
+			}
+
 			logx.Infof("Starting prometheus agent at %s", addr)
-			if err := http.ListenAndServe(addr, nil); err != nil {
+			var err error
+			if len(c.CertFile) > 0 && len(c.KeyFile) > 0 {
+				err = server.ListenAndServeTLS(c.CertFile, c.KeyFile)
+			} else {
+				err = server.ListenAndServe()
+			}
+			if err != nil {
 				logx.Error(err)
 			}
 		})
 	})
 }
+
+// withAuth guards next with basic auth or a bearer token when configured.
+func withAuth(c Config, next http.Handler) http.Handler {
+	if len(c.User) == 0 && len(c.Token) == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(c, r) {
+			if len(c.User) > 0 {
+				w.Header().Set("WWW-Authenticate", `Basic realm="metrics"`)
+			}
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func authorized(c Config, r *http.Request) bool {
+	if len(c.User) > 0 {
+		user, pass, ok := r.BasicAuth()
+		return ok && secureEquals(user, c.User) && secureEquals(pass, c.Pass)
+	}
+
+	const bearerPrefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if len(auth) <= len(bearerPrefix) {
+		return false
+	}
+
+	return secureEquals(auth[:len(bearerPrefix)], bearerPrefix) &&
+		secureEquals(auth[len(bearerPrefix):], c.Token)
+}
+
+func secureEquals(given, expected string) bool {
+	return subtle.ConstantTimeCompare([]byte(given), []byte(expected)) == 1
+}
@@ -0,0 +1,58 @@
+package prometheus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerNoAuth(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, defaultPath, nil)
+	resp := httptest.NewRecorder()
+	Handler(Config{}).ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestHandlerBasicAuth(t *testing.T) {
+	conf := Config{
+		User: "prom",
+		Pass: "secret",
+	}
+
+	req := httptest.NewRequest(http.MethodGet, defaultPath, nil)
+	resp := httptest.NewRecorder()
+	Handler(conf).ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.NotEmpty(t, resp.Header().Get("WWW-Authenticate"))
+
+	req = httptest.NewRequest(http.MethodGet, defaultPath, nil)
+	req.SetBasicAuth("prom", "wrong")
+	resp = httptest.NewRecorder()
+	Handler(conf).ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+
+	req = httptest.NewRequest(http.MethodGet, defaultPath, nil)
+	req.SetBasicAuth("prom", "secret")
+	resp = httptest.NewRecorder()
+	Handler(conf).ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestHandlerBearerToken(t *testing.T) {
+	conf := Config{
+		Token: "secret",
+	}
+
+	req := httptest.NewRequest(http.MethodGet, defaultPath, nil)
+	resp := httptest.NewRecorder()
+	Handler(conf).ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+
+	req = httptest.NewRequest(http.MethodGet, defaultPath, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp = httptest.NewRecorder()
+	Handler(conf).ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
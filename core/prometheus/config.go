@@ -1,8 +1,36 @@
 package prometheus
 
-// A Config is a prometheus config.
-type Config struct {
-	Host string `json:",optional"`
-	Port int    `json:",default=9101"`
-	Path string `json:",default=/metrics"`
-}
+import "time"
+
+type (
+	// A PushgatewayConf is a pushgateway config, so the short-lived jobs
+	// that exit before being scraped can push their metrics.
+	PushgatewayConf struct {
+		Url     string `json:",optional"`
+		JobName string `json:",optional"`
+		// PushInterval is how often the metrics are pushed,
+		// a final push is always done on graceful shutdown.
+		PushInterval time.Duration `json:",default=15s"`
+	}
+
+	// A Config is a prometheus config.
+	// Set Host to 127.0.0.1 to only serve the metrics locally.
+	Config struct {
+		Host string `json:",optional"`
+		Port int    `json:",default=9101"`
+		Path string `json:",default=/metrics"`
+		// CertFile and KeyFile enable serving the metrics over https.
+		CertFile string `json:",optional"`
+		KeyFile  string `json:",optional"`
+		// User and Pass protect the listener with basic auth.
+		User string `json:",optional"`
+		Pass string `json:",optional"`
+		// Token protects the listener with a bearer token,
+		// ignored when basic auth is set.
+		Token string `json:",optional"`
+		// RuntimeMetrics exposes the go runtime and process collectors,
+		// like gc pauses, heap, goroutines and threads.
+		RuntimeMetrics bool            `json:",default=true"`
+		Pushgateway    PushgatewayConf `json:",optional"`
+	}
+)
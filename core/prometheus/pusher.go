@@ -0,0 +1,54 @@
+package prometheus
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/proc"
+	"github.com/tal-tech/go-zero/core/sysx"
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+const defaultPushInterval = time.Second * 15
+
+// startPusher pushes the registered metrics to the configured pushgateway
+// periodically, and once more on graceful shutdown, so the metrics of a
+// finishing job are not lost.
+func startPusher(c PushgatewayConf) {
+	if len(c.Url) == 0 {
+		return
+	}
+
+	job := c.JobName
+	if len(job) == 0 {
+		job = filepath.Base(os.Args[0])
+	}
+	interval := c.PushInterval
+	if interval <= 0 {
+		interval = defaultPushInterval
+	}
+
+	pusher := push.New(c.Url, job).
+		Gatherer(prom.DefaultGatherer).
+		Grouping("instance", sysx.Hostname())
+	proc.AddShutdownListener(func() {
+		if err := pusher.Push(); err != nil {
+			logx.Errorf("final push to pushgateway failed: %v", err)
+		}
+	})
+
+	threading.GoSafe(func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := pusher.Push(); err != nil {
+				logx.Errorf("push to pushgateway failed: %v", err)
+			}
+		}
+	})
+}
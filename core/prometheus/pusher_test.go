@@ -0,0 +1,46 @@
+package prometheus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartPusher(t *testing.T) {
+	var pushes int32
+	var path atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&pushes, 1)
+		path.Store(r.URL.Path)
+	}))
+	defer server.Close()
+
+	startPusher(PushgatewayConf{
+		Url:          server.URL,
+		JobName:      "test-job",
+		PushInterval: time.Millisecond * 10,
+	})
+
+	for i := 0; i < 100; i++ {
+		if atomic.LoadInt32(&pushes) > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	assert.True(t, atomic.LoadInt32(&pushes) > 0)
+	pushed, ok := path.Load().(string)
+	assert.True(t, ok)
+	assert.True(t, strings.HasPrefix(pushed, "/metrics/job/test-job"))
+}
+
+func TestStartPusherNoUrl(t *testing.T) {
+	assert.NotPanics(t, func() {
+		startPusher(PushgatewayConf{})
+	})
+}
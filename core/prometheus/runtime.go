@@ -0,0 +1,22 @@
+package prometheus
+
+import (
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// EnableRuntimeMetrics registers the go runtime and process collectors,
+// covering gc pauses, heap, goroutines and threads. client_golang
+// registers them by default, calling it after DisableRuntimeMetrics
+// brings them back.
+func EnableRuntimeMetrics() {
+	// ignore AlreadyRegisteredError when they are still registered
+	_ = prom.Register(prom.NewGoCollector())
+	_ = prom.Register(prom.NewProcessCollector(prom.ProcessCollectorOpts{}))
+}
+
+// DisableRuntimeMetrics unregisters the go runtime and process collectors,
+// cutting the scrape size for the services that don't need them.
+func DisableRuntimeMetrics() {
+	prom.Unregister(prom.NewGoCollector())
+	prom.Unregister(prom.NewProcessCollector(prom.ProcessCollectorOpts{}))
+}
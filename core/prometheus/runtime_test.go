@@ -0,0 +1,33 @@
+package prometheus
+
+import (
+	"testing"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func hasMetricFamily(t *testing.T, name string) bool {
+	families, err := prom.DefaultGatherer.Gather()
+	assert.Nil(t, err)
+	for _, family := range families {
+		if family.GetName() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestRuntimeMetricsToggle(t *testing.T) {
+	assert.True(t, hasMetricFamily(t, "go_goroutines"))
+
+	DisableRuntimeMetrics()
+	assert.False(t, hasMetricFamily(t, "go_goroutines"))
+
+	EnableRuntimeMetrics()
+	assert.True(t, hasMetricFamily(t, "go_goroutines"))
+	// safe to call when already registered
+	EnableRuntimeMetrics()
+	assert.True(t, hasMetricFamily(t, "go_goroutines"))
+}
@@ -0,0 +1,229 @@
+package queue
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/stat"
+	"github.com/tal-tech/go-zero/core/syncx"
+	"github.com/tal-tech/go-zero/core/threading"
+	"github.com/tal-tech/go-zero/core/timex"
+)
+
+const (
+	defaultRetries       = 3
+	defaultRetryInterval = time.Second
+)
+
+type (
+	// A BrokerMessage is one message fetched from a message broker.
+	BrokerMessage interface {
+		// Payload returns the message body.
+		Payload() string
+		// Ack marks the message as processed on the broker.
+		Ack() error
+		// Nack gives the message back to the broker for redelivery.
+		Nack() error
+	}
+
+	// A BrokerReader reads messages from a message broker, e.g. a kafka consumer
+	// group session or an amqp channel. Fetch blocks until a message arrives or
+	// the reader is closed. Readers are shared by the consumers of a BrokerQueue,
+	// so implementations must be safe for concurrent use.
+	BrokerReader interface {
+		Name() string
+		Fetch() (BrokerMessage, error)
+		Close() error
+	}
+
+	// A DeadLetterPusher takes the messages that exhausted their retries,
+	// typically backed by a dead letter topic or exchange on the same broker.
+	DeadLetterPusher interface {
+		PushDeadLetter(payload string) error
+	}
+
+	// A BrokerQueueOption customizes a BrokerQueue.
+	BrokerQueueOption func(q *BrokerQueue)
+
+	// A BrokerQueue consumes a BrokerReader with a group of balanced consumers,
+	// handling messages at least once: a message is acknowledged only after it's
+	// consumed successfully or handed over to the dead letter pusher, otherwise
+	// it's given back to the broker for redelivery.
+	// It implements MessageQueue, and reports health for readiness checks.
+	BrokerQueue struct {
+		name            string
+		reader          BrokerReader
+		consumerFactory ConsumerFactory
+		consumerCount   int
+		retries         int
+		retryInterval   time.Duration
+		deadLetter      DeadLetterPusher
+		metrics         *stat.Metrics
+		routineGroup    *threading.RoutineGroup
+		started         *syncx.AtomicBool
+		quit            chan struct{}
+		stopOnce        func()
+	}
+)
+
+// WithConsumers customizes the number of consumers, default is NumCPU*2.
+func WithConsumers(count int) BrokerQueueOption {
+	return func(q *BrokerQueue) {
+		q.consumerCount = count
+	}
+}
+
+// WithRetry customizes how many times a message is retried before it's given up,
+// and the interval between the attempts.
+func WithRetry(retries int, interval time.Duration) BrokerQueueOption {
+	return func(q *BrokerQueue) {
+		q.retries = retries
+		q.retryInterval = interval
+	}
+}
+
+// WithDeadLetter customizes the pusher that takes the messages exhausted their retries,
+// without it such messages are given back to the broker.
+func WithDeadLetter(pusher DeadLetterPusher) BrokerQueueOption {
+	return func(q *BrokerQueue) {
+		q.deadLetter = pusher
+	}
+}
+
+// NewBrokerQueue returns a BrokerQueue that consumes reader with the consumers
+// created by factory.
+func NewBrokerQueue(reader BrokerReader, factory ConsumerFactory,
+	opts ...BrokerQueueOption) *BrokerQueue {
+	q := &BrokerQueue{
+		name:            reader.Name(),
+		reader:          reader,
+		consumerFactory: factory,
+		consumerCount:   runtime.NumCPU() << 1,
+		retries:         defaultRetries,
+		retryInterval:   defaultRetryInterval,
+		routineGroup:    threading.NewRoutineGroup(),
+		started:         syncx.NewAtomicBool(),
+		quit:            make(chan struct{}),
+	}
+	q.metrics = stat.NewMetrics(q.name)
+	q.stopOnce = syncx.Once(q.doStop)
+	for _, opt := range opts {
+		opt(q)
+	}
+
+	return q
+}
+
+// Healthy reports whether the consumers are up and running.
+func (q *BrokerQueue) Healthy() bool {
+	return q.started.True()
+}
+
+// Start starts the consumers and blocks until Stop is called.
+func (q *BrokerQueue) Start() {
+	for i := 0; i < q.consumerCount; i++ {
+		consumer, err := q.consumerFactory()
+		if err != nil {
+			logx.Errorf("%s: create consumer: %s", q.name, err.Error())
+			continue
+		}
+
+		q.routineGroup.RunSafe(func() {
+			q.consumeLoop(consumer)
+		})
+	}
+
+	q.started.Set(true)
+	q.routineGroup.Wait()
+}
+
+// Stop stops the consumers and closes the underlying reader.
+func (q *BrokerQueue) Stop() {
+	q.stopOnce()
+}
+
+func (q *BrokerQueue) doStop() {
+	q.started.Set(false)
+	close(q.quit)
+	if err := q.reader.Close(); err != nil {
+		logx.Errorf("%s: close reader: %s", q.name, err.Error())
+	}
+	q.routineGroup.Wait()
+}
+
+func (q *BrokerQueue) consumeLoop(consumer Consumer) {
+	for {
+		msg, err := q.reader.Fetch()
+		if q.quitting() {
+			return
+		}
+		if err != nil {
+			logx.Errorf("%s: fetch message: %s", q.name, err.Error())
+			time.Sleep(q.retryInterval)
+			continue
+		}
+
+		q.consumeOne(consumer, msg)
+	}
+}
+
+func (q *BrokerQueue) consumeOne(consumer Consumer, msg BrokerMessage) {
+	startTime := timex.Now()
+	defer func() {
+		q.metrics.Add(stat.Task{
+			Duration: timex.Since(startTime),
+		})
+	}()
+
+	if err := q.consumeWithRetries(consumer, msg.Payload()); err == nil {
+		q.acknowledge(msg)
+		return
+	}
+
+	if q.deadLetter != nil {
+		err := q.deadLetter.PushDeadLetter(msg.Payload())
+		if err == nil {
+			q.acknowledge(msg)
+			return
+		}
+
+		logx.Errorf("%s: push dead letter: %s", q.name, err.Error())
+	}
+
+	// give the message back to the broker, at-least-once
+	if err := msg.Nack(); err != nil {
+		logx.Errorf("%s: nack message: %s", q.name, err.Error())
+	}
+}
+
+func (q *BrokerQueue) consumeWithRetries(consumer Consumer, payload string) error {
+	var err error
+	for i := 0; ; i++ {
+		if err = consumer.Consume(payload); err == nil || i >= q.retries {
+			return err
+		}
+
+		logx.Errorf("%s: consume failed on attempt %d: %s", q.name, i+1, err.Error())
+		select {
+		case <-q.quit:
+			return err
+		case <-time.After(q.retryInterval):
+		}
+	}
+}
+
+func (q *BrokerQueue) acknowledge(msg BrokerMessage) {
+	if err := msg.Ack(); err != nil {
+		logx.Errorf("%s: ack message: %s", q.name, err.Error())
+	}
+}
+
+func (q *BrokerQueue) quitting() bool {
+	select {
+	case <-q.quit:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,164 @@
+package queue
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errClosedReader = errors.New("reader closed")
+
+type mockedBrokerMessage struct {
+	payload string
+	acked   int32
+	nacked  int32
+}
+
+func (m *mockedBrokerMessage) Payload() string {
+	return m.payload
+}
+
+func (m *mockedBrokerMessage) Ack() error {
+	atomic.AddInt32(&m.acked, 1)
+	return nil
+}
+
+func (m *mockedBrokerMessage) Nack() error {
+	atomic.AddInt32(&m.nacked, 1)
+	return nil
+}
+
+type mockedBrokerReader struct {
+	messages chan *mockedBrokerMessage
+	once     sync.Once
+}
+
+func newMockedBrokerReader(size int) *mockedBrokerReader {
+	return &mockedBrokerReader{
+		messages: make(chan *mockedBrokerMessage, size),
+	}
+}
+
+func (r *mockedBrokerReader) Name() string {
+	return "mocked"
+}
+
+func (r *mockedBrokerReader) Fetch() (BrokerMessage, error) {
+	msg, ok := <-r.messages
+	if !ok {
+		return nil, errClosedReader
+	}
+
+	return msg, nil
+}
+
+func (r *mockedBrokerReader) Close() error {
+	r.once.Do(func() {
+		close(r.messages)
+	})
+	return nil
+}
+
+type mockedDeadLetter struct {
+	payloads []string
+	lock     sync.Mutex
+	err      error
+}
+
+func (p *mockedDeadLetter) PushDeadLetter(payload string) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	p.lock.Lock()
+	p.payloads = append(p.payloads, payload)
+	p.lock.Unlock()
+	return nil
+}
+
+func TestBrokerQueueConsume(t *testing.T) {
+	reader := newMockedBrokerReader(10)
+	msg := &mockedBrokerMessage{payload: "hello"}
+	reader.messages <- msg
+
+	var consumed int32
+	q := NewBrokerQueue(reader, func() (Consumer, error) {
+		return consumeFunc(func(string) error {
+			atomic.AddInt32(&consumed, 1)
+			return nil
+		}), nil
+	}, WithConsumers(2))
+
+	go q.Start()
+	for atomic.LoadInt32(&msg.acked) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	q.Stop()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&consumed))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&msg.acked))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&msg.nacked))
+	assert.False(t, q.Healthy())
+}
+
+func TestBrokerQueueRetryThenDeadLetter(t *testing.T) {
+	reader := newMockedBrokerReader(10)
+	msg := &mockedBrokerMessage{payload: "bad"}
+	reader.messages <- msg
+
+	deadLetter := new(mockedDeadLetter)
+	var attempts int32
+	q := NewBrokerQueue(reader, func() (Consumer, error) {
+		return consumeFunc(func(string) error {
+			atomic.AddInt32(&attempts, 1)
+			return errors.New("always fails")
+		}), nil
+	}, WithConsumers(1), WithRetry(2, time.Millisecond), WithDeadLetter(deadLetter))
+
+	go q.Start()
+	for atomic.LoadInt32(&msg.acked) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	q.Stop()
+
+	// first attempt plus two retries
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+	deadLetter.lock.Lock()
+	assert.Equal(t, []string{"bad"}, deadLetter.payloads)
+	deadLetter.lock.Unlock()
+	assert.Equal(t, int32(0), atomic.LoadInt32(&msg.nacked))
+}
+
+func TestBrokerQueueNackWithoutDeadLetter(t *testing.T) {
+	reader := newMockedBrokerReader(10)
+	msg := &mockedBrokerMessage{payload: "bad"}
+	reader.messages <- msg
+
+	q := NewBrokerQueue(reader, func() (Consumer, error) {
+		return consumeFunc(func(string) error {
+			return errors.New("always fails")
+		}), nil
+	}, WithConsumers(1), WithRetry(0, time.Millisecond))
+
+	go q.Start()
+	for atomic.LoadInt32(&msg.nacked) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	q.Stop()
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&msg.acked))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&msg.nacked))
+}
+
+type consumeFunc func(string) error
+
+func (f consumeFunc) Consume(payload string) error {
+	return f(payload)
+}
+
+func (f consumeFunc) OnEvent(interface{}) {
+}
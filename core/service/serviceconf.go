@@ -2,11 +2,14 @@ package service
 
 import (
 	"log"
+	"time"
 
 	"github.com/tal-tech/go-zero/core/load"
 	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/proc"
 	"github.com/tal-tech/go-zero/core/prometheus"
 	"github.com/tal-tech/go-zero/core/stat"
+	"github.com/tal-tech/go-zero/core/trace"
 )
 
 const (
@@ -28,7 +31,12 @@ type ServiceConf struct {
 	Log        logx.LogConf
 	Mode       string            `json:",default=pro,options=dev|test|rt|pre|pro"`
 	MetricsUrl string            `json:",optional"`
-	Prometheus prometheus.Config `json:",optional"`
+	Prometheus prometheus.Config   `json:",optional"`
+	Trace      trace.SamplerConf   `json:",optional"`
+	Telemetry  trace.TelemetryConf `json:",optional"`
+	// GracePeriod is the total time to wait for the shutdown listeners on quitting,
+	// zero keeps the builtin 5.5s default.
+	GracePeriod time.Duration `json:",optional"`
 }
 
 // MustSetUp sets up the service, exits on error.
@@ -48,7 +56,18 @@ func (sc ServiceConf) SetUp() error {
 	}
 
 	sc.initMode()
+	if sc.GracePeriod > 0 {
+		proc.SetTimeToForceQuit(sc.GracePeriod)
+	}
 	prometheus.StartAgent(sc.Prometheus)
+	sampler, err := trace.NewSampler(sc.Trace)
+	if err != nil {
+		return err
+	}
+	trace.SetSampler(sampler)
+	if err := trace.StartSpanExporter(sc.Telemetry); err != nil {
+		return err
+	}
 	if len(sc.MetricsUrl) > 0 {
 		stat.SetReportWriter(stat.NewRemoteWriter(sc.MetricsUrl))
 	}
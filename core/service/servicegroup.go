@@ -2,12 +2,20 @@ package service
 
 import (
 	"log"
+	"sort"
+	"time"
 
+	"github.com/tal-tech/go-zero/core/logx"
 	"github.com/tal-tech/go-zero/core/proc"
 	"github.com/tal-tech/go-zero/core/syncx"
 	"github.com/tal-tech/go-zero/core/threading"
 )
 
+const (
+	defaultStartTimeout = time.Minute
+	healthPollInterval  = time.Millisecond * 10
+)
+
 type (
 	// Starter is the interface wraps the Start method.
 	Starter interface {
@@ -25,23 +33,83 @@ type (
 		Stopper
 	}
 
+	// HealthChecker is the optional interface for services that report their health,
+	// the group waits for it on start and aggregates it in Healthy.
+	HealthChecker interface {
+		Healthy() bool
+	}
+
+	// An AddOption customizes how a service is added into a ServiceGroup.
+	AddOption func(entry *serviceEntry)
+
+	serviceEntry struct {
+		service      Service
+		priority     int
+		startTimeout time.Duration
+	}
+
 	// A ServiceGroup is a group of services.
+	// Services are started in ascending priority order and stopped in the reverse order,
+	// services with the same priority start concurrently.
 	ServiceGroup struct {
-		services []Service
+		entries  []serviceEntry
 		stopOnce func()
+		started  *syncx.AtomicBool
 	}
 )
 
+// WithPriority customizes the priority of a service, lower priorities start earlier
+// and stop later, the default priority is 0.
+func WithPriority(priority int) AddOption {
+	return func(entry *serviceEntry) {
+		entry.priority = priority
+	}
+}
+
+// WithStartTimeout customizes how long the group waits for a health checkable service
+// to report healthy before starting the next priority, the default is one minute.
+func WithStartTimeout(timeout time.Duration) AddOption {
+	return func(entry *serviceEntry) {
+		entry.startTimeout = timeout
+	}
+}
+
 // NewServiceGroup returns a ServiceGroup.
 func NewServiceGroup() *ServiceGroup {
-	sg := new(ServiceGroup)
+	sg := &ServiceGroup{
+		started: syncx.NewAtomicBool(),
+	}
 	sg.stopOnce = syncx.Once(sg.doStop)
 	return sg
 }
 
 // Add adds service into sg.
-func (sg *ServiceGroup) Add(service Service) {
-	sg.services = append(sg.services, service)
+func (sg *ServiceGroup) Add(service Service, opts ...AddOption) {
+	entry := serviceEntry{
+		service:      service,
+		startTimeout: defaultStartTimeout,
+	}
+	for _, opt := range opts {
+		opt(&entry)
+	}
+
+	sg.entries = append(sg.entries, entry)
+}
+
+// Healthy reports whether all services are started and the health checkable ones
+// report healthy, it can back a readiness endpoint.
+func (sg *ServiceGroup) Healthy() bool {
+	if !sg.started.True() {
+		return false
+	}
+
+	for _, entry := range sg.entries {
+		if checker, ok := entry.service.(HealthChecker); ok && !checker.Healthy() {
+			return false
+		}
+	}
+
+	return true
 }
 
 // Start starts the ServiceGroup.
@@ -64,19 +132,60 @@ func (sg *ServiceGroup) Stop() {
 func (sg *ServiceGroup) doStart() {
 	routineGroup := threading.NewRoutineGroup()
 
-	for i := range sg.services {
-		service := sg.services[i]
-		routineGroup.RunSafe(func() {
-			service.Start()
-		})
+	entries := sg.sortedEntries()
+	for i := 0; i < len(entries); {
+		j := i
+		for j < len(entries) && entries[j].priority == entries[i].priority {
+			entry := entries[j]
+			routineGroup.RunSafe(func() {
+				entry.service.Start()
+			})
+			j++
+		}
+
+		waitForHealthy(entries[i:j])
+		i = j
 	}
 
+	sg.started.Set(true)
 	routineGroup.Wait()
 }
 
 func (sg *ServiceGroup) doStop() {
-	for _, service := range sg.services {
-		service.Stop()
+	entries := sg.sortedEntries()
+	for i := len(entries) - 1; i >= 0; i-- {
+		entries[i].service.Stop()
+	}
+}
+
+// sortedEntries returns the entries in start order,
+// services with the same priority keep their add order.
+func (sg *ServiceGroup) sortedEntries() []serviceEntry {
+	entries := make([]serviceEntry, len(sg.entries))
+	copy(entries, sg.entries)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].priority < entries[j].priority
+	})
+	return entries
+}
+
+func waitForHealthy(entries []serviceEntry) {
+	for _, entry := range entries {
+		checker, ok := entry.service.(HealthChecker)
+		if !ok {
+			continue
+		}
+
+		deadline := time.Now().Add(entry.startTimeout)
+		for !checker.Healthy() {
+			if time.Now().After(deadline) {
+				logx.Errorf("service %T not healthy within %v, starting next priority anyway",
+					entry.service, entry.startTimeout)
+				break
+			}
+
+			time.Sleep(healthPollInterval)
+		}
 	}
 }
 
@@ -3,8 +3,10 @@ package service
 import (
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/syncx"
 )
 
 var (
@@ -124,3 +126,106 @@ type mockedStarter struct {
 func (s mockedStarter) Start() {
 	s.fn()
 }
+
+type probedService struct {
+	name    string
+	lock    *sync.Mutex
+	starts  *[]string
+	stops   *[]string
+	healthy *syncx.AtomicBool
+	quit    chan struct{}
+}
+
+func newProbedService(name string, lock *sync.Mutex, starts, stops *[]string) *probedService {
+	return &probedService{
+		name:    name,
+		lock:    lock,
+		starts:  starts,
+		stops:   stops,
+		healthy: syncx.NewAtomicBool(),
+		quit:    make(chan struct{}),
+	}
+}
+
+func (s *probedService) Start() {
+	s.lock.Lock()
+	*s.starts = append(*s.starts, s.name)
+	s.lock.Unlock()
+	s.healthy.Set(true)
+	<-s.quit
+}
+
+func (s *probedService) Stop() {
+	s.lock.Lock()
+	*s.stops = append(*s.stops, s.name)
+	s.lock.Unlock()
+	s.healthy.Set(false)
+	close(s.quit)
+}
+
+func (s *probedService) Healthy() bool {
+	return s.healthy.True()
+}
+
+func TestServiceGroupPriority(t *testing.T) {
+	var lock sync.Mutex
+	var starts, stops []string
+
+	group := NewServiceGroup()
+	second := newProbedService("second", &lock, &starts, &stops)
+	first := newProbedService("first", &lock, &starts, &stops)
+	third := newProbedService("third", &lock, &starts, &stops)
+	group.Add(second, WithPriority(1))
+	group.Add(first)
+	group.Add(third, WithPriority(1))
+
+	assert.False(t, group.Healthy())
+	go group.Start()
+	for !group.Healthy() {
+		time.Sleep(time.Millisecond)
+	}
+
+	lock.Lock()
+	assert.Equal(t, "first", starts[0])
+	lock.Unlock()
+
+	group.Stop()
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, []string{"third", "second", "first"}, stops)
+}
+
+func TestServiceGroupStartTimeout(t *testing.T) {
+	var lock sync.Mutex
+	var starts, stops []string
+
+	group := NewServiceGroup()
+	group.Add(WithStarter(mockedStarter{fn: func() {}}))
+	group.Add(&alwaysUnhealthy{}, WithStartTimeout(time.Millisecond*10))
+	group.Add(newProbedService("next", &lock, &starts, &stops), WithPriority(1))
+
+	go group.Start()
+	for {
+		lock.Lock()
+		started := len(starts) > 0
+		lock.Unlock()
+		if started {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	assert.False(t, group.Healthy())
+	group.Stop()
+}
+
+type alwaysUnhealthy struct {
+	stopper
+}
+
+func (s *alwaysUnhealthy) Start() {
+}
+
+func (s *alwaysUnhealthy) Healthy() bool {
+	return false
+}
@@ -2,6 +2,7 @@ package stat
 
 import (
 	"os"
+	"sort"
 	"sync"
 	"time"
 
@@ -10,31 +11,47 @@ import (
 	"github.com/tal-tech/go-zero/core/syncx"
 )
 
+// the bounded number of slowest tasks kept per report interval.
+const topSlowCount = 5
+
 var (
 	logInterval  = time.Minute
 	writerLock   sync.Mutex
 	reportWriter Writer = nil
 	logEnabled          = syncx.ForAtomicBool(true)
+	slowLock     sync.Mutex
+	slowCallback SlowCallback
 )
 
 type (
+	// SlowCallback is called with the slowest tasks of each report interval.
+	SlowCallback func(tasks []Task)
+
 	// Writer interface wraps the Write method.
 	Writer interface {
 		Write(report *StatReport) error
 	}
 
+	// A SlowTask is one of the slowest tasks within a report interval.
+	SlowTask struct {
+		Description string  `json:"desc"`
+		Duration    float32 `json:"duration"`
+		TraceId     string  `json:"trace,omitempty"`
+	}
+
 	// A StatReport is a stat report entry.
 	StatReport struct {
-		Name          string  `json:"name"`
-		Timestamp     int64   `json:"tm"`
-		Pid           int     `json:"pid"`
-		ReqsPerSecond float32 `json:"qps"`
-		Drops         int     `json:"drops"`
-		Average       float32 `json:"avg"`
-		Median        float32 `json:"med"`
-		Top90th       float32 `json:"t90"`
-		Top99th       float32 `json:"t99"`
-		Top99p9th     float32 `json:"t99p9"`
+		Name          string     `json:"name"`
+		Timestamp     int64      `json:"tm"`
+		Pid           int        `json:"pid"`
+		ReqsPerSecond float32    `json:"qps"`
+		Drops         int        `json:"drops"`
+		Average       float32    `json:"avg"`
+		Median        float32    `json:"med"`
+		Top90th       float32    `json:"t90"`
+		Top99th       float32    `json:"t99"`
+		Top99p9th     float32    `json:"t99p9"`
+		Slowest       []SlowTask `json:"slowest,omitempty"`
 	}
 
 	// A Metrics is used to log and report stat reports.
@@ -49,7 +66,17 @@ func DisableLog() {
 	logEnabled.Set(false)
 }
 
+// SetSlowCallback sets fn to be called with the slowest tasks of each
+// report interval, so the slow requests can be triaged beyond percentiles.
+func SetSlowCallback(fn SlowCallback) {
+	slowLock.Lock()
+	slowCallback = fn
+	slowLock.Unlock()
+}
+
 // SetReportWriter sets the report writer.
+//
+// Deprecated: use AddReporter with NewWebhookReporter instead.
 func SetReportWriter(writer Writer) {
 	writerLock.Lock()
 	reportWriter = writer
@@ -133,6 +160,7 @@ func (c *metricsContainer) Execute(v interface{}) {
 
 	if size > 0 {
 		report.Average = float32(duration/time.Millisecond) / float32(size)
+		report.Slowest = slowestTasks(tasks)
 
 		fiftyPercent := size >> 1
 		if fiftyPercent > 0 {
@@ -195,6 +223,31 @@ func (c *metricsContainer) RemoveAll() interface{} {
 	}
 }
 
+func slowestTasks(tasks []Task) []SlowTask {
+	slowest := topK(tasks, topSlowCount)
+	sort.Slice(slowest, func(i, j int) bool {
+		return slowest[i].Duration > slowest[j].Duration
+	})
+
+	slowTasks := make([]SlowTask, 0, len(slowest))
+	for _, task := range slowest {
+		slowTasks = append(slowTasks, SlowTask{
+			Description: task.Description,
+			Duration:    float32(task.Duration) / float32(time.Millisecond),
+			TraceId:     task.TraceId,
+		})
+	}
+
+	slowLock.Lock()
+	fn := slowCallback
+	slowLock.Unlock()
+	if fn != nil {
+		fn(slowest)
+	}
+
+	return slowTasks
+}
+
 func getTopDuration(tasks []Task) float32 {
 	top := topK(tasks, 1)
 	if len(top) < 1 {
@@ -206,11 +259,9 @@ func getTopDuration(tasks []Task) float32 {
 
 func log(report *StatReport) {
 	writeReport(report)
+	reportToAll(report)
 	if logEnabled.True() {
-		logx.Statf("(%s) - qps: %.1f/s, drops: %d, avg time: %.1fms, med: %.1fms, "+
-			"90th: %.1fms, 99th: %.1fms, 99.9th: %.1fms",
-			report.Name, report.ReqsPerSecond, report.Drops, report.Average, report.Median,
-			report.Top90th, report.Top99th, report.Top99p9th)
+		logx.Stat(formatReport(report))
 	}
 }
 
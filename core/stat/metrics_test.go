@@ -1,6 +1,7 @@
 package stat
 
 import (
+	"os"
 	"strconv"
 	"testing"
 	"time"
@@ -40,3 +41,36 @@ func (m *mockedWriter) Write(report *StatReport) error {
 	m.report = report
 	return nil
 }
+
+func TestMetricsSlowestTasks(t *testing.T) {
+	var called []Task
+	SetSlowCallback(func(tasks []Task) {
+		called = tasks
+	})
+	defer SetSlowCallback(nil)
+
+	tasks := make([]Task, 0, 10)
+	var duration time.Duration
+	for i := 1; i <= 10; i++ {
+		task := Task{
+			Duration:    time.Millisecond * time.Duration(i),
+			Description: "GET /test",
+			TraceId:     "trace",
+		}
+		tasks = append(tasks, task)
+		duration += task.Duration
+	}
+
+	c := &metricsContainer{name: "slow", pid: os.Getpid()}
+	c.Execute(tasksDurationPair{
+		tasks:    tasks,
+		duration: duration,
+	})
+
+	assert.Equal(t, topSlowCount, len(called))
+	// sorted from slowest to fastest
+	assert.Equal(t, time.Millisecond*10, called[0].Duration)
+	assert.Equal(t, time.Millisecond*6, called[topSlowCount-1].Duration)
+	assert.Equal(t, "GET /test", called[0].Description)
+	assert.Equal(t, "trace", called[0].TraceId)
+}
@@ -21,6 +21,8 @@ type RemoteWriter struct {
 }
 
 // NewRemoteWriter returns a RemoteWriter.
+//
+// Deprecated: use AddReporter with NewWebhookReporter instead.
 func NewRemoteWriter(endpoint string) Writer {
 	return &RemoteWriter{
 		endpoint: endpoint,
@@ -0,0 +1,156 @@
+package stat
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/metric"
+)
+
+// The modes that can be set in ReporterConf.
+const (
+	ReporterModeLog        = "log"
+	ReporterModePrometheus = "prometheus"
+	ReporterModeWebhook    = "webhook"
+
+	statNamespace = "stat"
+)
+
+var (
+	reporterLock sync.Mutex
+	reporters    []Reporter
+
+	promReportOnce    sync.Once
+	metricReportQps   metric.GaugeVec
+	metricReportDrops metric.GaugeVec
+	metricReportAvg   metric.GaugeVec
+	metricReportMed   metric.GaugeVec
+	metricReportP90   metric.GaugeVec
+	metricReportP99   metric.GaugeVec
+	metricReportP999  metric.GaugeVec
+)
+
+type (
+	// A Reporter delivers the periodic StatReport snapshots,
+	// multiple reporters can be added and each gets every report.
+	Reporter interface {
+		Report(report *StatReport) error
+	}
+
+	// A ReporterConf is the config to select a Reporter.
+	ReporterConf struct {
+		Mode string `json:",default=log,options=log|prometheus|webhook"`
+		// Url is the webhook endpoint, only used in webhook mode.
+		Url string `json:",optional"`
+	}
+
+	logReporter        struct{}
+	prometheusReporter struct{}
+
+	webhookReporter struct {
+		writer Writer
+	}
+)
+
+// AddReporter adds the given reporter to deliver the stat reports.
+func AddReporter(reporter Reporter) {
+	reporterLock.Lock()
+	reporters = append(reporters, reporter)
+	reporterLock.Unlock()
+}
+
+// NewReporter returns a Reporter built from the given config.
+func NewReporter(c ReporterConf) (Reporter, error) {
+	switch c.Mode {
+	case ReporterModeLog, "":
+		return NewLogReporter(), nil
+	case ReporterModePrometheus:
+		return NewPrometheusReporter(), nil
+	case ReporterModeWebhook:
+		if len(c.Url) == 0 {
+			return nil, fmt.Errorf("reporter mode %q requires url", c.Mode)
+		}
+		return NewWebhookReporter(c.Url), nil
+	default:
+		return nil, fmt.Errorf("unknown reporter mode %q", c.Mode)
+	}
+}
+
+// NewLogReporter returns a Reporter that logs the reports like the built-in stat logs.
+func NewLogReporter() Reporter {
+	return logReporter{}
+}
+
+// NewPrometheusReporter returns a Reporter that publishes the reports as prometheus gauges.
+func NewPrometheusReporter() Reporter {
+	promReportOnce.Do(initReportMetrics)
+	return prometheusReporter{}
+}
+
+// NewWebhookReporter returns a Reporter that posts the reports to the given endpoint as json.
+func NewWebhookReporter(url string) Reporter {
+	return webhookReporter{
+		writer: NewRemoteWriter(url),
+	}
+}
+
+func (r logReporter) Report(report *StatReport) error {
+	logx.Stat(formatReport(report))
+	return nil
+}
+
+func (r prometheusReporter) Report(report *StatReport) error {
+	metricReportQps.Set(float64(report.ReqsPerSecond), report.Name)
+	metricReportDrops.Set(float64(report.Drops), report.Name)
+	metricReportAvg.Set(float64(report.Average), report.Name)
+	metricReportMed.Set(float64(report.Median), report.Name)
+	metricReportP90.Set(float64(report.Top90th), report.Name)
+	metricReportP99.Set(float64(report.Top99th), report.Name)
+	metricReportP999.Set(float64(report.Top99p9th), report.Name)
+	return nil
+}
+
+func (r webhookReporter) Report(report *StatReport) error {
+	return r.writer.Write(report)
+}
+
+func formatReport(report *StatReport) string {
+	return fmt.Sprintf("(%s) - qps: %.1f/s, drops: %d, avg time: %.1fms, med: %.1fms, "+
+		"90th: %.1fms, 99th: %.1fms, 99.9th: %.1fms",
+		report.Name, report.ReqsPerSecond, report.Drops, report.Average, report.Median,
+		report.Top90th, report.Top99th, report.Top99p9th)
+}
+
+// initReportMetrics registers the gauges lazily, stat is imported almost
+// everywhere, don't pollute the registry when prometheus mode is not used.
+func initReportMetrics() {
+	newGauge := func(name, help string) metric.GaugeVec {
+		return metric.NewGaugeVec(&metric.GaugeVecOpts{
+			Namespace: statNamespace,
+			Subsystem: "report",
+			Name:      name,
+			Help:      help,
+			Labels:    []string{"name"},
+		})
+	}
+	metricReportQps = newGauge("qps", "stat report qps.")
+	metricReportDrops = newGauge("drops", "stat report dropped requests.")
+	metricReportAvg = newGauge("avg_duration_ms", "stat report average duration.")
+	metricReportMed = newGauge("median_duration_ms", "stat report median duration.")
+	metricReportP90 = newGauge("p90th_duration_ms", "stat report 90th percentile duration.")
+	metricReportP99 = newGauge("p99th_duration_ms", "stat report 99th percentile duration.")
+	metricReportP999 = newGauge("p99p9th_duration_ms", "stat report 99.9th percentile duration.")
+}
+
+func reportToAll(report *StatReport) {
+	reporterLock.Lock()
+	all := reporters
+	reporterLock.Unlock()
+
+	for _, reporter := range all {
+		if err := reporter.Report(report); err != nil {
+			logx.Error(err)
+		}
+	}
+}
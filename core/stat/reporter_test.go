@@ -0,0 +1,89 @@
+package stat
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockedReporter struct {
+	count int32
+	err   error
+}
+
+func (r *mockedReporter) Report(report *StatReport) error {
+	atomic.AddInt32(&r.count, 1)
+	return r.err
+}
+
+func TestNewReporter(t *testing.T) {
+	reporter, err := NewReporter(ReporterConf{})
+	assert.Nil(t, err)
+	assert.NotNil(t, reporter)
+
+	reporter, err = NewReporter(ReporterConf{Mode: ReporterModeLog})
+	assert.Nil(t, err)
+	assert.NotNil(t, reporter)
+
+	reporter, err = NewReporter(ReporterConf{Mode: ReporterModePrometheus})
+	assert.Nil(t, err)
+	assert.NotNil(t, reporter)
+
+	reporter, err = NewReporter(ReporterConf{
+		Mode: ReporterModeWebhook,
+		Url:  "http://localhost:12345",
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, reporter)
+
+	_, err = NewReporter(ReporterConf{Mode: ReporterModeWebhook})
+	assert.NotNil(t, err)
+	_, err = NewReporter(ReporterConf{Mode: "bad"})
+	assert.NotNil(t, err)
+}
+
+func TestAddReporter(t *testing.T) {
+	mocked := &mockedReporter{}
+	failing := &mockedReporter{err: errors.New("dummy")}
+	AddReporter(mocked)
+	AddReporter(failing)
+	defer func() {
+		reporterLock.Lock()
+		reporters = nil
+		reporterLock.Unlock()
+	}()
+
+	log(&StatReport{Name: "foo"})
+	assert.Equal(t, int32(1), atomic.LoadInt32(&mocked.count))
+	// the failing reporter doesn't block the others
+	assert.Equal(t, int32(1), atomic.LoadInt32(&failing.count))
+}
+
+func TestLogReporter(t *testing.T) {
+	assert.Nil(t, NewLogReporter().Report(&StatReport{Name: "foo"}))
+}
+
+func TestPrometheusReporter(t *testing.T) {
+	assert.Nil(t, NewPrometheusReporter().Report(&StatReport{
+		Name:          "foo",
+		ReqsPerSecond: 10,
+		Drops:         1,
+	}))
+}
+
+func TestWebhookReporter(t *testing.T) {
+	var report StatReport
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&report))
+	}))
+	defer server.Close()
+
+	reporter := NewWebhookReporter(server.URL)
+	assert.Nil(t, reporter.Report(&StatReport{Name: "foo"}))
+	assert.Equal(t, "foo", report.Name)
+}
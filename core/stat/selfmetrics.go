@@ -0,0 +1,98 @@
+package stat
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/metric"
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+const (
+	selfMetricsInterval = time.Second * 5
+	selfStatmFile       = "/proc/self/statm"
+)
+
+var (
+	selfMetricsOnce sync.Once
+
+	metricSelfCpuUsage metric.GaugeVec
+	metricSelfCpuPct   metric.GaugeVec
+	metricSelfRss      metric.GaugeVec
+	metricSelfAlloc    metric.GaugeVec
+	metricSelfRoutines metric.GaugeVec
+)
+
+// EnableSelfMetrics publishes the process self metrics as prometheus gauges,
+// including the smoothed cpu usage that drives load shedding, the cpu
+// percentage, the resident memory and the goroutine count,
+// so autoscaling and dashboards can consume them. Safe to call more than once.
+func EnableSelfMetrics() {
+	selfMetricsOnce.Do(startSelfMetrics)
+}
+
+func startSelfMetrics() {
+	newGauge := func(name, help string) metric.GaugeVec {
+		// the process namespace is taken by the client_golang default
+		// process collector, publish under stat_self instead
+		return metric.NewGaugeVec(&metric.GaugeVecOpts{
+			Namespace: statNamespace,
+			Subsystem: "self",
+			Name:      name,
+			Help:      help,
+		})
+	}
+	metricSelfCpuUsage = newGauge("cpu_usage",
+		"smoothed cpu usage in per mille of the quota, the load shedding input.")
+	metricSelfCpuPct = newGauge("cpu_percent", "smoothed cpu usage percentage.")
+	metricSelfRss = newGauge("resident_memory_bytes", "resident memory in bytes, linux only.")
+	metricSelfAlloc = newGauge("heap_alloc_bytes", "allocated heap objects in bytes.")
+	metricSelfRoutines = newGauge("goroutines", "number of goroutines.")
+
+	threading.GoSafe(func() {
+		ticker := time.NewTicker(selfMetricsInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			updateSelfMetrics()
+		}
+	})
+}
+
+func updateSelfMetrics() {
+	usage := CpuUsage()
+	metricSelfCpuUsage.Set(float64(usage))
+	metricSelfCpuPct.Set(float64(usage) / 10)
+	metricSelfRss.Set(float64(memoryRss()))
+
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	metricSelfAlloc.Set(float64(m.HeapAlloc))
+	metricSelfRoutines.Set(float64(runtime.NumGoroutine()))
+}
+
+// memoryRss returns the resident memory in bytes, 0 on the platforms
+// without procfs.
+func memoryRss() uint64 {
+	bs, err := os.ReadFile(selfStatmFile)
+	if err != nil {
+		return 0
+	}
+
+	// the second field of statm is the resident pages
+	fields := strings.Fields(string(bs))
+	if len(fields) < 2 {
+		return 0
+	}
+
+	pages, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return pages * uint64(os.Getpagesize())
+}
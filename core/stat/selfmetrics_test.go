@@ -0,0 +1,24 @@
+package stat
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnableSelfMetrics(t *testing.T) {
+	assert.NotPanics(t, func() {
+		EnableSelfMetrics()
+		// safe to call more than once
+		EnableSelfMetrics()
+		updateSelfMetrics()
+	})
+}
+
+func TestMemoryRss(t *testing.T) {
+	rss := memoryRss()
+	if runtime.GOOS == "linux" {
+		assert.True(t, rss > 0)
+	}
+}
@@ -7,4 +7,5 @@ type Task struct {
 	Drop        bool
 	Duration    time.Duration
 	Description string
+	TraceId     string
 }
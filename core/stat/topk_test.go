@@ -26,17 +26,17 @@ func init() {
 
 func TestTopK(t *testing.T) {
 	tasks := []Task{
-		{false, 1, "a"},
-		{false, 4, "a"},
-		{false, 2, "a"},
-		{false, 5, "a"},
-		{false, 9, "a"},
-		{false, 10, "a"},
-		{false, 12, "a"},
-		{false, 3, "a"},
-		{false, 6, "a"},
-		{false, 11, "a"},
-		{false, 8, "a"},
+		{false, 1, "a", ""},
+		{false, 4, "a", ""},
+		{false, 2, "a", ""},
+		{false, 5, "a", ""},
+		{false, 9, "a", ""},
+		{false, 10, "a", ""},
+		{false, 12, "a", ""},
+		{false, 3, "a", ""},
+		{false, 6, "a", ""},
+		{false, 11, "a", ""},
+		{false, 8, "a", ""},
 	}
 
 	result := topK(tasks, 3)
@@ -306,3 +306,12 @@ func (d *dropBreaker) DoWithFallbackAcceptable(req func() error, fallback func(e
 	acceptable breaker.Acceptable) error {
 	return nil
 }
+
+func (d *dropBreaker) ForceOpen() {
+}
+
+func (d *dropBreaker) ForceClose() {
+}
+
+func (d *dropBreaker) Reset() {
+}
@@ -0,0 +1,117 @@
+package syncx
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/timex"
+)
+
+type (
+	// CachedCalls is a SharedCalls that additionally memoizes the results.
+	// Concurrent calls with the same key share one execution, and the result
+	// is kept for the given ttl, so immediately-following calls don't
+	// re-execute expensive idempotent lookups. Errors are not memoized.
+	CachedCalls interface {
+		Do(key string, ttl time.Duration, fn func() (interface{}, error)) (interface{}, error)
+	}
+
+	// CachedCallsOption defines the method to customize CachedCalls.
+	CachedCallsOption func(calls *cachedGroup)
+
+	cachedResult struct {
+		val      interface{}
+		expireAt time.Duration
+	}
+
+	cachedGroup struct {
+		calls    SharedCalls
+		lock     sync.Mutex
+		results  map[string]cachedResult
+		staleTtl time.Duration
+	}
+)
+
+// NewCachedCalls returns a CachedCalls.
+func NewCachedCalls(opts ...CachedCallsOption) CachedCalls {
+	group := &cachedGroup{
+		calls:   NewSharedCalls(),
+		results: make(map[string]cachedResult),
+	}
+	for _, opt := range opts {
+		opt(group)
+	}
+
+	return group
+}
+
+// WithStaleWhileRevalidate lets an expired result be served for up to stale
+// beyond its ttl, while it's refreshed in the background, so the hot keys
+// don't block the callers on refreshes.
+func WithStaleWhileRevalidate(stale time.Duration) CachedCallsOption {
+	return func(calls *cachedGroup) {
+		if stale > 0 {
+			calls.staleTtl = stale
+		}
+	}
+}
+
+func (g *cachedGroup) Do(key string, ttl time.Duration, fn func() (interface{}, error)) (
+	interface{}, error) {
+	now := timex.Now()
+	g.lock.Lock()
+	result, ok := g.results[key]
+	if ok && now < result.expireAt {
+		g.lock.Unlock()
+		return result.val, nil
+	}
+	if ok && g.staleTtl > 0 && now < result.expireAt+g.staleTtl {
+		g.lock.Unlock()
+		// serve the stale value, refresh in background,
+		// the concurrent refreshes are deduplicated by calls
+		go func() {
+			// can't use threading.GoSafe, it imports syncx back
+			defer func() {
+				_ = recover()
+			}()
+			g.calls.Do(key, func() (interface{}, error) {
+				return g.fetch(key, ttl, fn)
+			})
+		}()
+		return result.val, nil
+	}
+	if ok {
+		// beyond the stale window, drop it
+		delete(g.results, key)
+	}
+	g.lock.Unlock()
+
+	return g.calls.Do(key, func() (interface{}, error) {
+		// double check, the result might be refreshed by a shared call
+		g.lock.Lock()
+		result, ok := g.results[key]
+		g.lock.Unlock()
+		if ok && timex.Now() < result.expireAt {
+			return result.val, nil
+		}
+
+		return g.fetch(key, ttl, fn)
+	})
+}
+
+func (g *cachedGroup) fetch(key string, ttl time.Duration, fn func() (interface{}, error)) (
+	interface{}, error) {
+	val, err := fn()
+	if err != nil {
+		return nil, err
+	}
+
+	g.lock.Lock()
+	g.results[key] = cachedResult{
+		val:      val,
+		expireAt: timex.Now() + ttl,
+	}
+	g.lock.Unlock()
+
+	return val, nil
+}
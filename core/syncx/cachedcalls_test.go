@@ -0,0 +1,118 @@
+package syncx
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedCallsMemoize(t *testing.T) {
+	calls := NewCachedCalls()
+	var count int32
+	fn := func() (interface{}, error) {
+		atomic.AddInt32(&count, 1)
+		return "result", nil
+	}
+
+	for i := 0; i < 10; i++ {
+		val, err := calls.Do("key", time.Minute, fn)
+		assert.Nil(t, err)
+		assert.Equal(t, "result", val)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+}
+
+func TestCachedCallsExpire(t *testing.T) {
+	calls := NewCachedCalls()
+	var count int32
+	fn := func() (interface{}, error) {
+		atomic.AddInt32(&count, 1)
+		return "result", nil
+	}
+
+	_, err := calls.Do("key", time.Millisecond*50, fn)
+	assert.Nil(t, err)
+	time.Sleep(time.Millisecond * 100)
+	_, err = calls.Do("key", time.Millisecond*50, fn)
+	assert.Nil(t, err)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&count))
+}
+
+func TestCachedCallsErrorNotMemoized(t *testing.T) {
+	calls := NewCachedCalls()
+	var count int32
+	errDummy := errors.New("dummy")
+	fn := func() (interface{}, error) {
+		atomic.AddInt32(&count, 1)
+		return nil, errDummy
+	}
+
+	_, err := calls.Do("key", time.Minute, fn)
+	assert.Equal(t, errDummy, err)
+	_, err = calls.Do("key", time.Minute, fn)
+	assert.Equal(t, errDummy, err)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&count))
+}
+
+func TestCachedCallsConcurrent(t *testing.T) {
+	calls := NewCachedCalls()
+	var count int32
+	fn := func() (interface{}, error) {
+		atomic.AddInt32(&count, 1)
+		time.Sleep(time.Millisecond * 100)
+		return "result", nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			val, err := calls.Do("key", time.Minute, fn)
+			assert.Nil(t, err)
+			assert.Equal(t, "result", val)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+}
+
+func TestCachedCallsStaleWhileRevalidate(t *testing.T) {
+	calls := NewCachedCalls(WithStaleWhileRevalidate(time.Minute))
+	var count int32
+	fn := func() (interface{}, error) {
+		return atomic.AddInt32(&count, 1), nil
+	}
+
+	val, err := calls.Do("key", time.Millisecond*50, fn)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), val)
+
+	time.Sleep(time.Millisecond * 100)
+
+	// expired but within the stale window, served stale and refreshed in background
+	val, err = calls.Do("key", time.Millisecond*50, fn)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), val)
+
+	// wait for the background refresh
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if atomic.LoadInt32(&count) == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	val, err = calls.Do("key", time.Millisecond*50, fn)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), val)
+}
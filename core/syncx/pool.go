@@ -23,14 +23,24 @@ type (
 	//  2. max age of the resources can be set
 	//  3. the method to destroy resources can be customized
 	Pool struct {
-		limit   int
-		created int
-		maxAge  time.Duration
-		lock    sync.Locker
-		cond    *sync.Cond
-		head    *node
-		create  func() interface{}
-		destroy func(interface{})
+		limit       int
+		created     int
+		maxAge      time.Duration
+		maxLifetime time.Duration
+		idleTimeout time.Duration
+		lock        sync.Locker
+		cond        *sync.Cond
+		head        *node
+		create      func() interface{}
+		destroy     func(interface{})
+		birth       map[interface{}]time.Duration
+	}
+
+	// PoolStats is a snapshot of a Pool.
+	PoolStats struct {
+		Created int
+		Idle    int
+		InUse   int
 	}
 )
 
@@ -53,6 +63,13 @@ func NewPool(n int, create func() interface{}, destroy func(interface{}), opts .
 		opt(pool)
 	}
 
+	if pool.maxLifetime > 0 {
+		pool.birth = make(map[interface{}]time.Duration)
+	}
+	if pool.idleTimeout > 0 {
+		go pool.shrinkLoop()
+	}
+
 	return pool
 }
 
@@ -65,9 +82,12 @@ func (p *Pool) Get() interface{} {
 		if p.head != nil {
 			head := p.head
 			p.head = head.next
-			if p.maxAge > 0 && head.lastUsed+p.maxAge < timex.Now() {
+			if p.deadLocked(head, timex.Now()) {
 				p.created--
 				p.destroy(head.item)
+				if p.birth != nil {
+					delete(p.birth, head.item)
+				}
 				continue
 			} else {
 				return head.item
@@ -76,7 +96,11 @@ func (p *Pool) Get() interface{} {
 
 		if p.created < p.limit {
 			p.created++
-			return p.create()
+			item := p.create()
+			if p.birth != nil {
+				p.birth[item] = timex.Now()
+			}
+			return item
 		}
 
 		p.cond.Wait()
@@ -100,9 +124,107 @@ func (p *Pool) Put(x interface{}) {
 	p.cond.Signal()
 }
 
+// Shrink destroys the idle resources that outlived the idle timeout
+// or the max lifetime, it's called periodically when WithIdleTimeout
+// is set, the callers can call it on demand as well.
+func (p *Pool) Shrink() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := timex.Now()
+	var destroyed int
+	var prev *node
+	for cur := p.head; cur != nil; {
+		next := cur.next
+		if p.deadLocked(cur, now) {
+			if prev == nil {
+				p.head = next
+			} else {
+				prev.next = next
+			}
+			p.created--
+			destroyed++
+			p.destroy(cur.item)
+			if p.birth != nil {
+				delete(p.birth, cur.item)
+			}
+		} else {
+			prev = cur
+		}
+		cur = next
+	}
+
+	if destroyed > 0 {
+		// let the waiters create new resources
+		p.cond.Broadcast()
+	}
+}
+
+// Stats returns a snapshot of p, the callers can export the numbers as gauges.
+func (p *Pool) Stats() PoolStats {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var idle int
+	for cur := p.head; cur != nil; cur = cur.next {
+		idle++
+	}
+
+	return PoolStats{
+		Created: p.created,
+		Idle:    idle,
+		InUse:   p.created - idle,
+	}
+}
+
+// deadLocked checks if the resource held by n should be destroyed,
+// the callers must hold p.lock.
+func (p *Pool) deadLocked(n *node, now time.Duration) bool {
+	if p.maxAge > 0 && n.lastUsed+p.maxAge < now {
+		return true
+	}
+	if p.idleTimeout > 0 && n.lastUsed+p.idleTimeout < now {
+		return true
+	}
+	if p.birth != nil {
+		if birth, ok := p.birth[n.item]; ok && birth+p.maxLifetime < now {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Pool) shrinkLoop() {
+	ticker := time.NewTicker(p.idleTimeout)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		p.Shrink()
+	}
+}
+
+// WithIdleTimeout returns a function to customize a Pool to destroy
+// the resources idle longer than duration, so the pool doesn't stay
+// inflated after a spike.
+func WithIdleTimeout(duration time.Duration) PoolOption {
+	return func(pool *Pool) {
+		pool.idleTimeout = duration
+	}
+}
+
 // WithMaxAge returns a function to customize a Pool with given max age.
 func WithMaxAge(duration time.Duration) PoolOption {
 	return func(pool *Pool) {
 		pool.maxAge = duration
 	}
 }
+
+// WithMaxLifetime returns a function to customize a Pool to destroy
+// the resources older than duration no matter how recently used,
+// the pooled resources must be comparable, like pointers.
+func WithMaxLifetime(duration time.Duration) PoolOption {
+	return func(pool *Pool) {
+		pool.maxLifetime = duration
+	}
+}
@@ -96,6 +96,57 @@ func TestPoolWithMaxAge(t *testing.T) {
 	assert.NotEqual(t, v1, v2)
 }
 
+func TestPoolWithMaxLifetime(t *testing.T) {
+	var value int32
+	stack := NewPool(limit, func() interface{} {
+		return atomic.AddInt32(&value, 1)
+	}, destroy, WithMaxLifetime(time.Millisecond*10))
+
+	v1 := stack.Get().(int32)
+	stack.Put(v1)
+	time.Sleep(time.Millisecond * 50)
+	v2 := stack.Get().(int32)
+	assert.NotEqual(t, v1, v2)
+}
+
+func TestPoolShrink(t *testing.T) {
+	var value, destroyed int32
+	stack := NewPool(limit, func() interface{} {
+		return atomic.AddInt32(&value, 1)
+	}, func(_ interface{}) {
+		atomic.AddInt32(&destroyed, 1)
+	}, WithIdleTimeout(time.Millisecond*50))
+
+	var items []interface{}
+	for i := 0; i < 3; i++ {
+		items = append(items, stack.Get())
+	}
+	for _, item := range items {
+		stack.Put(item)
+	}
+	assert.Equal(t, PoolStats{Created: 3, Idle: 3}, stack.Stats())
+
+	// wait for the background shrink to kick in
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if atomic.LoadInt32(&destroyed) == 3 {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&destroyed))
+	assert.Equal(t, PoolStats{}, stack.Stats())
+}
+
+func TestPoolStats(t *testing.T) {
+	stack := NewPool(limit, create, destroy)
+	v := stack.Get()
+	assert.Equal(t, PoolStats{Created: 1, InUse: 1}, stack.Stats())
+	stack.Put(v)
+	assert.Equal(t, PoolStats{Created: 1, Idle: 1}, stack.Stats())
+}
+
 func TestNewPoolPanics(t *testing.T) {
 	assert.Panics(t, func() {
 		NewPool(0, create, destroy)
@@ -0,0 +1,122 @@
+package threading
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/core/logx"
+)
+
+type (
+	// ErrorGroupOption defines the method to customize an ErrorGroup.
+	ErrorGroupOption func(group *ErrorGroup)
+
+	// An ErrorGroup is a RoutineGroup variant that collects the errors from
+	// its goroutines, optionally caps the concurrency, and cancels the
+	// sibling goroutines through a context once one of them fails.
+	ErrorGroup struct {
+		waitGroup sync.WaitGroup
+		cancel    context.CancelFunc
+		tokens    chan lang.PlaceholderType
+		lock      sync.Mutex
+		errs      []error
+	}
+)
+
+// NewErrorGroup returns an ErrorGroup.
+func NewErrorGroup(opts ...ErrorGroupOption) *ErrorGroup {
+	group := new(ErrorGroup)
+	for _, opt := range opts {
+		opt(group)
+	}
+
+	return group
+}
+
+// NewErrorGroupWithContext returns an ErrorGroup and a context derived from ctx,
+// the context is canceled when a goroutine returns an error or panics,
+// so the sibling goroutines can give up early.
+func NewErrorGroupWithContext(ctx context.Context, opts ...ErrorGroupOption) (
+	*ErrorGroup, context.Context) {
+	group := NewErrorGroup(opts...)
+	ctx, group.cancel = context.WithCancel(ctx)
+	return group, ctx
+}
+
+// WithLimit caps the concurrently running goroutines at n,
+// RunChecked blocks when the cap is reached.
+func WithLimit(n int) ErrorGroupOption {
+	return func(group *ErrorGroup) {
+		if n > 0 {
+			group.tokens = make(chan lang.PlaceholderType, n)
+		}
+	}
+}
+
+// RunChecked runs the given fn in ErrorGroup, the returned errors are
+// collected and reported by Wait. The panics are logged and converted
+// into errors instead of crashing the process.
+// Don't reference the variables from outside,
+// because outside variables can be changed by other goroutines
+func (g *ErrorGroup) RunChecked(fn func() error) {
+	if g.tokens != nil {
+		g.tokens <- lang.Placeholder
+	}
+	g.waitGroup.Add(1)
+
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				logx.ErrorStack(p)
+				g.record(fmt.Errorf("panic: %v", p))
+			}
+			if g.tokens != nil {
+				<-g.tokens
+			}
+			g.waitGroup.Done()
+		}()
+
+		if err := fn(); err != nil {
+			g.record(err)
+		}
+	}()
+}
+
+// Wait waits all running functions to be done,
+// and returns the first error collected from them.
+func (g *ErrorGroup) Wait() error {
+	g.waitGroup.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if len(g.errs) > 0 {
+		return g.errs[0]
+	}
+
+	return nil
+}
+
+// Errors returns all the errors collected from the finished functions,
+// only call it after Wait.
+func (g *ErrorGroup) Errors() []error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	return append([]error(nil), g.errs...)
+}
+
+func (g *ErrorGroup) record(err error) {
+	g.lock.Lock()
+	g.errs = append(g.errs, err)
+	g.lock.Unlock()
+
+	if g.cancel != nil {
+		g.cancel()
+	}
+}
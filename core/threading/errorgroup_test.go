@@ -0,0 +1,97 @@
+package threading
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/logx"
+)
+
+func TestErrorGroupNoErrors(t *testing.T) {
+	var count int32
+	group := NewErrorGroup()
+	for i := 0; i < 10; i++ {
+		group.RunChecked(func() error {
+			atomic.AddInt32(&count, 1)
+			return nil
+		})
+	}
+
+	assert.Nil(t, group.Wait())
+	assert.Empty(t, group.Errors())
+	assert.Equal(t, int32(10), atomic.LoadInt32(&count))
+}
+
+func TestErrorGroupCollectsErrors(t *testing.T) {
+	group := NewErrorGroup()
+	for i := 0; i < 3; i++ {
+		group.RunChecked(func() error {
+			return errors.New("dummy")
+		})
+	}
+	group.RunChecked(func() error {
+		return nil
+	})
+
+	assert.NotNil(t, group.Wait())
+	assert.Equal(t, 3, len(group.Errors()))
+}
+
+func TestErrorGroupRecoversPanic(t *testing.T) {
+	logx.Disable()
+
+	group := NewErrorGroup()
+	group.RunChecked(func() error {
+		panic("mocked panic")
+	})
+
+	err := group.Wait()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "mocked panic")
+}
+
+func TestErrorGroupWithLimit(t *testing.T) {
+	const limit = 3
+	var current, peak int32
+	group := NewErrorGroup(WithLimit(limit))
+	for i := 0; i < 10; i++ {
+		group.RunChecked(func() error {
+			cur := atomic.AddInt32(&current, 1)
+			for {
+				prev := atomic.LoadInt32(&peak)
+				if cur <= prev || atomic.CompareAndSwapInt32(&peak, prev, cur) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond * 10)
+			atomic.AddInt32(&current, -1)
+			return nil
+		})
+	}
+
+	assert.Nil(t, group.Wait())
+	assert.True(t, atomic.LoadInt32(&peak) <= limit)
+}
+
+func TestErrorGroupCancelsSiblings(t *testing.T) {
+	group, ctx := NewErrorGroupWithContext(context.Background())
+	group.RunChecked(func() error {
+		return errors.New("dummy")
+	})
+	group.RunChecked(func() error {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Second):
+			return errors.New("not canceled")
+		}
+	})
+
+	assert.NotNil(t, group.Wait())
+	assert.Equal(t, 1, len(group.Errors()))
+	assert.NotNil(t, ctx.Err())
+}
@@ -0,0 +1,92 @@
+package timextest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/collection"
+)
+
+func TestFakeClockTicker(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	select {
+	case <-ticker.Chan():
+		t.Fatal("ticked before advance")
+	default:
+	}
+
+	clock.Advance(time.Second)
+	select {
+	case <-ticker.Chan():
+	default:
+		t.Fatal("no tick after advance")
+	}
+}
+
+func TestFakeClockCoalesce(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	// nobody is receiving, the ticks coalesce like time.Ticker
+	clock.Advance(time.Second * 3)
+	var count int
+	for {
+		select {
+		case <-ticker.Chan():
+			count++
+			continue
+		default:
+		}
+		break
+	}
+
+	assert.Equal(t, 1, count)
+}
+
+func TestFakeClockStop(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	ticker := clock.NewTicker(time.Second)
+	ticker.Stop()
+
+	clock.Advance(time.Second * 2)
+	select {
+	case <-ticker.Chan():
+		t.Fatal("ticked after stop")
+	default:
+	}
+}
+
+func TestFakeClockNow(t *testing.T) {
+	start := time.Now()
+	clock := NewFakeClock(start)
+	clock.Advance(time.Minute)
+	assert.Equal(t, start.Add(time.Minute), clock.Now())
+}
+
+func TestFakeClockDrivesTimingWheel(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	done := make(chan struct{})
+	tw, err := collection.NewTimingWheelWithTicker(time.Second, 10, func(k, v interface{}) {
+		close(done)
+	}, clock.NewTicker(time.Second))
+	assert.Nil(t, err)
+	defer tw.Stop()
+
+	tw.SetTimer("key", "value", time.Second*3)
+	for i := 0; i < 5; i++ {
+		clock.Advance(time.Second)
+		// let the wheel consume the tick before the next one coalesces
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timer not fired")
+	}
+}
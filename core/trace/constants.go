@@ -3,4 +3,14 @@ package trace
 const (
 	traceIdKey = "X-Trace-ID"
 	spanIdKey  = "X-Span-ID"
+	sampledKey = "X-Trace-Sampled"
+
+	// the w3c trace context headers, see
+	// https://www.w3.org/TR/trace-context/
+	traceparentKey = "traceparent"
+	tracestateKey  = "tracestate"
+
+	// the zipkin b3 headers
+	b3TraceIdKey = "X-B3-TraceId"
+	b3SpanIdKey  = "X-B3-SpanId"
 )
@@ -0,0 +1,299 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/proc"
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+// The exporters that can be set in TelemetryConf. Jaeger is served through
+// its zipkin-compatible collector endpoint, both speak the zipkin v2 json.
+const (
+	ExporterNone   = "none"
+	ExporterLog    = "log"
+	ExporterZipkin = "zipkin"
+	ExporterJaeger = "jaeger"
+)
+
+const (
+	defaultBatchSize     = 512
+	defaultQueueSize     = 2048
+	defaultFlushInterval = time.Second * 5
+	exportTimeout        = time.Second * 5
+)
+
+var (
+	processorLock sync.Mutex
+	processor     *spanProcessor
+)
+
+type (
+	// A SpanRecord is a finished span handed to the exporters.
+	SpanRecord struct {
+		TraceId   string        `json:"traceId"`
+		SpanId    string        `json:"spanId"`
+		Service   string        `json:"service"`
+		Operation string        `json:"operation"`
+		Kind      string        `json:"kind"`
+		Start     time.Time     `json:"start"`
+		Duration  time.Duration `json:"duration"`
+	}
+
+	// A SpanExporter delivers the finished spans to a backend.
+	SpanExporter interface {
+		Export(spans []SpanRecord) error
+	}
+
+	// A TelemetryConf configures where and how the spans are exported.
+	TelemetryConf struct {
+		Exporter string `json:",default=none,options=none|log|zipkin|jaeger"`
+		// Endpoint is the collector url, like http://localhost:9411/api/v2/spans,
+		// required by the zipkin and jaeger exporters.
+		Endpoint      string        `json:",optional"`
+		BatchSize     int           `json:",default=512"`
+		QueueSize     int           `json:",default=2048"`
+		FlushInterval time.Duration `json:",default=5s"`
+	}
+
+	spanProcessor struct {
+		exporter  SpanExporter
+		queue     chan SpanRecord
+		batchSize int
+		interval  time.Duration
+		flushChan chan chan lang.PlaceholderType
+	}
+
+	logExporter    struct{}
+	zipkinExporter struct {
+		endpoint string
+	}
+
+	zipkinSpan struct {
+		TraceId       string         `json:"traceId"`
+		Id            string         `json:"id"`
+		Name          string         `json:"name"`
+		Kind          string         `json:"kind"`
+		Timestamp     int64          `json:"timestamp"`
+		Duration      int64          `json:"duration"`
+		LocalEndpoint zipkinEndpoint `json:"localEndpoint"`
+	}
+
+	zipkinEndpoint struct {
+		ServiceName string `json:"serviceName"`
+	}
+)
+
+// StartSpanExporter starts exporting the finished sampled spans as the
+// given config says, the spans are batched and flushed on graceful shutdown.
+func StartSpanExporter(c TelemetryConf) error {
+	exporter, err := newExporter(c)
+	if err != nil {
+		return err
+	}
+	if exporter == nil {
+		return nil
+	}
+
+	SetSpanExporter(exporter, c)
+	return nil
+}
+
+// SetSpanExporter sets the exporter that receives the finished sampled spans.
+func SetSpanExporter(exporter SpanExporter, c TelemetryConf) {
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaultBatchSize
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = defaultQueueSize
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = defaultFlushInterval
+	}
+
+	p := &spanProcessor{
+		exporter:  exporter,
+		queue:     make(chan SpanRecord, c.QueueSize),
+		batchSize: c.BatchSize,
+		interval:  c.FlushInterval,
+		flushChan: make(chan chan lang.PlaceholderType),
+	}
+	threading.GoSafe(p.run)
+	proc.AddShutdownListener(func() {
+		p.flush(exportTimeout)
+	})
+
+	processorLock.Lock()
+	processor = p
+	processorLock.Unlock()
+}
+
+func newExporter(c TelemetryConf) (SpanExporter, error) {
+	switch c.Exporter {
+	case ExporterNone, "":
+		return nil, nil
+	case ExporterLog:
+		return logExporter{}, nil
+	case ExporterZipkin, ExporterJaeger:
+		if len(c.Endpoint) == 0 {
+			return nil, fmt.Errorf("exporter %q requires endpoint", c.Exporter)
+		}
+		return zipkinExporter{endpoint: c.Endpoint}, nil
+	default:
+		return nil, fmt.Errorf("unknown exporter %q", c.Exporter)
+	}
+}
+
+// exportSpan enqueues the record, the spans are dropped when the queue
+// is full, exporting must not block the request path.
+func exportSpan(record SpanRecord) {
+	processorLock.Lock()
+	p := processor
+	processorLock.Unlock()
+	if p == nil {
+		return
+	}
+
+	select {
+	case p.queue <- record:
+	default:
+	}
+}
+
+func (p *spanProcessor) export(batch []SpanRecord) {
+	if len(batch) == 0 {
+		return
+	}
+
+	if err := p.exporter.Export(batch); err != nil {
+		logx.Errorf("export %d spans failed: %v", len(batch), err)
+	}
+}
+
+// flush drains the queue and exports the pending spans,
+// it gives up after timeout.
+func (p *spanProcessor) flush(timeout time.Duration) {
+	ack := make(chan lang.PlaceholderType)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case p.flushChan <- ack:
+	case <-timer.C:
+		return
+	}
+
+	select {
+	case <-ack:
+	case <-timer.C:
+	}
+}
+
+func (p *spanProcessor) run() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	var batch []SpanRecord
+	for {
+		select {
+		case record := <-p.queue:
+			batch = append(batch, record)
+			if len(batch) >= p.batchSize {
+				p.export(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			p.export(batch)
+			batch = nil
+		case ack := <-p.flushChan:
+			batch = append(batch, p.drain()...)
+			p.export(batch)
+			batch = nil
+			close(ack)
+		}
+	}
+}
+
+func (p *spanProcessor) drain() []SpanRecord {
+	var records []SpanRecord
+	for {
+		select {
+		case record := <-p.queue:
+			records = append(records, record)
+		default:
+			return records
+		}
+	}
+}
+
+func (e logExporter) Export(spans []SpanRecord) error {
+	for _, span := range spans {
+		logx.Infof("span %s %s %s/%s duration: %v",
+			span.TraceId, span.SpanId, span.Service, span.Operation, span.Duration)
+	}
+
+	return nil
+}
+
+func (e zipkinExporter) Export(spans []SpanRecord) error {
+	zipkinSpans := make([]zipkinSpan, 0, len(spans))
+	for _, span := range spans {
+		zipkinSpans = append(zipkinSpans, toZipkinSpan(span))
+	}
+
+	bs, err := json.Marshal(zipkinSpans)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: exportTimeout,
+	}
+	resp, err := client.Post(e.endpoint, "application/json", bytes.NewReader(bs))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("export spans failed, code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func toZipkinSpan(span SpanRecord) zipkinSpan {
+	// zipkin requires hex ids, reuse the w3c representation
+	traceId := span.TraceId
+	switch {
+	case isHex(traceId, w3cTraceIdLen):
+	case isHex(traceId, w3cTraceIdLen/2):
+		traceId = strings.Repeat("0", w3cTraceIdLen/2) + traceId
+	default:
+		traceId = strings.Repeat("0", w3cTraceIdLen/2) + hashSpanId(traceId)
+	}
+	spanId := span.SpanId
+	if !isHex(spanId, w3cSpanIdLen) {
+		spanId = hashSpanId(spanId)
+	}
+
+	return zipkinSpan{
+		TraceId:   traceId,
+		Id:        spanId,
+		Name:      span.Operation,
+		Kind:      strings.ToUpper(span.Kind),
+		Timestamp: span.Start.UnixNano() / int64(time.Microsecond),
+		Duration:  int64(span.Duration / time.Microsecond),
+		LocalEndpoint: zipkinEndpoint{
+			ServiceName: span.Service,
+		},
+	}
+}
@@ -0,0 +1,145 @@
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockedExporter struct {
+	lock  sync.Mutex
+	spans []SpanRecord
+}
+
+func (e *mockedExporter) Export(spans []SpanRecord) error {
+	e.lock.Lock()
+	e.spans = append(e.spans, spans...)
+	e.lock.Unlock()
+	return nil
+}
+
+func (e *mockedExporter) count() int {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return len(e.spans)
+}
+
+func resetSpanExporter() {
+	processorLock.Lock()
+	processor = nil
+	processorLock.Unlock()
+}
+
+func TestNewExporter(t *testing.T) {
+	exporter, err := newExporter(TelemetryConf{})
+	assert.Nil(t, err)
+	assert.Nil(t, exporter)
+
+	exporter, err = newExporter(TelemetryConf{Exporter: ExporterLog})
+	assert.Nil(t, err)
+	assert.NotNil(t, exporter)
+
+	exporter, err = newExporter(TelemetryConf{
+		Exporter: ExporterZipkin,
+		Endpoint: "http://localhost:9411/api/v2/spans",
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, exporter)
+
+	_, err = newExporter(TelemetryConf{Exporter: ExporterJaeger})
+	assert.NotNil(t, err)
+	_, err = newExporter(TelemetryConf{Exporter: "bad"})
+	assert.NotNil(t, err)
+}
+
+func TestSpanExportOnFinish(t *testing.T) {
+	exporter := &mockedExporter{}
+	SetSpanExporter(exporter, TelemetryConf{})
+	defer resetSpanExporter()
+
+	_, span := StartServerSpan(context.Background(), nil, "service", "operation")
+	span.Finish()
+
+	processorLock.Lock()
+	p := processor
+	processorLock.Unlock()
+	p.flush(time.Second)
+
+	assert.Equal(t, 1, exporter.count())
+	assert.Equal(t, "operation", exporter.spans[0].Operation)
+	assert.Equal(t, serverFlag, exporter.spans[0].Kind)
+}
+
+func TestSpanExportBatching(t *testing.T) {
+	exporter := &mockedExporter{}
+	SetSpanExporter(exporter, TelemetryConf{
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+	})
+	defer resetSpanExporter()
+
+	for i := 0; i < 4; i++ {
+		exportSpan(SpanRecord{TraceId: "a", SpanId: "0"})
+	}
+
+	for i := 0; i < 100; i++ {
+		if exporter.count() >= 4 {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	assert.Equal(t, 4, exporter.count())
+}
+
+func TestUnsampledSpanNotExported(t *testing.T) {
+	exporter := &mockedExporter{}
+	SetSpanExporter(exporter, TelemetryConf{})
+	defer resetSpanExporter()
+
+	SetSampler(NewNeverSampler())
+	defer SetSampler(NewAlwaysSampler())
+
+	_, span := StartServerSpan(context.Background(), nil, "service", "operation")
+	span.Finish()
+
+	processorLock.Lock()
+	p := processor
+	processorLock.Unlock()
+	p.flush(time.Second)
+
+	assert.Equal(t, 0, exporter.count())
+}
+
+func TestZipkinExporter(t *testing.T) {
+	var spans []zipkinSpan
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&spans))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	exporter := zipkinExporter{endpoint: server.URL}
+	err := exporter.Export([]SpanRecord{
+		{
+			TraceId:   "0af7651916cd43dd8448eb211c80319c",
+			SpanId:    "0.1",
+			Service:   "service",
+			Operation: "operation",
+			Kind:      serverFlag,
+			Start:     time.Now(),
+			Duration:  time.Millisecond * 5,
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(spans))
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", spans[0].TraceId)
+	assert.Equal(t, hashSpanId("0.1"), spans[0].Id)
+	assert.Equal(t, "SERVER", spans[0].Kind)
+	assert.Equal(t, "service", spans[0].LocalEndpoint.ServiceName)
+}
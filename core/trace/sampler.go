@@ -0,0 +1,164 @@
+package trace
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/mathx"
+)
+
+// The sampler modes that can be set in SamplerConf.
+const (
+	SamplerModeAlways    = "always"
+	SamplerModeNever     = "never"
+	SamplerModeRatio     = "ratio"
+	SamplerModeRateLimit = "ratelimit"
+	SamplerModeParent    = "parent"
+)
+
+var (
+	samplerLock   sync.RWMutex
+	globalSampler Sampler = alwaysSampler{}
+)
+
+type (
+	// A Sampler decides whether a new server span should be sampled.
+	Sampler interface {
+		// Sample returns the decision, hasParent and parentSampled
+		// describe the decision of the upstream service.
+		Sample(hasParent, parentSampled bool) bool
+	}
+
+	// A SamplerConf is the config of the trace sampler.
+	SamplerConf struct {
+		Mode string `json:",default=always,options=always|never|ratio|ratelimit|parent"`
+		// Ratio is the probability to sample, used in ratio and parent modes.
+		Ratio float64 `json:",default=1"`
+		// RatePerSecond caps the sampled traces, only used in ratelimit mode.
+		RatePerSecond int64 `json:",default=100"`
+	}
+
+	alwaysSampler struct{}
+	neverSampler  struct{}
+
+	ratioSampler struct {
+		proba *mathx.Proba
+		ratio float64
+	}
+
+	rateLimitSampler struct {
+		perSecond int64
+		lock      sync.Mutex
+		second    int64
+		count     int64
+	}
+
+	parentSampler struct {
+		root Sampler
+	}
+)
+
+// NewSampler returns a Sampler built from the given config.
+func NewSampler(c SamplerConf) (Sampler, error) {
+	switch c.Mode {
+	case SamplerModeAlways, "":
+		return NewAlwaysSampler(), nil
+	case SamplerModeNever:
+		return NewNeverSampler(), nil
+	case SamplerModeRatio:
+		return NewRatioSampler(c.Ratio), nil
+	case SamplerModeRateLimit:
+		return NewRateLimitSampler(c.RatePerSecond), nil
+	case SamplerModeParent:
+		return NewParentSampler(NewRatioSampler(c.Ratio)), nil
+	default:
+		return nil, fmt.Errorf("unknown sampler mode %q", c.Mode)
+	}
+}
+
+// NewAlwaysSampler returns a Sampler that samples every trace.
+func NewAlwaysSampler() Sampler {
+	return alwaysSampler{}
+}
+
+// NewNeverSampler returns a Sampler that samples no traces.
+func NewNeverSampler() Sampler {
+	return neverSampler{}
+}
+
+// NewRatioSampler returns a Sampler that samples the given ratio of the traces.
+func NewRatioSampler(ratio float64) Sampler {
+	return ratioSampler{
+		proba: mathx.NewProba(),
+		ratio: ratio,
+	}
+}
+
+// NewRateLimitSampler returns a Sampler that samples up to perSecond traces per second.
+func NewRateLimitSampler(perSecond int64) Sampler {
+	return &rateLimitSampler{
+		perSecond: perSecond,
+	}
+}
+
+// NewParentSampler returns a Sampler that honors the upstream decision,
+// and delegates to root for the traces started by this service.
+func NewParentSampler(root Sampler) Sampler {
+	return parentSampler{
+		root: root,
+	}
+}
+
+// SetSampler sets the sampler applied to the new server spans.
+func SetSampler(sampler Sampler) {
+	if sampler == nil {
+		return
+	}
+
+	samplerLock.Lock()
+	globalSampler = sampler
+	samplerLock.Unlock()
+}
+
+func getSampler() Sampler {
+	samplerLock.RLock()
+	defer samplerLock.RUnlock()
+
+	return globalSampler
+}
+
+func (s alwaysSampler) Sample(_, _ bool) bool {
+	return true
+}
+
+func (s neverSampler) Sample(_, _ bool) bool {
+	return false
+}
+
+func (s ratioSampler) Sample(_, _ bool) bool {
+	return s.proba.TrueOnProba(s.ratio)
+}
+
+func (s *rateLimitSampler) Sample(_, _ bool) bool {
+	now := time.Now().Unix()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if now != s.second {
+		s.second = now
+		s.count = 0
+	}
+	s.count++
+
+	return s.count <= s.perSecond
+}
+
+func (s parentSampler) Sample(hasParent, parentSampled bool) bool {
+	if hasParent {
+		return parentSampled
+	}
+
+	return s.root.Sample(hasParent, parentSampled)
+}
@@ -0,0 +1,78 @@
+package trace
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSamplerModes(t *testing.T) {
+	modes := []string{
+		"",
+		SamplerModeAlways,
+		SamplerModeNever,
+		SamplerModeRatio,
+		SamplerModeRateLimit,
+		SamplerModeParent,
+	}
+	for _, mode := range modes {
+		sampler, err := NewSampler(SamplerConf{Mode: mode})
+		assert.Nil(t, err, mode)
+		assert.NotNil(t, sampler, mode)
+	}
+
+	_, err := NewSampler(SamplerConf{Mode: "bad"})
+	assert.NotNil(t, err)
+}
+
+func TestRatioSampler(t *testing.T) {
+	assert.False(t, NewRatioSampler(0).Sample(false, false))
+	assert.True(t, NewRatioSampler(1).Sample(false, false))
+}
+
+func TestRateLimitSampler(t *testing.T) {
+	sampler := NewRateLimitSampler(2)
+	var sampled int
+	for i := 0; i < 10; i++ {
+		if sampler.Sample(false, false) {
+			sampled++
+		}
+	}
+	assert.Equal(t, 2, sampled)
+}
+
+func TestParentSampler(t *testing.T) {
+	sampler := NewParentSampler(NewNeverSampler())
+	assert.True(t, sampler.Sample(true, true))
+	assert.False(t, sampler.Sample(true, false))
+	assert.False(t, sampler.Sample(false, false))
+}
+
+func TestSamplerDecisionPropagated(t *testing.T) {
+	SetSampler(NewNeverSampler())
+	defer SetSampler(NewAlwaysSampler())
+
+	_, span := StartServerSpan(context.Background(), nil, "service", "operation")
+	s, ok := span.(*Span)
+	assert.True(t, ok)
+	assert.False(t, s.Sampled())
+
+	header := http.Header{}
+	span.Visit(func(key, val string) bool {
+		header.Set(key, val)
+		return true
+	})
+	assert.Equal(t, "0", header.Get(sampledKey))
+
+	// the downstream honors the decision in parent mode, even though
+	// its own root sampler would sample everything
+	SetSampler(NewParentSampler(NewAlwaysSampler()))
+	carrier, err := Extract(HttpFormat, header)
+	assert.Nil(t, err)
+	_, downstream := StartServerSpan(context.Background(), carrier, "service", "operation")
+	ds, ok := downstream.(*Span)
+	assert.True(t, ok)
+	assert.False(t, ds.Sampled())
+}
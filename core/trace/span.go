@@ -32,25 +32,62 @@ type Span struct {
 }
 
 func newServerSpan(carrier Carrier, serviceName, operationName string) tracespec.Trace {
+	var w3cTraceId, w3cSpanId string
+	var tracestate string
+	var hasParent, parentSampled bool
+	if carrier != nil {
+		// interoperate with the proxies and frameworks that speak
+		// w3c trace context, the legacy headers take priority
+		if tid, sid, sampled, ok := parseTraceparent(carrier.Get(traceparentKey)); ok {
+			w3cTraceId = tid
+			w3cSpanId = sid
+			tracestate = carrier.Get(tracestateKey)
+			hasParent = true
+			parentSampled = sampled
+		} else if tid := carrier.Get(b3TraceIdKey); isHex(tid, w3cTraceIdLen) ||
+			isHex(tid, w3cTraceIdLen/2) {
+			w3cTraceId = tid
+			w3cSpanId = carrier.Get(b3SpanIdKey)
+			hasParent = true
+			parentSampled = true
+		}
+
+		// a legacy upstream without the sampled header implies sampled
+		if len(carrier.Get(traceIdKey)) > 0 {
+			hasParent = true
+			parentSampled = true
+		}
+		if decision := carrier.Get(sampledKey); len(decision) > 0 {
+			hasParent = true
+			parentSampled = decision != "0"
+		}
+	}
+
 	traceId := stringx.TakeWithPriority(func() string {
 		if carrier != nil {
 			return carrier.Get(traceIdKey)
 		}
 		return ""
+	}, func() string {
+		return w3cTraceId
 	}, stringx.RandId)
 	spanId := stringx.TakeWithPriority(func() string {
 		if carrier != nil {
 			return carrier.Get(spanIdKey)
 		}
 		return ""
+	}, func() string {
+		return w3cSpanId
 	}, func() string {
 		return initSpanId
 	})
 
 	return &Span{
 		ctx: spanContext{
-			traceId: traceId,
-			spanId:  spanId,
+			traceId:    traceId,
+			spanId:     spanId,
+			tracestate: tracestate,
+			sampled:    getSampler().Sample(hasParent, parentSampled),
 		},
 		serviceName:   serviceName,
 		operationName: operationName,
@@ -59,16 +96,32 @@ func newServerSpan(carrier Carrier, serviceName, operationName string) tracespec
 	}
 }
 
-// Finish finishes the calling span.
+// Finish finishes the calling span, the sampled spans are handed to the
+// configured exporter.
 func (s *Span) Finish() {
+	if !s.ctx.sampled {
+		return
+	}
+
+	exportSpan(SpanRecord{
+		TraceId:   s.ctx.traceId,
+		SpanId:    s.ctx.spanId,
+		Service:   s.serviceName,
+		Operation: s.operationName,
+		Kind:      s.flag,
+		Start:     s.startTime,
+		Duration:  time.Since(s.startTime),
+	})
 }
 
 // Follow follows the tracing service and operation names in context.
 func (s *Span) Follow(ctx context.Context, serviceName, operationName string) (context.Context, tracespec.Trace) {
 	span := &Span{
 		ctx: spanContext{
-			traceId: s.ctx.traceId,
-			spanId:  s.followSpanId(),
+			traceId:    s.ctx.traceId,
+			spanId:     s.followSpanId(),
+			tracestate: s.ctx.tracestate,
+			sampled:    s.ctx.sampled,
 		},
 		serviceName:   serviceName,
 		operationName: operationName,
@@ -82,8 +135,10 @@ func (s *Span) Follow(ctx context.Context, serviceName, operationName string) (c
 func (s *Span) Fork(ctx context.Context, serviceName, operationName string) (context.Context, tracespec.Trace) {
 	span := &Span{
 		ctx: spanContext{
-			traceId: s.ctx.traceId,
-			spanId:  s.forkSpanId(),
+			traceId:    s.ctx.traceId,
+			spanId:     s.forkSpanId(),
+			tracestate: s.ctx.tracestate,
+			sampled:    s.ctx.sampled,
 		},
 		serviceName:   serviceName,
 		operationName: operationName,
@@ -93,6 +148,11 @@ func (s *Span) Fork(ctx context.Context, serviceName, operationName string) (con
 	return context.WithValue(ctx, tracespec.TracingKey, span), span
 }
 
+// Sampled returns whether the span is sampled.
+func (s *Span) Sampled() bool {
+	return s.ctx.sampled
+}
+
 // SpanId returns the span id.
 func (s *Span) SpanId() string {
 	return s.ctx.SpanId()
@@ -1,8 +1,12 @@
 package trace
 
+import "strings"
+
 type spanContext struct {
-	traceId string
-	spanId  string
+	traceId    string
+	spanId     string
+	tracestate string
+	sampled    bool
 }
 
 func (sc spanContext) TraceId() string {
@@ -16,4 +20,26 @@ func (sc spanContext) SpanId() string {
 func (sc spanContext) Visit(fn func(key, val string) bool) {
 	fn(traceIdKey, sc.traceId)
 	fn(spanIdKey, sc.spanId)
+	fn(sampledKey, sampledFlag(sc.sampled))
+	traceparent := buildTraceparent(sc.traceId, sc.spanId, sc.sampled)
+	if len(traceparent) == 0 {
+		return
+	}
+
+	fn(traceparentKey, traceparent)
+	if len(sc.tracestate) > 0 {
+		// tracestate is only meaningful along with traceparent
+		fn(tracestateKey, sc.tracestate)
+	}
+	fields := strings.Split(traceparent, "-")
+	fn(b3TraceIdKey, fields[1])
+	fn(b3SpanIdKey, fields[2])
+}
+
+func sampledFlag(sampled bool) string {
+	if sampled {
+		return "1"
+	}
+
+	return "0"
 }
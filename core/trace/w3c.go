@@ -0,0 +1,91 @@
+package trace
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+const (
+	supportedVersion   = "00"
+	sampledTraceFlags  = "01"
+	deferredTraceFlags = "00"
+	invalidVersion     = "ff"
+	w3cTraceIdLen      = 32
+	w3cSpanIdLen       = 16
+)
+
+// buildTraceparent formats the given ids as a w3c traceparent header,
+// it returns an empty string if traceId can't be represented.
+// The legacy 16-char trace ids are zero padded, the dotted span ids
+// are hashed into a valid parent id.
+func buildTraceparent(traceId, spanId string, sampled bool) string {
+	switch {
+	case isHex(traceId, w3cTraceIdLen):
+	case isHex(traceId, w3cTraceIdLen/2):
+		traceId = strings.Repeat("0", w3cTraceIdLen/2) + traceId
+	default:
+		return ""
+	}
+
+	if !isHex(spanId, w3cSpanIdLen) {
+		spanId = hashSpanId(spanId)
+	}
+
+	flags := sampledTraceFlags
+	if !sampled {
+		flags = deferredTraceFlags
+	}
+
+	return strings.Join([]string{supportedVersion, traceId, spanId, flags}, "-")
+}
+
+// parseTraceparent parses a w3c traceparent header,
+// ok is false if the header is missing or malformed.
+func parseTraceparent(header string) (traceId, spanId string, sampled, ok bool) {
+	fields := strings.Split(header, "-")
+	if len(fields) < 4 {
+		return "", "", false, false
+	}
+	if !isHex(fields[0], 2) || fields[0] == invalidVersion {
+		return "", "", false, false
+	}
+	if !isHex(fields[1], w3cTraceIdLen) || isZero(fields[1]) {
+		return "", "", false, false
+	}
+	if !isHex(fields[2], w3cSpanIdLen) || isZero(fields[2]) {
+		return "", "", false, false
+	}
+	flags, err := strconv.ParseUint(fields[3], 16, 8)
+	if err != nil {
+		return "", "", false, false
+	}
+
+	return fields[1], fields[2], flags&1 == 1, true
+}
+
+func hashSpanId(spanId string) string {
+	h := fnv.New64a()
+	// fnv write never fails
+	h.Write([]byte(spanId))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+func isHex(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isZero(s string) bool {
+	return strings.Count(s, "0") == len(s)
+}
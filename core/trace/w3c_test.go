@@ -0,0 +1,117 @@
+package trace
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	traceId, spanId, sampled, ok := parseTraceparent(
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	assert.True(t, ok)
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", traceId)
+	assert.Equal(t, "b7ad6b7169203331", spanId)
+	assert.True(t, sampled)
+
+	badHeaders := []string{
+		"",
+		"whatever",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331",
+		"ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"00-00000000000000000000000000000000-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319c-0000000000000000-01",
+		"00-ZZf7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+	}
+	for _, header := range badHeaders {
+		_, _, _, ok = parseTraceparent(header)
+		assert.False(t, ok, header)
+	}
+}
+
+func TestBuildTraceparent(t *testing.T) {
+	// the legacy 16-char trace id is zero padded,
+	// the dotted span id is hashed to a valid parent id
+	header := buildTraceparent("b7ad6b7169203331", "0.1", true)
+	traceId, spanId, sampled, ok := parseTraceparent(header)
+	assert.True(t, ok)
+	assert.Equal(t, "0000000000000000b7ad6b7169203331", traceId)
+	assert.Equal(t, hashSpanId("0.1"), spanId)
+	assert.True(t, sampled)
+
+	// the w3c ids are kept as is
+	header = buildTraceparent("0af7651916cd43dd8448eb211c80319c", "b7ad6b7169203331", true)
+	assert.Equal(t, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", header)
+
+	assert.Empty(t, buildTraceparent("not-a-trace-id", "0", true))
+
+	// the unsampled flag is kept
+	_, _, sampled, ok = parseTraceparent(
+		buildTraceparent("0af7651916cd43dd8448eb211c80319c", "b7ad6b7169203331", false))
+	assert.True(t, ok)
+	assert.False(t, sampled)
+}
+
+func TestServerSpanFromTraceparent(t *testing.T) {
+	header := http.Header{}
+	header.Set(traceparentKey, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	header.Set(tracestateKey, "congo=t61rcWkgMzE")
+	carrier, err := Extract(HttpFormat, header)
+	assert.Nil(t, err)
+
+	_, span := StartServerSpan(context.Background(), carrier, "service", "operation")
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", span.TraceId())
+	assert.Equal(t, "b7ad6b7169203331", span.SpanId())
+
+	vals := make(map[string]string)
+	span.Visit(func(key, val string) bool {
+		vals[key] = val
+		return true
+	})
+	assert.Equal(t, span.TraceId(), vals[traceIdKey])
+	assert.Equal(t, "congo=t61rcWkgMzE", vals[tracestateKey])
+	assert.True(t, strings.HasPrefix(vals[traceparentKey],
+		"00-0af7651916cd43dd8448eb211c80319c-"))
+}
+
+func TestServerSpanLegacyHeadersFirst(t *testing.T) {
+	header := http.Header{}
+	header.Set(traceIdKey, "legacy-trace-id")
+	header.Set(spanIdKey, "0.1")
+	header.Set(traceparentKey, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	carrier, err := Extract(HttpFormat, header)
+	assert.Nil(t, err)
+
+	_, span := StartServerSpan(context.Background(), carrier, "service", "operation")
+	assert.Equal(t, "legacy-trace-id", span.TraceId())
+	assert.Equal(t, "0.1", span.SpanId())
+}
+
+func TestServerSpanFromB3(t *testing.T) {
+	header := http.Header{}
+	header.Set(b3TraceIdKey, "0af7651916cd43dd8448eb211c80319c")
+	header.Set(b3SpanIdKey, "b7ad6b7169203331")
+	carrier, err := Extract(HttpFormat, header)
+	assert.Nil(t, err)
+
+	_, span := StartServerSpan(context.Background(), carrier, "service", "operation")
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", span.TraceId())
+	assert.Equal(t, "b7ad6b7169203331", span.SpanId())
+}
+
+func TestVisitInjectsB3(t *testing.T) {
+	_, span := StartServerSpan(context.Background(), nil, "service", "operation")
+	vals := make(map[string]string)
+	span.Visit(func(key, val string) bool {
+		vals[key] = val
+		return true
+	})
+
+	// the random trace id is 16 hex chars, representable after padding
+	assert.NotEmpty(t, vals[traceparentKey])
+	assert.NotEmpty(t, vals[b3TraceIdKey])
+	assert.NotEmpty(t, vals[b3SpanIdKey])
+}
@@ -3,6 +3,7 @@ package rest
 import (
 	"time"
 
+	"github.com/tal-tech/go-zero/core/load"
 	"github.com/tal-tech/go-zero/core/service"
 )
 
@@ -37,8 +38,9 @@ type (
 		MaxConns int    `json:",default=10000"`
 		MaxBytes int64  `json:",default=1048576,range=[0:33554432]"`
 		// milliseconds
-		Timeout      int64         `json:",default=3000"`
-		CpuThreshold int64         `json:",default=900,range=[0:1000]"`
-		Signature    SignatureConf `json:",optional"`
+		Timeout      int64            `json:",default=3000"`
+		CpuThreshold int64            `json:",default=900,range=[0:1000]"`
+		Shedding     load.ShedderConf `json:",optional"`
+		Signature    SignatureConf    `json:",optional"`
 	}
 )
@@ -37,9 +37,10 @@ func newEngine(c RestConf) *engine {
 		conf: c,
 	}
 	if c.CpuThreshold > 0 {
-		srv.shedder = load.NewAdaptiveShedder(load.WithCpuThreshold(c.CpuThreshold))
-		srv.priorityShedder = load.NewAdaptiveShedder(load.WithCpuThreshold(
-			(c.CpuThreshold + topCpuUsage) >> 1))
+		srv.shedder = load.NewAdaptiveShedder(append(c.Shedding.Options(),
+			load.WithCpuThreshold(c.CpuThreshold))...)
+		srv.priorityShedder = load.NewAdaptiveShedder(append(c.Shedding.Options(),
+			load.WithCpuThreshold((c.CpuThreshold+topCpuUsage)>>1))...)
 	}
 
 	return srv
@@ -5,6 +5,7 @@ import (
 
 	"github.com/tal-tech/go-zero/core/stat"
 	"github.com/tal-tech/go-zero/core/timex"
+	"github.com/tal-tech/go-zero/core/trace/tracespec"
 )
 
 // MetricHandler returns a middleware that stat the metrics.
@@ -14,7 +15,9 @@ func MetricHandler(metrics *stat.Metrics) func(http.Handler) http.Handler {
 			startTime := timex.Now()
 			defer func() {
 				metrics.Add(stat.Task{
-					Duration: timex.Since(startTime),
+					Duration:    timex.Since(startTime),
+					Description: r.Method + " " + r.URL.Path,
+					TraceId:     traceIdFromContext(r),
 				})
 			}()
 
@@ -22,3 +25,12 @@ func MetricHandler(metrics *stat.Metrics) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+func traceIdFromContext(r *http.Request) string {
+	span, ok := r.Context().Value(tracespec.TracingKey).(tracespec.Trace)
+	if !ok {
+		return ""
+	}
+
+	return span.TraceId()
+}
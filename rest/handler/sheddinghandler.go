@@ -15,9 +15,19 @@ const serviceType = "api"
 
 var (
 	sheddingStat *load.SheddingStat
+	dropHandler  func(r *http.Request)
 	lock         sync.Mutex
 )
 
+// SetDropHandler sets the callback that is invoked with each dropped request,
+// e.g. to sample dropped traffic for analysis.
+// Call it before starting the server, the handler must not block.
+func SetDropHandler(handler func(r *http.Request)) {
+	lock.Lock()
+	dropHandler = handler
+	lock.Unlock()
+}
+
 // SheddingHandler returns a middleware that does load shedding.
 func SheddingHandler(shedder load.Shedder, metrics *stat.Metrics) func(http.Handler) http.Handler {
 	if shedder == nil {
@@ -37,6 +47,12 @@ func SheddingHandler(shedder load.Shedder, metrics *stat.Metrics) func(http.Hand
 				sheddingStat.IncrementDrop()
 				logx.Errorf("[http] dropped, %s - %s - %s",
 					r.RequestURI, httpx.GetRemoteAddr(r), r.UserAgent())
+				lock.Lock()
+				onDrop := dropHandler
+				lock.Unlock()
+				if onDrop != nil {
+					onDrop(r)
+				}
 				w.WriteHeader(http.StatusServiceUnavailable)
 				return
 			}
@@ -53,6 +53,28 @@ func TestSheddingHandlerFail(t *testing.T) {
 	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
 }
 
+func TestSheddingHandlerOnDrop(t *testing.T) {
+	metrics := stat.NewMetrics("unit-test")
+	shedder := mockShedder{
+		allow: false,
+	}
+	var dropped string
+	SetDropHandler(func(r *http.Request) {
+		dropped = r.RequestURI
+	})
+	defer SetDropHandler(nil)
+
+	sheddingHandler := SheddingHandler(shedder, metrics)
+	handler := sheddingHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/dropped", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	assert.Equal(t, "http://localhost/dropped", dropped)
+}
+
 func TestSheddingHandlerReject(t *testing.T) {
 	metrics := stat.NewMetrics("unit-test")
 	shedder := mockShedder{
@@ -52,14 +52,16 @@ func (lc *LogCollector) takeAll() []string {
 	return messages
 }
 
-// Error logs the given v along with r in error log.
+// Error logs the given v along with r in error log,
+// the entry carries the trace/span ids of r if any.
 func Error(r *http.Request, v ...interface{}) {
-	logx.ErrorCaller(1, format(r, v...))
+	logx.WithContext(r.Context()).Error(format(r, v...))
 }
 
-// Errorf logs the given v with format along with r in error log.
+// Errorf logs the given v with format along with r in error log,
+// the entry carries the trace/span ids of r if any.
 func Errorf(r *http.Request, format string, v ...interface{}) {
-	logx.ErrorCaller(1, formatf(r, format, v...))
+	logx.WithContext(r.Context()).Error(formatf(r, format, v...))
 }
 
 // Info logs the given v along with r in access log.
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/trace"
 )
 
 func TestInfo(t *testing.T) {
@@ -36,3 +37,18 @@ func TestError(t *testing.T) {
 	assert.True(t, strings.Contains(val, "third"))
 	assert.True(t, strings.Contains(val, "\n"))
 }
+
+func TestErrorWithTrace(t *testing.T) {
+	var writer strings.Builder
+	log.SetOutput(&writer)
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	carrier, _ := trace.Extract(trace.HttpFormat, req.Header)
+	ctx, span := trace.StartServerSpan(req.Context(), carrier, "localhost", req.RequestURI)
+	defer span.Finish()
+	req = req.WithContext(ctx)
+	Error(req, "with trace")
+	val := writer.String()
+	assert.True(t, strings.Contains(val, "with trace"))
+	assert.True(t, strings.Contains(val, `"trace":"`+span.TraceId()+`"`))
+	assert.True(t, strings.Contains(val, `"span":"`+span.SpanId()+`"`))
+}
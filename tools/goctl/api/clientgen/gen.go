@@ -0,0 +1,282 @@
+package clientgen
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/tal-tech/go-zero/tools/goctl/api/gogen"
+	"github.com/tal-tech/go-zero/tools/goctl/api/parser"
+	"github.com/tal-tech/go-zero/tools/goctl/api/spec"
+	"github.com/tal-tech/go-zero/tools/goctl/config"
+	"github.com/tal-tech/go-zero/tools/goctl/util"
+	"github.com/tal-tech/go-zero/tools/goctl/util/format"
+	"github.com/urfave/cli"
+)
+
+const (
+	pathPrefixProperty = "prefix"
+	pathTagKey         = "path"
+	formTagKey         = "form"
+)
+
+// ClientCommand generates a typed go client package from the given api file.
+func ClientCommand(c *cli.Context) error {
+	apiFile := c.String("api")
+	dir := c.String("dir")
+	pkg := c.String("pkg")
+	style := c.String("style")
+	if len(apiFile) == 0 {
+		return errors.New("missing -api")
+	}
+	if len(dir) == 0 {
+		return errors.New("missing -dir")
+	}
+	if len(pkg) == 0 {
+		pkg = "client"
+	}
+
+	api, err := parser.Parse(apiFile)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.NewConfig(style)
+	if err != nil {
+		return err
+	}
+
+	return DoGenClient(api, cfg, dir, pkg)
+}
+
+// DoGenClient generates the client package for the given api spec.
+func DoGenClient(api *spec.ApiSpec, cfg *config.Config, dir, pkg string) error {
+	if err := util.MkdirIfNotExist(dir); err != nil {
+		return err
+	}
+
+	if err := genTypes(api, cfg, dir, pkg); err != nil {
+		return err
+	}
+
+	return genClient(api, cfg, dir, pkg)
+}
+
+func genTypes(api *spec.ApiSpec, cfg *config.Config, dir, pkg string) error {
+	val, err := gogen.BuildTypes(api.Types)
+	if err != nil {
+		return err
+	}
+
+	filename, err := format.FileNamingFormat(cfg.NamingFormat, "types")
+	if err != nil {
+		return err
+	}
+
+	text, err := util.LoadTemplate(category, typesTemplateFile, typesTemplate)
+	if err != nil {
+		return err
+	}
+
+	return util.With("types").Parse(text).GoFmt(true).SaveTo(map[string]interface{}{
+		"pkg":   pkg,
+		"types": val,
+	}, filepath.Join(dir, filename+".go"), true)
+}
+
+func genClient(api *spec.ApiSpec, cfg *config.Config, dir, pkg string) error {
+	methods, err := buildMethods(api)
+	if err != nil {
+		return err
+	}
+
+	filename, err := format.FileNamingFormat(cfg.NamingFormat, "client")
+	if err != nil {
+		return err
+	}
+
+	text, err := util.LoadTemplate(category, clientTemplateFile, clientTemplate)
+	if err != nil {
+		return err
+	}
+
+	return util.With("client").Parse(text).GoFmt(true).SaveTo(map[string]interface{}{
+		"pkg":         pkg,
+		"serviceName": api.Service.Name,
+		"methods":     methods,
+	}, filepath.Join(dir, filename+".go"), true)
+}
+
+func buildMethods(api *spec.ApiSpec) (string, error) {
+	var builder strings.Builder
+	seen := make(map[string]bool)
+	for _, group := range api.Service.Groups {
+		for _, route := range group.Routes {
+			name, err := methodName(route)
+			if err != nil {
+				return "", err
+			}
+			if seen[name] {
+				return "", fmt.Errorf("duplicate handler: %s, handlers must be unique to generate a client", route.Handler)
+			}
+
+			seen[name] = true
+			if err := buildMethod(&builder, group, route, name); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return strings.TrimSpace(builder.String()), nil
+}
+
+func buildMethod(builder *strings.Builder, group spec.Group, route spec.Route, name string) error {
+	fullPath := pathForRoute(group, route)
+	pathExpr, err := pathExprForRoute(route, fullPath)
+	if err != nil {
+		return err
+	}
+
+	doc := route.JoinedDoc()
+	if len(doc) > 0 {
+		doc = ", " + doc
+	}
+	fmt.Fprintf(builder, "// %s invokes %s %s%s.\n", name, strings.ToLower(route.Method), fullPath, doc)
+
+	params := "ctx context.Context"
+	if len(route.RequestTypeName()) > 0 {
+		params += fmt.Sprintf(", req *%s", util.Title(route.RequestTypeName()))
+	}
+
+	respType := route.ResponseTypeName()
+	if len(respType) > 0 {
+		respType = util.Title(respType)
+		fmt.Fprintf(builder, "func (c *Client) %s(%s) (*%s, error) {\n", name, params, respType)
+	} else {
+		fmt.Fprintf(builder, "func (c *Client) %s(%s) error {\n", name, params)
+	}
+
+	queryExpr := "nil"
+	formMembers := formMembersForRoute(route)
+	if len(formMembers) > 0 {
+		builder.WriteString("\tquery := url.Values{}\n")
+		for _, member := range formMembers {
+			name, ok := tagName(member, formTagKey)
+			if !ok {
+				continue
+			}
+
+			fmt.Fprintf(builder, "\tquery.Set(%q, fmt.Sprint(req.%s))\n", name, member.Name)
+		}
+		queryExpr = "query"
+	}
+
+	bodyExpr := "nil"
+	if hasRequestBody(route) {
+		bodyExpr = "req"
+	}
+
+	httpMethod := "http.Method" + strings.Title(strings.ToLower(route.Method))
+	if len(respType) > 0 {
+		fmt.Fprintf(builder, "\tvar resp %s\n", respType)
+		fmt.Fprintf(builder, "\tif err := c.call(ctx, %s, %s, %s, %s, &resp); err != nil {\n",
+			httpMethod, pathExpr, queryExpr, bodyExpr)
+		builder.WriteString("\t\treturn nil, err\n\t}\n\n\treturn &resp, nil\n}\n\n")
+	} else {
+		fmt.Fprintf(builder, "\treturn c.call(ctx, %s, %s, %s, %s, nil)\n}\n\n",
+			httpMethod, pathExpr, queryExpr, bodyExpr)
+	}
+
+	return nil
+}
+
+func methodName(route spec.Route) (string, error) {
+	handler := strings.TrimSuffix(route.Handler, "Handler")
+	if len(handler) == 0 {
+		return "", fmt.Errorf("missing handler annotation for route %q", route.Path)
+	}
+
+	return util.Title(handler), nil
+}
+
+func pathForRoute(group spec.Group, route spec.Route) string {
+	prefix := group.GetAnnotation(pathPrefixProperty)
+	prefix = strings.TrimPrefix(prefix, `"`)
+	prefix = strings.TrimSuffix(prefix, `"`)
+	return path.Join("/", prefix, route.Path)
+}
+
+// pathExprForRoute returns the go expression that evaluates to the route path,
+// substituting :param segments with the path tagged fields of the request type.
+func pathExprForRoute(route spec.Route, fullPath string) (string, error) {
+	segments := strings.Split(fullPath, "/")
+	var args []string
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+
+		member, err := pathMemberForRoute(route, segment[1:])
+		if err != nil {
+			return "", err
+		}
+
+		segments[i] = "%v"
+		args = append(args, "req."+member.Name)
+	}
+
+	if len(args) == 0 {
+		return fmt.Sprintf("%q", fullPath), nil
+	}
+
+	return fmt.Sprintf("fmt.Sprintf(%q, %s)", strings.Join(segments, "/"),
+		strings.Join(args, ", ")), nil
+}
+
+func pathMemberForRoute(route spec.Route, name string) (spec.Member, error) {
+	ds, ok := route.RequestType.(spec.DefineStruct)
+	if !ok {
+		return spec.Member{}, fmt.Errorf("missing request type for path param %q of route %q",
+			name, route.Path)
+	}
+
+	for _, member := range ds.Members {
+		value, ok := tagName(member, pathTagKey)
+		if ok && value == name {
+			return member, nil
+		}
+	}
+
+	return spec.Member{}, fmt.Errorf("no field tagged with path %q in type %s for route %q",
+		name, ds.Name(), route.Path)
+}
+
+func tagName(member spec.Member, key string) (string, bool) {
+	for _, tag := range member.Tags() {
+		if tag.Key == key {
+			return tag.Name, true
+		}
+	}
+
+	return "", false
+}
+
+func formMembersForRoute(route spec.Route) []spec.Member {
+	ds, ok := route.RequestType.(spec.DefineStruct)
+	if !ok {
+		return nil
+	}
+
+	return ds.GetFormMembers()
+}
+
+func hasRequestBody(route spec.Route) bool {
+	ds, ok := route.RequestType.(spec.DefineStruct)
+	if !ok {
+		return false
+	}
+
+	return len(ds.GetBodyMembers()) > 0
+}
@@ -0,0 +1,78 @@
+package clientgen
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/tools/goctl/api/parser"
+	"github.com/tal-tech/go-zero/tools/goctl/config"
+)
+
+const testApi = `
+type SearchRequest struct {
+	Name string ` + "`" + `path:"name"` + "`" + `
+	Page int ` + "`" + `form:"page"` + "`" + `
+}
+
+type SearchResponse struct {
+	Message string ` + "`" + `json:"message"` + "`" + `
+}
+
+type SaveRequest struct {
+	Name string ` + "`" + `json:"name"` + "`" + `
+}
+
+@server(
+	prefix: /api
+)
+service search-api {
+	@server(
+		handler: SearchHandler
+	)
+	get /search/:name(SearchRequest) returns (SearchResponse)
+
+	@server(
+		handler: SaveHandler
+	)
+	post /save(SaveRequest)
+}
+`
+
+func TestBuildMethods(t *testing.T) {
+	api, err := parser.ParseContent(testApi)
+	assert.Nil(t, err)
+
+	methods, err := buildMethods(api)
+	assert.Nil(t, err)
+	assert.Contains(t, methods,
+		"func (c *Client) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {")
+	assert.Contains(t, methods, `fmt.Sprintf("/api/search/%v", req.Name)`)
+	assert.Contains(t, methods, `query.Set("page", fmt.Sprint(req.Page))`)
+	assert.Contains(t, methods,
+		"func (c *Client) Save(ctx context.Context, req *SaveRequest) error {")
+	assert.Contains(t, methods, `return c.call(ctx, http.MethodPost, "/api/save", nil, req, nil)`)
+}
+
+func TestDoGenClient(t *testing.T) {
+	api, err := parser.ParseContent(testApi)
+	assert.Nil(t, err)
+
+	cfg, err := config.NewConfig(config.DefaultFormat)
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	err = DoGenClient(api, cfg, dir, "client")
+	assert.Nil(t, err)
+
+	client, err := ioutil.ReadFile(filepath.Join(dir, "client.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(client), "package client")
+	assert.Contains(t, string(client), "func NewClient(host string, opts ...ClientOption) *Client {")
+	assert.Contains(t, string(client), "breaker.DoWithAcceptable")
+
+	types, err := ioutil.ReadFile(filepath.Join(dir, "types.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(types), "type SearchResponse struct {")
+}
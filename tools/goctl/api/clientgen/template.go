@@ -0,0 +1,228 @@
+package clientgen
+
+import (
+	"fmt"
+
+	"github.com/tal-tech/go-zero/tools/goctl/util"
+	"github.com/urfave/cli"
+)
+
+const (
+	category           = "client"
+	clientTemplateFile = "client.tpl"
+	typesTemplateFile  = "types.tpl"
+)
+
+const clientTemplate = `// Code generated by goctl. DO NOT EDIT.
+package {{.pkg}}
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/tal-tech/go-zero/core/breaker"
+	"github.com/tal-tech/go-zero/core/trace"
+)
+
+type (
+	// A ClientOption customizes the client.
+	ClientOption func(c *Client)
+
+	// A Client is a typed client for the {{.serviceName}} service.
+	Client struct {
+		host    string
+		cli     *http.Client
+		noBreak bool
+	}
+
+	// An Error describes a non-2xx response from the server,
+	// Code and Msg are filled when the body is a {"code":...,"msg":...} envelope.
+	Error struct {
+		StatusCode int    ` + "`" + `json:"-"` + "`" + `
+		Code       int    ` + "`" + `json:"code"` + "`" + `
+		Msg        string ` + "`" + `json:"msg"` + "`" + `
+		Body       string ` + "`" + `json:"-"` + "`" + `
+	}
+)
+
+// WithHTTPClient customizes the underlying http client.
+func WithHTTPClient(cli *http.Client) ClientOption {
+	return func(c *Client) {
+		c.cli = cli
+	}
+}
+
+// WithoutBreaker disables the circuit breaker on the client.
+func WithoutBreaker() ClientOption {
+	return func(c *Client) {
+		c.noBreak = true
+	}
+}
+
+// NewClient returns a Client that calls the {{.serviceName}} service on the given host,
+// e.g. http://localhost:8888. Calls are protected by a circuit breaker, and trace
+// headers are propagated when the passed in context carries a span.
+func NewClient(host string, opts ...ClientOption) *Client {
+	c := &Client{
+		host: strings.TrimSuffix(host, "/"),
+		cli:  http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+func (e *Error) Error() string {
+	if len(e.Msg) > 0 {
+		return fmt.Sprintf("status %d: %s", e.StatusCode, e.Msg)
+	}
+
+	return fmt.Sprintf("status %d: %s", e.StatusCode, e.Body)
+}
+
+func newError(statusCode int, body []byte) error {
+	e := &Error{
+		StatusCode: statusCode,
+		Body:       string(body),
+	}
+	// best effort, keep the raw body when it's not an envelope
+	_ = json.Unmarshal(body, e)
+	return e
+}
+
+func (c *Client) call(ctx context.Context, method, path string, query url.Values,
+	body, resp interface{}) error {
+	u := c.host + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		reader = bytes.NewReader(bs)
+	}
+
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	ctx, span := trace.StartClientSpan(ctx, c.host, method+" "+path)
+	defer span.Finish()
+	span.Visit(func(key, val string) bool {
+		req.Header.Set(key, val)
+		return true
+	})
+	req = req.WithContext(ctx)
+
+	if c.noBreak {
+		return c.do(req, resp)
+	}
+
+	return breaker.DoWithAcceptable(c.host, func() error {
+		return c.do(req, resp)
+	}, func(err error) bool {
+		if err == nil {
+			return true
+		}
+
+		// only server side errors trip the breaker
+		e, ok := err.(*Error)
+		return ok && e.StatusCode < http.StatusInternalServerError
+	})
+}
+
+func (c *Client) do(req *http.Request, resp interface{}) error {
+	res, err := c.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	payload, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return newError(res.StatusCode, payload)
+	}
+
+	if resp == nil {
+		return nil
+	}
+
+	return json.Unmarshal(payload, resp)
+}
+
+{{.methods}}
+`
+
+const typesTemplate = `// Code generated by goctl. DO NOT EDIT.
+package {{.pkg}}
+
+{{.types}}
+`
+
+var templates = map[string]string{
+	clientTemplateFile: clientTemplate,
+	typesTemplateFile:  typesTemplate,
+}
+
+// Category returns the category of the client files.
+func Category() string {
+	return category
+}
+
+// BuiltinTemplates returns the builtin templates of the client category.
+func BuiltinTemplates() map[string]string {
+	return templates
+}
+
+// Clean cleans the generated client template files.
+func Clean() error {
+	return util.Clean(category)
+}
+
+// GenTemplates generates client template files.
+func GenTemplates(_ *cli.Context) error {
+	return util.InitTemplates(category, templates)
+}
+
+// RevertTemplate reverts the given template file to the default value.
+func RevertTemplate(name string) error {
+	content, ok := templates[name]
+	if !ok {
+		return fmt.Errorf("%s: no such file name", name)
+	}
+
+	return util.CreateTemplate(category, name, content)
+}
+
+// Update updates the template files to the templates built in current goctl.
+func Update() error {
+	err := Clean()
+	if err != nil {
+		return err
+	}
+
+	return util.InitTemplates(category, templates)
+}
@@ -318,6 +318,25 @@ service A-api {
 }
 `
 
+const complexTypesApi = `
+type Node {
+  Name string ` + "`" + `json:"name"` + "`" + `
+  Children []Node ` + "`" + `json:"children,optional"` + "`" + `
+  Parent *Node ` + "`" + `json:"parent,optional"` + "`" + `
+}
+
+type ComplexRequest {
+  Rows [][]int ` + "`" + `json:"rows"` + "`" + `
+  Labels map[string][]Node ` + "`" + `json:"labels"` + "`" + `
+  Refs map[string]*Node ` + "`" + `json:"refs,optional"` + "`" + `
+}
+
+service A-api {
+  @handler ComplexHandler
+  post /complex(ComplexRequest) returns (Node)
+}
+`
+
 func TestParser(t *testing.T) {
 	filename := "greet.api"
 	err := ioutil.WriteFile(filename, []byte(testApiTemplate), os.ModePerm)
@@ -532,6 +551,26 @@ func TestCamelStyle(t *testing.T) {
 	validateWithCamel(t, filename, "GoZero")
 }
 
+func TestComplexTypesApi(t *testing.T) {
+	filename := "greet.api"
+	err := ioutil.WriteFile(filename, []byte(complexTypesApi), os.ModePerm)
+	assert.Nil(t, err)
+	defer os.Remove(filename)
+
+	api, err := parser.Parse(filename)
+	assert.Nil(t, err)
+
+	types, err := BuildTypes(api.Types)
+	assert.Nil(t, err)
+	assert.Contains(t, types, "Children []Node")
+	assert.Contains(t, types, "Parent *Node")
+	assert.Contains(t, types, "Rows [][]int")
+	assert.Contains(t, types, "Labels map[string][]Node")
+	assert.Contains(t, types, "Refs map[string]*Node")
+
+	validate(t, filename)
+}
+
 func validate(t *testing.T, api string) {
 	validateWithCamel(t, api, "gozero")
 }
@@ -5,32 +5,71 @@ import (
 
 	"github.com/tal-tech/go-zero/tools/goctl/api/spec"
 	"github.com/tal-tech/go-zero/tools/goctl/config"
+	ctlutil "github.com/tal-tech/go-zero/tools/goctl/util"
 	"github.com/tal-tech/go-zero/tools/goctl/util/format"
 )
 
 var middlewareImplementCode = `
 package middleware
 
-import "net/http"
+import (
+	"net/http"
+
+	{{.configImport}}
+)
 
 type {{.name}} struct {
+	Config config.Config
 }
 
-func New{{.name}}() *{{.name}} {	
-	return &{{.name}}{}
+func New{{.name}}(c config.Config) *{{.name}} {
+	return &{{.name}}{
+		Config: c,
+	}
 }
 
 func (m *{{.name}})Handle(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// TODO generate middleware implement function, delete after code implementation
 
-		// Passthrough to next handler if need 
+		// Passthrough to next handler if need
 		next(w, r)
-	}	
+	}
+}
+`
+
+var middlewareTestCode = `
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	{{.configImport}}
+)
+
+func Test{{.name}}(t *testing.T) {
+	m := New{{.name}}(config.Config{})
+	handler := m.Handle(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("unexpected status code: %d", w.Code)
+	}
 }
 `
 
 func genMiddleware(dir string, cfg *config.Config, api *spec.ApiSpec) error {
+	parentPkg, err := getParentPackage(dir)
+	if err != nil {
+		return err
+	}
+
+	configImport := "\"" + ctlutil.JoinPackages(parentPkg, configDir) + "\""
 	var middlewares = getMiddleware(api)
 	for _, item := range middlewares {
 		middlewareFilename := strings.TrimSuffix(strings.ToLower(item), "middleware") + "_middleware"
@@ -39,15 +78,35 @@ func genMiddleware(dir string, cfg *config.Config, api *spec.ApiSpec) error {
 			return err
 		}
 
-		name := strings.TrimSuffix(item, "Middleware") + "Middleware"
+		name := strings.Title(strings.TrimSuffix(item, "Middleware") + "Middleware")
 		err = genFile(fileGenConfig{
 			dir:             dir,
 			subdir:          middlewareDir,
 			filename:        filename + ".go",
-			templateName:    "contextTemplate",
+			templateName:    "middlewareTemplate",
+			category:        category,
+			templateFile:    middlewareTemplateFile,
 			builtinTemplate: middlewareImplementCode,
 			data: map[string]string{
-				"name": strings.Title(name),
+				"name":         name,
+				"configImport": configImport,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		err = genFile(fileGenConfig{
+			dir:             dir,
+			subdir:          middlewareDir,
+			filename:        filename + "_test.go",
+			templateName:    "middlewareTestTemplate",
+			category:        category,
+			templateFile:    middlewareTestTemplateFile,
+			builtinTemplate: middlewareTestCode,
+			data: map[string]string{
+				"name":         name,
+				"configImport": configImport,
 			},
 		})
 		if err != nil {
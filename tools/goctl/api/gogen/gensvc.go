@@ -58,7 +58,7 @@ func genServiceContext(dir string, cfg *config.Config, api *spec.ApiSpec) error
 		middlewareStr += fmt.Sprintf("%s rest.Middleware\n", item)
 		name := strings.TrimSuffix(item, "Middleware") + "Middleware"
 		middlewareAssignment += fmt.Sprintf("%s: %s,\n", item,
-			fmt.Sprintf("middleware.New%s().%s", strings.Title(name), "Handle"))
+			fmt.Sprintf("middleware.New%s(c).%s", strings.Title(name), "Handle"))
 	}
 
 	var configImport = "\"" + ctlutil.JoinPackages(parentPkg, configDir) + "\""
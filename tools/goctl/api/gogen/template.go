@@ -15,15 +15,20 @@ const (
 	handlerTemplateFile = "handler.tpl"
 	logicTemplateFile   = "logic.tpl"
 	mainTemplateFile    = "main.tpl"
+
+	middlewareTemplateFile     = "middleware.tpl"
+	middlewareTestTemplateFile = "middleware-test.tpl"
 )
 
 var templates = map[string]string{
-	configTemplateFile:  configTemplate,
-	contextTemplateFile: contextTemplate,
-	etcTemplateFile:     etcTemplate,
-	handlerTemplateFile: handlerTemplate,
-	logicTemplateFile:   logicTemplate,
-	mainTemplateFile:    mainTemplate,
+	configTemplateFile:         configTemplate,
+	contextTemplateFile:        contextTemplate,
+	etcTemplateFile:            etcTemplate,
+	handlerTemplateFile:        handlerTemplate,
+	logicTemplateFile:          logicTemplate,
+	mainTemplateFile:           mainTemplate,
+	middlewareTemplateFile:     middlewareImplementCode,
+	middlewareTestTemplateFile: middlewareTestCode,
 }
 
 // Category returns the category of the api files.
@@ -31,6 +36,11 @@ func Category() string {
 	return category
 }
 
+// BuiltinTemplates returns the builtin templates of the api category.
+func BuiltinTemplates() map[string]string {
+	return templates
+}
+
 // Clean cleans the generated deployment files.
 func Clean() error {
 	return util.Clean(category)
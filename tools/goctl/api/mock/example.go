@@ -0,0 +1,157 @@
+package mock
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/tal-tech/go-zero/tools/goctl/api/spec"
+)
+
+// exampleBuilder builds schema-valid example values from the api types
+type exampleBuilder struct {
+	types map[string]spec.DefineStruct
+}
+
+func newExampleBuilder(api *spec.ApiSpec) *exampleBuilder {
+	types := make(map[string]spec.DefineStruct)
+	for _, tp := range api.Types {
+		if definedType, ok := tp.(spec.DefineStruct); ok {
+			types[definedType.RawName] = definedType
+		}
+	}
+
+	return &exampleBuilder{
+		types: types,
+	}
+}
+
+// buildType returns an example value for the given type, the returned value
+// marshals to a json document that matches the schema of the type
+func (b *exampleBuilder) buildType(tp spec.Type) interface{} {
+	return b.build(tp, nil, make(map[string]bool))
+}
+
+func (b *exampleBuilder) build(tp spec.Type, member *spec.Member, seen map[string]bool) interface{} {
+	switch v := tp.(type) {
+	case spec.DefineStruct:
+		definedType, ok := b.types[v.RawName]
+		if !ok {
+			definedType = v
+		}
+		// break the recursion of self-referencing types
+		if seen[definedType.RawName] {
+			return nil
+		}
+		seen[definedType.RawName] = true
+		defer delete(seen, definedType.RawName)
+
+		result := make(map[string]interface{})
+		for _, m := range definedType.Members {
+			if m.IsInline {
+				if embedded, ok := b.build(m.Type, nil, seen).(map[string]interface{}); ok {
+					for key, value := range embedded {
+						result[key] = value
+					}
+				}
+				continue
+			}
+
+			name, err := m.GetPropertyName()
+			if err != nil {
+				continue
+			}
+
+			m := m
+			result[name] = b.build(m.Type, &m, seen)
+		}
+		return result
+	case spec.PrimitiveType:
+		return b.buildPrimitive(v, member)
+	case spec.ArrayType:
+		item := b.build(v.Value, member, seen)
+		if item == nil {
+			return []interface{}{}
+		}
+		return []interface{}{item}
+	case spec.MapType:
+		return map[string]interface{}{
+			"key": b.build(v.Value, member, seen),
+		}
+	case spec.PointerType:
+		return b.build(v.Type, member, seen)
+	case spec.InterfaceType:
+		return map[string]interface{}{}
+	default:
+		return nil
+	}
+}
+
+func (b *exampleBuilder) buildPrimitive(tp spec.PrimitiveType, member *spec.Member) interface{} {
+	value, ok := b.memberValue(member)
+	if !ok {
+		return defaultPrimitiveValue(tp.RawName)
+	}
+
+	return parsePrimitiveValue(tp.RawName, value)
+}
+
+// memberValue returns the sample value declared in the member tag,
+// the example option wins over default, options and range
+func (b *exampleBuilder) memberValue(member *spec.Member) (string, bool) {
+	if member == nil {
+		return "", false
+	}
+
+	if value, ok := member.ExampleOption(); ok {
+		return value, true
+	}
+	if value, ok := member.DefaultOption(); ok {
+		return value, true
+	}
+	if options := member.EnumOptions(); len(options) > 0 {
+		return options[0], true
+	}
+	if min, _, ok := member.RangeOption(); ok {
+		return min, true
+	}
+
+	return "", false
+}
+
+func defaultPrimitiveValue(rawName string) interface{} {
+	switch {
+	case rawName == "string":
+		return "string"
+	case rawName == "bool":
+		return true
+	case strings.HasPrefix(rawName, "float"):
+		return 0.0
+	case strings.HasPrefix(rawName, "int"), strings.HasPrefix(rawName, "uint"),
+		rawName == "byte", rawName == "rune":
+		return 0
+	default:
+		return nil
+	}
+}
+
+func parsePrimitiveValue(rawName, value string) interface{} {
+	switch {
+	case rawName == "string":
+		return value
+	case rawName == "bool":
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	case strings.HasPrefix(rawName, "float"):
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	case strings.HasPrefix(rawName, "int"), strings.HasPrefix(rawName, "uint"),
+		rawName == "byte", rawName == "rune":
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return parsed
+		}
+	}
+
+	return defaultPrimitiveValue(rawName)
+}
@@ -0,0 +1,108 @@
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/service"
+	"github.com/tal-tech/go-zero/rest"
+	"github.com/tal-tech/go-zero/rest/httpx"
+	"github.com/tal-tech/go-zero/tools/goctl/api/parser"
+	"github.com/tal-tech/go-zero/tools/goctl/api/spec"
+	"github.com/urfave/cli"
+)
+
+const pathPrefixProperty = "prefix"
+
+// MockCommand serves a mock rest server from the given api file, the routes return
+// schema-valid example responses, which makes the api consumable before it is implemented
+func MockCommand(c *cli.Context) error {
+	apiFile := c.String("api")
+	if len(apiFile) == 0 {
+		return errors.New("missing -api")
+	}
+
+	api, err := parser.Parse(apiFile)
+	if err != nil {
+		return err
+	}
+
+	port := c.Int("port")
+	latency := time.Duration(c.Int("latency")) * time.Millisecond
+	errorRate := c.Float64("errorRate")
+	if errorRate < 0 || errorRate > 1 {
+		return errors.New("errorRate should be between 0 and 1")
+	}
+
+	server := rest.MustNewServer(rest.RestConf{
+		ServiceConf: service.ServiceConf{
+			Name: fmt.Sprintf("%s-mock", api.Service.Name),
+			Log: logx.LogConf{
+				Mode: "console",
+			},
+		},
+		Host:     "0.0.0.0",
+		Port:     port,
+		MaxConns: 10000,
+		MaxBytes: 1048576,
+		Timeout:  3000,
+	})
+	defer server.Stop()
+
+	registerRoutes(server, api, latency, errorRate)
+
+	fmt.Printf("Starting mock server at 0.0.0.0:%d...\n", port)
+	server.Start()
+	return nil
+}
+
+func registerRoutes(server *rest.Server, api *spec.ApiSpec, latency time.Duration, errorRate float64) {
+	builder := newExampleBuilder(api)
+	for _, group := range api.Service.Groups {
+		prefix := group.GetAnnotation(pathPrefixProperty)
+		var routes []rest.Route
+		for _, route := range group.Routes {
+			routes = append(routes, rest.Route{
+				Method:  strings.ToUpper(route.Method),
+				Path:    path.Join("/", prefix, route.Path),
+				Handler: mockHandler(builder, route, latency, errorRate),
+			})
+		}
+		server.AddRoutes(routes)
+	}
+}
+
+// mockHandler returns a handler that responds with the example response of the route,
+// it sleeps the configured latency and injects errors at the configured rate
+func mockHandler(builder *exampleBuilder, route spec.Route, latency time.Duration,
+	errorRate float64) http.HandlerFunc {
+	var example interface{}
+	if route.ResponseType != nil {
+		example = builder.buildType(route.ResponseType)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if latency > 0 {
+			time.Sleep(latency)
+		}
+
+		if errorRate > 0 && rand.Float64() < errorRate {
+			httpx.WriteJson(w, http.StatusInternalServerError, map[string]string{
+				"error": "mock injected error",
+			})
+			return
+		}
+
+		if example == nil {
+			httpx.Ok(w)
+		} else {
+			httpx.OkJson(w, example)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/tools/goctl/api/parser"
+)
+
+const mockApi = `
+type Profile {
+  Nickname string ` + "`" + `json:"nickname,example=jack"` + "`" + `
+  Level int ` + "`" + `json:"level,default=3"` + "`" + `
+  Gender string ` + "`" + `json:"gender,options=male|female"` + "`" + `
+  Age int ` + "`" + `json:"age,range=[18:35]"` + "`" + `
+}
+
+type UserResponse {
+  Name string ` + "`" + `json:"name"` + "`" + `
+  Vip bool ` + "`" + `json:"vip"` + "`" + `
+  Profile Profile ` + "`" + `json:"profile"` + "`" + `
+  Tags []string ` + "`" + `json:"tags"` + "`" + `
+  Extra map[string]int ` + "`" + `json:"extra"` + "`" + `
+  Friends []UserResponse ` + "`" + `json:"friends,optional"` + "`" + `
+}
+
+service user-api {
+  @handler UserHandler
+  get /user returns (UserResponse)
+}
+`
+
+func TestBuildExample(t *testing.T) {
+	api, err := parser.ParseContent(mockApi)
+	assert.Nil(t, err)
+
+	builder := newExampleBuilder(api)
+	routes := api.Service.Routes()
+	assert.Equal(t, 1, len(routes))
+
+	example, ok := builder.buildType(routes[0].ResponseType).(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "string", example["name"])
+	assert.Equal(t, true, example["vip"])
+	assert.Equal(t, []interface{}{"string"}, example["tags"])
+	assert.Equal(t, map[string]interface{}{"key": 0}, example["extra"])
+
+	profile, ok := example["profile"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "jack", profile["nickname"])
+	assert.Equal(t, int64(3), profile["level"])
+	assert.Equal(t, "male", profile["gender"])
+	assert.Equal(t, int64(18), profile["age"])
+
+	// self reference stops the recursion instead of overflowing
+	assert.Equal(t, []interface{}{}, example["friends"])
+}
+
+func TestMockHandler(t *testing.T) {
+	api, err := parser.ParseContent(mockApi)
+	assert.Nil(t, err)
+
+	builder := newExampleBuilder(api)
+	route := api.Service.Routes()[0]
+
+	handler := mockHandler(builder, route, 0, 0)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/user", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var body map[string]interface{}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "string", body["name"])
+
+	handler = mockHandler(builder, route, 0, 1)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/user", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestMockHandlerLatency(t *testing.T) {
+	api, err := parser.ParseContent(mockApi)
+	assert.Nil(t, err)
+
+	builder := newExampleBuilder(api)
+	route := api.Service.Routes()[0]
+
+	const latency = 10 * time.Millisecond
+	handler := mockHandler(builder, route, latency, 0)
+	w := httptest.NewRecorder()
+	start := time.Now()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/user", nil))
+	assert.True(t, time.Since(start) >= latency)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
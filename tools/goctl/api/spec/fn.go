@@ -13,6 +13,12 @@ const (
 	formTagKey        = "form"
 	pathTagKey        = "path"
 	defaultSummaryKey = "summary"
+
+	optionsOptionPrefix = "options="
+	rangeOptionPrefix   = "range="
+	defaultOptionPrefix = "default="
+	exampleOptionPrefix = "example="
+	optionsSeparator    = "|"
 )
 
 var definedKeys = []string{bodyTagKey, formTagKey, pathTagKey}
@@ -70,6 +76,64 @@ func (m Member) IsOmitEmpty() bool {
 	return false
 }
 
+// EnumOptions returns the values of the options constraint,
+// which acts as an enum in validation, docs and generated clients,
+// i.e: `json:"gender,options=male|female"` returns ["male", "female"]
+func (m Member) EnumOptions() []string {
+	value, ok := m.constraint(optionsOptionPrefix)
+	if !ok {
+		return nil
+	}
+
+	return strings.Split(value, optionsSeparator)
+}
+
+// RangeOption returns the min and max of the range constraint,
+// i.e: `json:"age,range=[18:35]"` returns "18", "35", true
+func (m Member) RangeOption() (min, max string, ok bool) {
+	value, ok := m.constraint(rangeOptionPrefix)
+	if !ok || len(value) < 2 {
+		return "", "", false
+	}
+
+	fields := strings.Split(value[1:len(value)-1], ":")
+	if len(fields) != 2 {
+		return "", "", false
+	}
+
+	return fields[0], fields[1], true
+}
+
+// DefaultOption returns the value of the default constraint,
+// i.e: `json:"level,default=1"` returns "1", true
+func (m Member) DefaultOption() (string, bool) {
+	return m.constraint(defaultOptionPrefix)
+}
+
+// ExampleOption returns the value of the example option,
+// which is used in docs and mock responses,
+// i.e: `json:"name,example=jack"` returns "jack", true
+func (m Member) ExampleOption() (string, bool) {
+	return m.constraint(exampleOptionPrefix)
+}
+
+// constraint returns the value of the tag option with the given prefix
+func (m Member) constraint(prefix string) (string, bool) {
+	for _, tag := range m.Tags() {
+		if !stringx.Contains(definedKeys, tag.Key) {
+			continue
+		}
+
+		for _, option := range tag.Options {
+			if strings.HasPrefix(option, prefix) {
+				return option[len(prefix):], true
+			}
+		}
+	}
+
+	return "", false
+}
+
 // GetPropertyName returns json tag value
 func (m Member) GetPropertyName() (string, error) {
 	tags := m.Tags()
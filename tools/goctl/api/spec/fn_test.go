@@ -0,0 +1,47 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnumOptions(t *testing.T) {
+	member := Member{Tag: "`json:\"gender,options=male|female\"`"}
+	assert.Equal(t, []string{"male", "female"}, member.EnumOptions())
+
+	member = Member{Tag: "`form:\"gender,options=male\"`"}
+	assert.Equal(t, []string{"male"}, member.EnumOptions())
+
+	member = Member{Tag: "`json:\"gender\"`"}
+	assert.Nil(t, member.EnumOptions())
+}
+
+func TestRangeOption(t *testing.T) {
+	member := Member{Tag: "`json:\"age,range=[18:35]\"`"}
+	min, max, ok := member.RangeOption()
+	assert.True(t, ok)
+	assert.Equal(t, "18", min)
+	assert.Equal(t, "35", max)
+
+	member = Member{Tag: "`form:\"score,optional,range=(0:100]\"`"}
+	min, max, ok = member.RangeOption()
+	assert.True(t, ok)
+	assert.Equal(t, "0", min)
+	assert.Equal(t, "100", max)
+
+	member = Member{Tag: "`json:\"age\"`"}
+	_, _, ok = member.RangeOption()
+	assert.False(t, ok)
+}
+
+func TestDefaultOption(t *testing.T) {
+	member := Member{Tag: "`json:\"level,default=1\"`"}
+	value, ok := member.DefaultOption()
+	assert.True(t, ok)
+	assert.Equal(t, "1", value)
+
+	member = Member{Tag: "`json:\"level\"`"}
+	_, ok = member.DefaultOption()
+	assert.False(t, ok)
+}
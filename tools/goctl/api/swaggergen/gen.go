@@ -0,0 +1,357 @@
+package swaggergen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/tal-tech/go-zero/core/stringx"
+	"github.com/tal-tech/go-zero/tools/goctl/api/parser"
+	"github.com/tal-tech/go-zero/tools/goctl/api/spec"
+	"github.com/tal-tech/go-zero/tools/goctl/api/util"
+	"github.com/urfave/cli"
+)
+
+const (
+	applicationJson  = "application/json"
+	schemaRefPrefix  = "#/components/schemas/"
+	bearerAuthScheme = "bearerAuth"
+)
+
+// SwaggerCommand generates an OpenAPI 3 spec from the given api file
+func SwaggerCommand(c *cli.Context) error {
+	apiFile := c.String("api")
+	if len(apiFile) == 0 {
+		return errors.New("missing -api")
+	}
+
+	dir := c.String("dir")
+	if len(dir) == 0 {
+		dir = "."
+	}
+
+	api, err := parser.Parse(apiFile)
+	if err != nil {
+		return err
+	}
+
+	filename := c.String("filename")
+	if len(filename) == 0 {
+		base := filepath.Base(apiFile)
+		filename = strings.TrimSuffix(base, filepath.Ext(base)) + ".json"
+	}
+
+	return genSwagger(api, dir, filename, c.String("host"))
+}
+
+func genSwagger(api *spec.ApiSpec, dir, filename, host string) error {
+	doc, err := buildDoc(api, host)
+	if err != nil {
+		return err
+	}
+
+	fp, _, err := util.MaybeCreateFile(dir, "", filename)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fp.Write(content)
+	return err
+}
+
+func buildDoc(api *spec.ApiSpec, host string) (*swaggerDoc, error) {
+	doc := &swaggerDoc{
+		Openapi: "3.0.3",
+		Info:    buildInfo(api),
+		Paths:   make(map[string]swaggerPath),
+		Components: swaggerComponents{
+			Schemas: make(map[string]*swaggerSchema),
+		},
+	}
+	if len(host) > 0 {
+		doc.Servers = []swaggerServer{{Url: host}}
+	}
+
+	for _, tp := range api.Types {
+		definedType, ok := tp.(spec.DefineStruct)
+		if !ok {
+			continue
+		}
+
+		schema, err := buildStructSchema(definedType)
+		if err != nil {
+			return nil, err
+		}
+		doc.Components.Schemas[definedType.Name()] = schema
+	}
+
+	var jwtUsed bool
+	for _, group := range api.Service.Groups {
+		jwtEnabled := len(group.GetAnnotation("jwt")) > 0
+		if jwtEnabled {
+			jwtUsed = true
+		}
+
+		for _, route := range group.Routes {
+			op, err := buildOperation(api, group, route, jwtEnabled)
+			if err != nil {
+				return nil, err
+			}
+
+			path := swaggerPathOf(route.Path)
+			if doc.Paths[path] == nil {
+				doc.Paths[path] = make(swaggerPath)
+			}
+			doc.Paths[path][strings.ToLower(route.Method)] = op
+		}
+	}
+
+	if jwtUsed {
+		doc.Components.SecuritySchemes = map[string]swaggerSecurityScheme{
+			bearerAuthScheme: {
+				Type:         "http",
+				Scheme:       "bearer",
+				BearerFormat: "JWT",
+			},
+		}
+	}
+
+	return doc, nil
+}
+
+func buildInfo(api *spec.ApiSpec) swaggerInfo {
+	info := swaggerInfo{
+		Title:       infoProperty(api.Info, "title", api.Info.Title),
+		Description: infoProperty(api.Info, "desc", api.Info.Desc),
+		Version:     infoProperty(api.Info, "version", api.Info.Version),
+	}
+	if len(info.Title) == 0 {
+		info.Title = api.Service.Name
+	}
+	if len(info.Version) == 0 {
+		info.Version = "1.0"
+	}
+	return info
+}
+
+func infoProperty(info spec.Info, key, deprecated string) string {
+	value := deprecated
+	if len(info.Properties[key]) > 0 {
+		value = info.Properties[key]
+	}
+	return strings.Trim(value, `"`)
+}
+
+func buildOperation(api *spec.ApiSpec, group spec.Group, route spec.Route,
+	jwtEnabled bool) (swaggerOperation, error) {
+	op := swaggerOperation{
+		Summary:     route.JoinedDoc(),
+		OperationId: route.Handler,
+		Responses:   make(map[string]swaggerResponse),
+	}
+
+	tag := group.GetAnnotation("group")
+	if len(tag) == 0 {
+		tag = api.Service.Name
+	}
+	op.Tags = []string{tag}
+
+	if jwtEnabled {
+		op.Security = []map[string][]string{{bearerAuthScheme: {}}}
+	}
+
+	if err := fillParameters(&op, route); err != nil {
+		return op, err
+	}
+
+	response := swaggerResponse{Description: "A successful response."}
+	if route.ResponseType != nil && len(route.ResponseType.Name()) > 0 {
+		response.Content = map[string]swaggerMediaType{
+			applicationJson: {Schema: schemaOf(route.ResponseType)},
+		}
+	}
+	op.Responses["200"] = response
+
+	return op, nil
+}
+
+func fillParameters(op *swaggerOperation, route spec.Route) error {
+	definedType, ok := route.RequestType.(spec.DefineStruct)
+	if !ok {
+		return nil
+	}
+
+	for _, member := range definedType.Members {
+		if member.IsBodyMember() {
+			continue
+		}
+
+		name, err := member.GetPropertyName()
+		if err != nil {
+			return err
+		}
+
+		in := "query"
+		if !member.IsFormMember() {
+			in = "path"
+		}
+		schema := schemaOf(member.Type)
+		applyConstraints(schema, member)
+		op.Parameters = append(op.Parameters, swaggerParameter{
+			Name:        name,
+			In:          in,
+			Description: member.GetComment(),
+			Required:    in == "path" || !optionalMember(member),
+			Schema:      schema,
+		})
+	}
+
+	bodyMembers := definedType.GetBodyMembers()
+	if len(bodyMembers) == 0 {
+		return nil
+	}
+
+	schema, err := buildStructSchema(spec.DefineStruct{
+		RawName: definedType.RawName,
+		Members: bodyMembers,
+	})
+	if err != nil {
+		return err
+	}
+
+	op.RequestBody = &swaggerRequestBody{
+		Required: len(schema.Required) > 0,
+		Content: map[string]swaggerMediaType{
+			applicationJson: {Schema: schema},
+		},
+	}
+	return nil
+}
+
+// optionalMember reports whether the member tag carries the optional option,
+// spec.Member.IsOptional only covers body members.
+func optionalMember(member spec.Member) bool {
+	for _, tag := range member.Tags() {
+		if stringx.Contains(tag.Options, "optional") {
+			return true
+		}
+	}
+	return false
+}
+
+func buildStructSchema(tp spec.DefineStruct) (*swaggerSchema, error) {
+	schema := &swaggerSchema{
+		Type:       "object",
+		Properties: make(map[string]*swaggerSchema),
+	}
+
+	for _, member := range tp.Members {
+		if member.IsInline {
+			if inlineType, ok := member.Type.(spec.DefineStruct); ok {
+				inlineSchema, err := buildStructSchema(inlineType)
+				if err != nil {
+					return nil, err
+				}
+				for name, property := range inlineSchema.Properties {
+					schema.Properties[name] = property
+				}
+				schema.Required = append(schema.Required, inlineSchema.Required...)
+			}
+			continue
+		}
+		if !member.IsBodyMember() {
+			continue
+		}
+
+		name, err := member.GetPropertyName()
+		if err != nil {
+			return nil, err
+		}
+
+		property := schemaOf(member.Type)
+		property.Description = member.GetComment()
+		applyConstraints(property, member)
+		schema.Properties[name] = property
+		if !member.IsOptional() {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema, nil
+}
+
+// applyConstraints copies the validation constraints of the member tag
+// into the schema, they are enforced by httpx.Parse at runtime.
+func applyConstraints(schema *swaggerSchema, member spec.Member) {
+	schema.Enum = member.EnumOptions()
+	if min, max, ok := member.RangeOption(); ok {
+		if value, err := strconv.ParseFloat(min, 64); err == nil {
+			schema.Minimum = &value
+		}
+		if value, err := strconv.ParseFloat(max, 64); err == nil {
+			schema.Maximum = &value
+		}
+	}
+	if value, ok := member.DefaultOption(); ok {
+		schema.Default = value
+	}
+}
+
+func schemaOf(tp spec.Type) *swaggerSchema {
+	switch tt := tp.(type) {
+	case spec.PrimitiveType:
+		t, format := primitiveSchema(tt.RawName)
+		return &swaggerSchema{Type: t, Format: format}
+	case spec.DefineStruct:
+		return &swaggerSchema{Ref: schemaRefPrefix + tt.Name()}
+	case spec.ArrayType:
+		return &swaggerSchema{Type: "array", Items: schemaOf(tt.Value)}
+	case spec.MapType:
+		return &swaggerSchema{Type: "object", AdditionalProperties: schemaOf(tt.Value)}
+	case spec.PointerType:
+		return schemaOf(tt.Type)
+	default:
+		return &swaggerSchema{Type: "object"}
+	}
+}
+
+func primitiveSchema(rawName string) (string, string) {
+	switch rawName {
+	case "bool":
+		return "boolean", ""
+	case "int8", "int16", "int32", "uint8", "uint16", "uint32":
+		return "integer", "int32"
+	case "int", "int64", "uint", "uint64":
+		return "integer", "int64"
+	case "float32":
+		return "number", "float"
+	case "float64":
+		return "number", "double"
+	case "string":
+		return "string", ""
+	case "byte":
+		return "integer", "int32"
+	default:
+		return "string", ""
+	}
+}
+
+// swaggerPathOf rewrites the :name path style to the openapi {name} style.
+func swaggerPathOf(path string) string {
+	fields := strings.Split(path, "/")
+	for i, field := range fields {
+		if strings.HasPrefix(field, ":") {
+			fields[i] = fmt.Sprintf("{%s}", field[1:])
+		}
+	}
+	return strings.Join(fields, "/")
+}
@@ -0,0 +1,108 @@
+package swaggergen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/tools/goctl/api/parser"
+)
+
+const testApiTemplate = `
+info(
+    title: swagger title
+    desc: swagger description
+    version: 1.0
+)
+
+type Request struct {
+  Name string ` + "`" + `path:"name"` + "`" + `
+  Page int ` + "`" + `form:"page,optional"` + "`" + `
+}
+
+type Item struct {
+  Id int64 ` + "`" + `json:"id"` + "`" + `
+  Labels map[string]string ` + "`" + `json:"labels,optional"` + "`" + `
+}
+
+type Response struct {
+  Message string ` + "`" + `json:"message"` + "`" + `
+  Items []Item ` + "`" + `json:"items"` + "`" + `
+}
+
+type CreateRequest struct {
+  Name string ` + "`" + `json:"name"` + "`" + `
+  Gender string ` + "`" + `json:"gender,options=male|female"` + "`" + `
+  Age int ` + "`" + `json:"age,optional,range=[18:35]"` + "`" + `
+}
+
+@server(
+    jwt: Auth
+    group: greet
+)
+service A-api {
+  @server(
+    handler: GreetHandler
+  )
+  get /greet/from/:name(Request) returns (Response)
+
+  @handler CreateHandler
+  post /greet/create(CreateRequest) returns (Response)
+}
+`
+
+func TestBuildDoc(t *testing.T) {
+	api, err := parser.ParseContent(testApiTemplate)
+	assert.Nil(t, err)
+
+	doc, err := buildDoc(api, "https://example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "3.0.3", doc.Openapi)
+	assert.Equal(t, "swagger title", doc.Info.Title)
+	assert.Equal(t, []swaggerServer{{Url: "https://example.com"}}, doc.Servers)
+
+	getOp, ok := doc.Paths["/greet/from/{name}"]["get"]
+	assert.True(t, ok)
+	assert.Equal(t, "GreetHandler", getOp.OperationId)
+	assert.Equal(t, []string{"greet"}, getOp.Tags)
+	assert.Equal(t, []map[string][]string{{bearerAuthScheme: {}}}, getOp.Security)
+	assert.Equal(t, 2, len(getOp.Parameters))
+	assert.Equal(t, "name", getOp.Parameters[0].Name)
+	assert.Equal(t, "path", getOp.Parameters[0].In)
+	assert.True(t, getOp.Parameters[0].Required)
+	assert.Equal(t, "page", getOp.Parameters[1].Name)
+	assert.Equal(t, "query", getOp.Parameters[1].In)
+	assert.False(t, getOp.Parameters[1].Required)
+	assert.Nil(t, getOp.RequestBody)
+	assert.Equal(t, schemaRefPrefix+"Response",
+		getOp.Responses["200"].Content[applicationJson].Schema.Ref)
+
+	postOp, ok := doc.Paths["/greet/create"]["post"]
+	assert.True(t, ok)
+	assert.NotNil(t, postOp.RequestBody)
+	assert.True(t, postOp.RequestBody.Required)
+	body := postOp.RequestBody.Content[applicationJson].Schema
+	assert.Equal(t, "string", body.Properties["name"].Type)
+	assert.Equal(t, []string{"male", "female"}, body.Properties["gender"].Enum)
+	assert.Equal(t, float64(18), *body.Properties["age"].Minimum)
+	assert.Equal(t, float64(35), *body.Properties["age"].Maximum)
+
+	response := doc.Components.Schemas["Response"]
+	assert.NotNil(t, response)
+	assert.Equal(t, "array", response.Properties["items"].Type)
+	assert.Equal(t, schemaRefPrefix+"Item", response.Properties["items"].Items.Ref)
+
+	item := doc.Components.Schemas["Item"]
+	assert.Equal(t, "integer", item.Properties["id"].Type)
+	assert.Equal(t, "int64", item.Properties["id"].Format)
+	assert.Equal(t, "object", item.Properties["labels"].Type)
+	assert.Equal(t, "string", item.Properties["labels"].AdditionalProperties.Type)
+	assert.Equal(t, []string{"id"}, item.Required)
+
+	_, ok = doc.Components.SecuritySchemes[bearerAuthScheme]
+	assert.True(t, ok)
+}
+
+func TestSwaggerPathOf(t *testing.T) {
+	assert.Equal(t, "/a/{b}/c/{d}", swaggerPathOf("/a/:b/c/:d"))
+	assert.Equal(t, "/a/b", swaggerPathOf("/a/b"))
+}
@@ -0,0 +1,85 @@
+package swaggergen
+
+// the subset of the OpenAPI 3 object model that an .api file can express,
+// kept as plain structs so the document marshals without extra dependencies.
+type (
+	swaggerDoc struct {
+		Openapi    string                 `json:"openapi"`
+		Info       swaggerInfo            `json:"info"`
+		Servers    []swaggerServer        `json:"servers,omitempty"`
+		Paths      map[string]swaggerPath `json:"paths"`
+		Components swaggerComponents      `json:"components,omitempty"`
+	}
+
+	swaggerInfo struct {
+		Title       string `json:"title"`
+		Description string `json:"description,omitempty"`
+		Version     string `json:"version"`
+	}
+
+	swaggerServer struct {
+		Url string `json:"url"`
+	}
+
+	// swaggerPath maps lowercased http methods to their operations.
+	swaggerPath map[string]swaggerOperation
+
+	swaggerOperation struct {
+		Summary     string                     `json:"summary,omitempty"`
+		Description string                     `json:"description,omitempty"`
+		OperationId string                     `json:"operationId"`
+		Tags        []string                   `json:"tags,omitempty"`
+		Parameters  []swaggerParameter         `json:"parameters,omitempty"`
+		RequestBody *swaggerRequestBody        `json:"requestBody,omitempty"`
+		Responses   map[string]swaggerResponse `json:"responses"`
+		Security    []map[string][]string      `json:"security,omitempty"`
+	}
+
+	swaggerParameter struct {
+		Name        string         `json:"name"`
+		In          string         `json:"in"`
+		Description string         `json:"description,omitempty"`
+		Required    bool           `json:"required"`
+		Schema      *swaggerSchema `json:"schema,omitempty"`
+	}
+
+	swaggerRequestBody struct {
+		Required bool                        `json:"required"`
+		Content  map[string]swaggerMediaType `json:"content"`
+	}
+
+	swaggerResponse struct {
+		Description string                      `json:"description"`
+		Content     map[string]swaggerMediaType `json:"content,omitempty"`
+	}
+
+	swaggerMediaType struct {
+		Schema *swaggerSchema `json:"schema,omitempty"`
+	}
+
+	swaggerSchema struct {
+		Ref                  string                    `json:"$ref,omitempty"`
+		Type                 string                    `json:"type,omitempty"`
+		Format               string                    `json:"format,omitempty"`
+		Description          string                    `json:"description,omitempty"`
+		Properties           map[string]*swaggerSchema `json:"properties,omitempty"`
+		Required             []string                  `json:"required,omitempty"`
+		Items                *swaggerSchema            `json:"items,omitempty"`
+		AdditionalProperties *swaggerSchema            `json:"additionalProperties,omitempty"`
+		Enum                 []string                  `json:"enum,omitempty"`
+		Minimum              *float64                  `json:"minimum,omitempty"`
+		Maximum              *float64                  `json:"maximum,omitempty"`
+		Default              string                    `json:"default,omitempty"`
+	}
+
+	swaggerComponents struct {
+		Schemas         map[string]*swaggerSchema        `json:"schemas,omitempty"`
+		SecuritySchemes map[string]swaggerSecurityScheme `json:"securitySchemes,omitempty"`
+	}
+
+	swaggerSecurityScheme struct {
+		Type         string `json:"type"`
+		Scheme       string `json:"scheme"`
+		BearerFormat string `json:"bearerFormat,omitempty"`
+	}
+)
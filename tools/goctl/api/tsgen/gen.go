@@ -18,6 +18,8 @@ func TsCommand(c *cli.Context) error {
 	webAPI := c.String("webapi")
 	caller := c.String("caller")
 	unwrapAPI := c.Bool("unwrap")
+	client := c.String("client")
+	namespace := c.Bool("namespace")
 	if len(apiFile) == 0 {
 		return errors.New("missing -api")
 	}
@@ -33,7 +35,15 @@ func TsCommand(c *cli.Context) error {
 	}
 
 	logx.Must(util.MkdirIfNotExist(dir))
-	logx.Must(genHandler(dir, webAPI, caller, api, unwrapAPI))
+	if len(client) > 0 {
+		if len(caller) == 0 {
+			caller = "webapi"
+		}
+		// the generated handlers import the generated http layer
+		webAPI = "./" + requestFilename
+		logx.Must(genRequest(dir, client, caller))
+	}
+	logx.Must(genHandler(dir, webAPI, caller, api, unwrapAPI, namespace))
 	logx.Must(genComponents(dir, api))
 
 	fmt.Println(aurora.Green("Done."))
@@ -0,0 +1,38 @@
+package tsgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/tools/goctl/api/parser"
+)
+
+const complexTypesApi = `
+type Node {
+  Name string ` + "`" + `json:"name"` + "`" + `
+  Children []Node ` + "`" + `json:"children,optional"` + "`" + `
+  Parent *Node ` + "`" + `json:"parent,optional"` + "`" + `
+}
+
+type ComplexRequest {
+  Rows [][]int ` + "`" + `json:"rows"` + "`" + `
+  Labels map[string][]Node ` + "`" + `json:"labels"` + "`" + `
+}
+
+service A-api {
+  @handler ComplexHandler
+  post /complex(ComplexRequest) returns (Node)
+}
+`
+
+func TestBuildTypesComplex(t *testing.T) {
+	api, err := parser.ParseContent(complexTypesApi)
+	assert.Nil(t, err)
+
+	types, err := buildTypes(api.Types)
+	assert.Nil(t, err)
+	assert.Contains(t, types, "children?: Array<Node>")
+	assert.Contains(t, types, "parent?: Node")
+	assert.Contains(t, types, "rows: Array<Array<number>>")
+	assert.Contains(t, types, "labels: { [key: string]: Array<Node> }")
+}
@@ -18,7 +18,7 @@ const (
 `
 )
 
-func genHandler(dir, webAPI, caller string, api *spec.ApiSpec, unwrapAPI bool) error {
+func genHandler(dir, webAPI, caller string, api *spec.ApiSpec, unwrapAPI, namespace bool) error {
 	filename := strings.Replace(api.Service.Name, "-api", "", 1) + ".ts"
 	if err := util.RemoveIfExist(path.Join(dir, filename)); err != nil {
 		return err
@@ -53,7 +53,7 @@ func genHandler(dir, webAPI, caller string, api *spec.ApiSpec, unwrapAPI bool) e
 		imports += fmt.Sprintf(`%sexport * from "%s"`, util.NL, "./"+outputFile)
 	}
 
-	apis, err := genAPI(api, caller)
+	apis, err := genAPI(api, caller, namespace)
 	if err != nil {
 		return err
 	}
@@ -65,35 +65,27 @@ func genHandler(dir, webAPI, caller string, api *spec.ApiSpec, unwrapAPI bool) e
 	})
 }
 
-func genAPI(api *spec.ApiSpec, caller string) (string, error) {
+func genAPI(api *spec.ApiSpec, caller string, namespace bool) (string, error) {
 	var builder strings.Builder
 	for _, group := range api.Service.Groups {
+		groupNamespace := ""
+		indent := 0
+		if namespace {
+			groupNamespace = namespaceForGroup(group)
+		}
+		if len(groupNamespace) > 0 {
+			fmt.Fprintf(&builder, "export namespace %s {\n", groupNamespace)
+			indent = 1
+		}
+
 		for _, route := range group.Routes {
-			handler := route.Handler
-			if len(handler) == 0 {
-				return "", fmt.Errorf("missing handler annotation for route %q", route.Path)
+			if err := genRoute(&builder, group, route, caller, indent); err != nil {
+				return "", err
 			}
+		}
 
-			handler = util.Untitle(handler)
-			handler = strings.Replace(handler, "Handler", "", 1)
-			comment := commentForRoute(route)
-			if len(comment) > 0 {
-				fmt.Fprintf(&builder, "%s\n", comment)
-			}
-			fmt.Fprintf(&builder, "export function %s(%s) {\n", handler, paramsForRoute(route))
-			writeIndent(&builder, 1)
-			responseGeneric := "<null>"
-			if len(route.ResponseTypeName()) > 0 {
-				val, err := goTypeToTs(route.ResponseType, true)
-				if err != nil {
-					return "", err
-				}
-
-				responseGeneric = fmt.Sprintf("<%s>", val)
-			}
-			fmt.Fprintf(&builder, `return %s.%s%s(%s)`, caller, strings.ToLower(route.Method),
-				util.Title(responseGeneric), callParamsForRoute(route, group))
-			builder.WriteString("\n}\n\n")
+		if len(groupNamespace) > 0 {
+			builder.WriteString("}\n\n")
 		}
 	}
 
@@ -101,6 +93,64 @@ func genAPI(api *spec.ApiSpec, caller string) (string, error) {
 	return apis, nil
 }
 
+func genRoute(builder *strings.Builder, group spec.Group, route spec.Route,
+	caller string, indent int) error {
+	handler := route.Handler
+	if len(handler) == 0 {
+		return fmt.Errorf("missing handler annotation for route %q", route.Path)
+	}
+
+	handler = util.Untitle(handler)
+	handler = strings.Replace(handler, "Handler", "", 1)
+	comment := commentForRoute(route)
+	if len(comment) > 0 {
+		for _, line := range strings.Split(comment, "\n") {
+			writeIndent(builder, indent)
+			fmt.Fprintf(builder, "%s\n", line)
+		}
+	}
+	writeIndent(builder, indent)
+	fmt.Fprintf(builder, "export function %s(%s) {\n", handler, paramsForRoute(route))
+	writeIndent(builder, indent+1)
+	responseGeneric := "<null>"
+	if len(route.ResponseTypeName()) > 0 {
+		val, err := goTypeToTs(route.ResponseType, true)
+		if err != nil {
+			return err
+		}
+
+		responseGeneric = fmt.Sprintf("<%s>", val)
+	}
+	fmt.Fprintf(builder, `return %s.%s%s(%s)`, caller, strings.ToLower(route.Method),
+		util.Title(responseGeneric), callParamsForRoute(route, group))
+	builder.WriteString("\n")
+	writeIndent(builder, indent)
+	builder.WriteString("}\n\n")
+	return nil
+}
+
+// namespaceForGroup derives a ts namespace from the group annotation,
+// e.g. greet/s1 becomes greetS1, empty for ungrouped routes.
+func namespaceForGroup(group spec.Group) string {
+	name := group.GetAnnotation(groupProperty)
+	if len(name) == 0 {
+		return ""
+	}
+
+	fields := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '/' || r == '-' || r == '_'
+	})
+	var builder strings.Builder
+	for i, field := range fields {
+		if i == 0 {
+			builder.WriteString(util.Untitle(field))
+		} else {
+			builder.WriteString(util.Title(field))
+		}
+	}
+	return builder.String()
+}
+
 func paramsForRoute(route spec.Route) string {
 	if route.RequestType == nil {
 		return ""
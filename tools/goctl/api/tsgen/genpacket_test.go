@@ -0,0 +1,65 @@
+package tsgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/tools/goctl/api/parser"
+	"github.com/tal-tech/go-zero/tools/goctl/api/spec"
+)
+
+const testApiTemplate = `
+type Request struct {
+  Name string ` + "`" + `path:"name"` + "`" + `
+}
+
+type Response struct {
+  Message string ` + "`" + `json:"message"` + "`" + `
+}
+
+@server(
+    group: greet/s1
+)
+service A-api {
+  @server(
+    handler: GreetHandler
+  )
+  get /greet/from/:name(Request) returns (Response)
+}
+`
+
+func TestNamespaceForGroup(t *testing.T) {
+	tests := []struct {
+		annotation string
+		expect     string
+	}{
+		{"greet/s1", "greetS1"},
+		{"user-center", "userCenter"},
+		{"admin", "admin"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		group := spec.Group{
+			Annotation: spec.Annotation{
+				Properties: map[string]string{groupProperty: test.annotation},
+			},
+		}
+		assert.Equal(t, test.expect, namespaceForGroup(group))
+	}
+}
+
+func TestGenAPIWithNamespace(t *testing.T) {
+	api, err := parser.ParseContent(testApiTemplate)
+	assert.Nil(t, err)
+
+	apis, err := genAPI(api, "webapi", true)
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(apis, "export namespace greetS1 {"))
+	assert.True(t, strings.Contains(apis, "export function greet(params: components.RequestParams)"))
+
+	apis, err = genAPI(api, "webapi", false)
+	assert.Nil(t, err)
+	assert.False(t, strings.Contains(apis, "namespace"))
+}
@@ -0,0 +1,203 @@
+package tsgen
+
+import (
+	"fmt"
+	"path"
+	"text/template"
+
+	apiutil "github.com/tal-tech/go-zero/tools/goctl/api/util"
+	"github.com/tal-tech/go-zero/tools/goctl/util"
+)
+
+const (
+	clientFetch = "fetch"
+	clientAxios = "axios"
+
+	requestFilename = "request"
+
+	// the shared part of the generated http layer: the error envelope,
+	// the request config and the path parameter substitution.
+	requestCommonTemplate = `// Code generated by goctl. DO NOT EDIT.
+
+// ErrorResponse is the error envelope the server replies with on failures.
+export interface ErrorResponse {
+	code: number
+	message: string
+}
+
+// RequestError carries the typed error envelope of a failed request.
+export class RequestError extends Error {
+	status: number
+	response?: ErrorResponse
+
+	constructor(status: number, message: string, response?: ErrorResponse) {
+		super(message)
+		this.status = status
+		this.response = response
+	}
+}
+
+export interface RequestConfig {
+	baseUrl?: string
+	headers?: { [key: string]: string }
+}
+
+// config can be adjusted by the caller, e.g. to set the base url
+// or an authorization header.
+export const config: RequestConfig = {}
+
+// buildUrl substitutes :name path parameters from params,
+// the remaining entries are appended as the query string.
+function buildUrl(url: string, params?: { [key: string]: any }): string {
+	const query: string[] = []
+	if (params) {
+		for (const key of Object.keys(params)) {
+			const value = params[key]
+			if (value === undefined || value === null) {
+				continue
+			}
+			const placeholder = ':' + key
+			if (url.indexOf(placeholder) >= 0) {
+				url = url.replace(placeholder, encodeURIComponent(String(value)))
+			} else {
+				query.push(encodeURIComponent(key) + '=' + encodeURIComponent(String(value)))
+			}
+		}
+	}
+	if (query.length > 0) {
+		url += (url.indexOf('?') >= 0 ? '&' : '?') + query.join('&')
+	}
+	return (config.baseUrl || '') + url
+}
+
+function splitArgs(args: any[]): [{ [key: string]: any } | undefined, any] {
+	// generated calls pass (params), (body) or (params, body),
+	// a lone argument is params when the url still has a placeholder to fill
+	return args.length > 1 ? [args[0], args[1]] : [args[0], undefined]
+}
+`
+
+	fetchClientTemplate = `
+async function request<T>(method: string, url: string, ...args: any[]): Promise<T> {
+	let [params, body] = splitArgs(args)
+	if (body === undefined && params && !hasPathOrQueryUse(url, params)) {
+		body = params
+		params = undefined
+	}
+	const response = await fetch(buildUrl(url, params), {
+		method: method.toUpperCase(),
+		headers: {
+			'Content-Type': 'application/json',
+			...config.headers,
+		},
+		body: body !== undefined ? JSON.stringify(body) : undefined,
+	})
+	if (!response.ok) {
+		let envelope: ErrorResponse | undefined
+		try {
+			envelope = await response.json()
+		} catch {
+			// not a json error envelope, keep the status text
+		}
+		throw new RequestError(response.status, envelope?.message || response.statusText, envelope)
+	}
+	if (response.status === 204) {
+		return undefined as unknown as T
+	}
+	return (await response.json()) as T
+}
+
+function hasPathOrQueryUse(url: string, params: { [key: string]: any }): boolean {
+	return Object.keys(params).some((key) => url.indexOf(':' + key) >= 0)
+}
+
+export const {{.caller}} = {
+	get: <T>(url: string, ...args: any[]) => request<T>('get', url, ...args),
+	post: <T>(url: string, ...args: any[]) => request<T>('post', url, ...args),
+	put: <T>(url: string, ...args: any[]) => request<T>('put', url, ...args),
+	patch: <T>(url: string, ...args: any[]) => request<T>('patch', url, ...args),
+	delete: <T>(url: string, ...args: any[]) => request<T>('delete', url, ...args),
+	head: <T>(url: string, ...args: any[]) => request<T>('head', url, ...args),
+}
+
+export default {{.caller}}
+`
+
+	axiosClientTemplate = `
+import axios, { AxiosError } from 'axios'
+
+async function request<T>(method: string, url: string, ...args: any[]): Promise<T> {
+	let [params, body] = splitArgs(args)
+	if (body === undefined && params && !hasPathOrQueryUse(url, params)) {
+		body = params
+		params = undefined
+	}
+	try {
+		const response = await axios.request<T>({
+			method: method,
+			url: buildUrl(url, params),
+			headers: config.headers,
+			data: body,
+		})
+		return response.data
+	} catch (err) {
+		const axiosError = err as AxiosError<ErrorResponse>
+		if (axiosError.response) {
+			const envelope = axiosError.response.data
+			throw new RequestError(axiosError.response.status,
+				envelope?.message || axiosError.message, envelope)
+		}
+		throw err
+	}
+}
+
+function hasPathOrQueryUse(url: string, params: { [key: string]: any }): boolean {
+	return Object.keys(params).some((key) => url.indexOf(':' + key) >= 0)
+}
+
+export const {{.caller}} = {
+	get: <T>(url: string, ...args: any[]) => request<T>('get', url, ...args),
+	post: <T>(url: string, ...args: any[]) => request<T>('post', url, ...args),
+	put: <T>(url: string, ...args: any[]) => request<T>('put', url, ...args),
+	patch: <T>(url: string, ...args: any[]) => request<T>('patch', url, ...args),
+	delete: <T>(url: string, ...args: any[]) => request<T>('delete', url, ...args),
+	head: <T>(url: string, ...args: any[]) => request<T>('head', url, ...args),
+}
+
+export default {{.caller}}
+`
+)
+
+// genRequest writes the http layer the generated handlers import,
+// client selects the transport, fetch or axios.
+func genRequest(dir, client, caller string) error {
+	var clientTemplate string
+	switch client {
+	case clientFetch:
+		clientTemplate = fetchClientTemplate
+	case clientAxios:
+		clientTemplate = axiosClientTemplate
+	default:
+		return fmt.Errorf("unsupported client %q, use %s or %s", client, clientFetch, clientAxios)
+	}
+
+	outputFile := requestFilename + ".ts"
+	if err := util.RemoveIfExist(path.Join(dir, outputFile)); err != nil {
+		return err
+	}
+
+	fp, created, err := apiutil.MaybeCreateFile(dir, "", outputFile)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return nil
+	}
+	defer fp.Close()
+
+	t := template.Must(template.New("requestTemplate").Parse(
+		requestCommonTemplate + clientTemplate))
+	return t.Execute(fp, map[string]string{
+		"caller": caller,
+	})
+}
@@ -18,6 +18,10 @@ func writeProperty(writer io.Writer, member spec.Member, indent int) error {
 		return err
 	}
 
+	if union := unionType(member, ty); len(union) > 0 {
+		ty = union
+	}
+
 	optionalTag := ""
 	if member.IsOptional() || member.IsOmitEmpty() {
 		optionalTag = "?"
@@ -40,6 +44,29 @@ func writeProperty(writer io.Writer, member spec.Member, indent int) error {
 	return err
 }
 
+// unionType narrows the ts type of a member with an options constraint
+// into a union of its literal values, i.e: 'male' | 'female'
+func unionType(member spec.Member, tsType string) string {
+	options := member.EnumOptions()
+	if len(options) == 0 {
+		return ""
+	}
+
+	var literals []string
+	switch tsType {
+	case "string":
+		for _, option := range options {
+			literals = append(literals, fmt.Sprintf("'%s'", option))
+		}
+	case "number":
+		literals = options
+	default:
+		return ""
+	}
+
+	return strings.Join(literals, " | ")
+}
+
 func writeIndent(writer io.Writer, indent int) {
 	for i := 0; i < indent; i++ {
 		fmt.Fprint(writer, "\t")
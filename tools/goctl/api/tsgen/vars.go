@@ -3,4 +3,5 @@ package tsgen
 const (
 	packagePrefix = "components."
 	pathPrefix    = "pathPrefix"
+	groupProperty = "group"
 )
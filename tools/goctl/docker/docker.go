@@ -28,6 +28,9 @@ type Docker struct {
 	GoRelPath string
 	GoFile    string
 	ExeFile   string
+	BaseImage string
+	Version   string
+	Timezone  string
 	HasPort   bool
 	Port      int
 	Argument  string
@@ -50,9 +53,8 @@ func DockerCommand(c *cli.Context) (err error) {
 		return fmt.Errorf("file %q not found", goFile)
 	}
 
-	port := c.Int("port")
 	if _, err := os.Stat(etcDir); os.IsNotExist(err) {
-		return generateDockerfile(goFile, port)
+		return generateDockerfile(c)
 	}
 
 	cfg, err := findConfig(goFile, etcDir)
@@ -60,7 +62,7 @@ func DockerCommand(c *cli.Context) (err error) {
 		return err
 	}
 
-	if err := generateDockerfile(goFile, port, "-f", "etc/"+cfg); err != nil {
+	if err := generateDockerfile(c, "-f", "etc/"+cfg); err != nil {
 		return err
 	}
 
@@ -101,7 +103,8 @@ func findConfig(file, dir string) (string, error) {
 	return files[0], nil
 }
 
-func generateDockerfile(goFile string, port int, args ...string) error {
+func generateDockerfile(c *cli.Context, args ...string) error {
+	goFile := c.String("go")
 	projPath, err := getFilePath(filepath.Dir(goFile))
 	if err != nil {
 		return err
@@ -132,6 +135,12 @@ func generateDockerfile(goFile string, port int, args ...string) error {
 		builder.WriteString(`, "` + arg + `"`)
 	}
 
+	version := c.String("version")
+	if len(version) > 0 {
+		version += "-"
+	}
+
+	port := c.Int("port")
 	_, offset := time.Now().Zone()
 	t := template.Must(template.New("dockerfile").Parse(text))
 	return t.Execute(out, Docker{
@@ -139,6 +148,9 @@ func generateDockerfile(goFile string, port int, args ...string) error {
 		GoRelPath: projPath,
 		GoFile:    goFile,
 		ExeFile:   util.FileNameWithoutExt(filepath.Base(goFile)),
+		BaseImage: c.String("base"),
+		Version:   version,
+		Timezone:  c.String("tz"),
 		HasPort:   port > 0,
 		Port:      port,
 		Argument:  builder.String(),
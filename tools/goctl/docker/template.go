@@ -8,7 +8,7 @@ import (
 const (
 	category           = "docker"
 	dockerTemplateFile = "docker.tpl"
-	dockerTemplate     = `FROM golang:alpine AS builder
+	dockerTemplate     = `FROM golang:{{.Version}}alpine AS builder
 
 LABEL stage=gobuilder
 
@@ -16,6 +16,8 @@ ENV CGO_ENABLED 0
 ENV GOOS linux
 {{if .Chinese}}ENV GOPROXY https://goproxy.cn,direct
 {{end}}
+RUN apk update --no-cache && apk add --no-cache ca-certificates tzdata
+
 WORKDIR /build/zero
 
 ADD go.mod .
@@ -26,10 +28,11 @@ COPY . .
 {{end}}RUN go build -ldflags="-s -w" -o /app/{{.ExeFile}} {{.GoRelPath}}/{{.GoFile}}
 
 
-FROM alpine
+FROM {{.BaseImage}}
 
-RUN apk update --no-cache && apk add --no-cache ca-certificates tzdata
-ENV TZ Asia/Shanghai
+COPY --from=builder /etc/ssl/certs/ca-certificates.crt /etc/ssl/certs/ca-certificates.crt
+COPY --from=builder /usr/share/zoneinfo/{{.Timezone}} /usr/share/zoneinfo/{{.Timezone}}
+ENV TZ {{.Timezone}}
 
 WORKDIR /app
 COPY --from=builder /app/{{.ExeFile}} /app/{{.ExeFile}}{{if .Argument}}
@@ -41,6 +44,10 @@ CMD ["./{{.ExeFile}}"{{.Argument}}]
 `
 )
 
+var templates = map[string]string{
+	dockerTemplateFile: dockerTemplate,
+}
+
 // Clean deletes all templates files
 func Clean() error {
 	return util.Clean(category)
@@ -56,6 +63,11 @@ func Category() string {
 	return category
 }
 
+// BuiltinTemplates returns the builtin templates of the docker category
+func BuiltinTemplates() map[string]string {
+	return templates
+}
+
 // RevertTemplate recovers the deleted template files
 func RevertTemplate(name string) error {
 	return util.CreateTemplate(category, name, dockerTemplate)
@@ -72,7 +84,5 @@ func Update() error {
 }
 
 func initTemplate() error {
-	return util.InitTemplates(category, map[string]string{
-		dockerTemplateFile: dockerTemplate,
-	})
+	return util.InitTemplates(category, templates)
 }
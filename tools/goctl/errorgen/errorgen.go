@@ -0,0 +1,240 @@
+package errorgen
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/tal-tech/go-zero/core/conf"
+	ctlconf "github.com/tal-tech/go-zero/tools/goctl/config"
+	"github.com/tal-tech/go-zero/tools/goctl/util"
+	"github.com/tal-tech/go-zero/tools/goctl/util/format"
+	"github.com/urfave/cli"
+)
+
+type (
+	// Code declares one error code with its mappings and translations
+	Code struct {
+		// Name is the go identifier of the error, it gets an Err prefix
+		Name string
+		// Code is the business error code
+		Code int
+		// Desc documents the error in the generated code
+		Desc string `json:",optional"`
+		// HttpStatus is the http status the error maps to
+		HttpStatus int `json:",default=400"`
+		// GrpcCode is the grpc code the error maps to, default codes.Unknown
+		GrpcCode int `json:",default=2"`
+		// Messages are the translations of the error keyed by language
+		Messages map[string]string `json:",optional"`
+	}
+
+	// Definition describes the error definition file
+	Definition struct {
+		// Package is the package name of the generated code
+		Package string `json:",default=errors"`
+		// DefaultLanguage is the language of the message compiled into the errors
+		DefaultLanguage string `json:",default=en"`
+		// Codes are the error codes to generate
+		Codes []Code
+	}
+)
+
+// ErrorsCommand generates a typed errors package from a declarative error definition file,
+// including the rest error-handler mapping, the grpc code mapping and the i18n message catalogs
+func ErrorsCommand(c *cli.Context) error {
+	configFile := c.String("config")
+	dir := c.String("dir")
+	if len(configFile) == 0 {
+		return errors.New("missing -config")
+	}
+	if len(dir) == 0 {
+		return errors.New("missing -dir")
+	}
+
+	cfg, err := ctlconf.NewConfig(c.String("style"))
+	if err != nil {
+		return err
+	}
+
+	definition, err := LoadDefinition(configFile)
+	if err != nil {
+		return err
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	if err := util.MkdirIfNotExist(abs); err != nil {
+		return err
+	}
+
+	if err := generate(abs, cfg, definition); err != nil {
+		return err
+	}
+
+	fmt.Println(aurora.Green("Done."))
+	return nil
+}
+
+// LoadDefinition loads and validates the error definition file, yaml and json are both supported
+func LoadDefinition(file string) (Definition, error) {
+	var definition Definition
+	if err := conf.LoadConfig(file, &definition); err != nil {
+		return Definition{}, err
+	}
+
+	if len(definition.Codes) == 0 {
+		return Definition{}, fmt.Errorf("no codes found in %s", file)
+	}
+
+	names := make(map[string]bool)
+	codes := make(map[int]bool)
+	for _, code := range definition.Codes {
+		if len(code.Name) == 0 {
+			return Definition{}, fmt.Errorf("missing name of code %d", code.Code)
+		}
+		if names[code.Name] {
+			return Definition{}, fmt.Errorf("duplicated name: %s", code.Name)
+		}
+		if codes[code.Code] {
+			return Definition{}, fmt.Errorf("duplicated code: %d", code.Code)
+		}
+		names[code.Name] = true
+		codes[code.Code] = true
+	}
+
+	return definition, nil
+}
+
+func generate(dir string, cfg *ctlconf.Config, definition Definition) error {
+	if err := genErrors(dir, cfg, definition); err != nil {
+		return err
+	}
+
+	if err := genHandler(dir, cfg, definition); err != nil {
+		return err
+	}
+
+	return genMessages(dir, cfg, definition)
+}
+
+func genErrors(dir string, cfg *ctlconf.Config, definition Definition) error {
+	text, err := util.LoadTemplate(category, errorsTemplateFile, errorsTemplate)
+	if err != nil {
+		return err
+	}
+
+	var builder strings.Builder
+	for _, code := range definition.Codes {
+		buffer, err := util.With("errorItem").Parse(errorItemTemplate).Execute(map[string]interface{}{
+			"name":    code.Name,
+			"code":    code.Code,
+			"message": message(definition, code),
+			"hasDesc": len(code.Desc) > 0,
+			"desc":    code.Desc,
+		})
+		if err != nil {
+			return err
+		}
+
+		builder.WriteString(buffer.String())
+	}
+
+	filename, err := format.FileNamingFormat(cfg.NamingFormat, "errors")
+	if err != nil {
+		return err
+	}
+
+	return util.With("errors").GoFmt(true).Parse(text).SaveTo(map[string]interface{}{
+		"package": definition.Package,
+		"errors":  builder.String(),
+	}, filepath.Join(dir, filename+".go"), true)
+}
+
+func genHandler(dir string, cfg *ctlconf.Config, definition Definition) error {
+	text, err := util.LoadTemplate(category, handlerTemplateFile, handlerTemplate)
+	if err != nil {
+		return err
+	}
+
+	var httpBuilder, grpcBuilder strings.Builder
+	for _, code := range definition.Codes {
+		httpBuilder.WriteString(fmt.Sprintf("\t%d: %d,\n", code.Code, code.HttpStatus))
+		grpcBuilder.WriteString(fmt.Sprintf("\t%d: %d,\n", code.Code, code.GrpcCode))
+	}
+
+	filename, err := format.FileNamingFormat(cfg.NamingFormat, "handler")
+	if err != nil {
+		return err
+	}
+
+	return util.With("handler").GoFmt(true).Parse(text).SaveTo(map[string]interface{}{
+		"package":    definition.Package,
+		"httpStatus": httpBuilder.String(),
+		"grpcCodes":  grpcBuilder.String(),
+	}, filepath.Join(dir, filename+".go"), true)
+}
+
+func genMessages(dir string, cfg *ctlconf.Config, definition Definition) error {
+	text, err := util.LoadTemplate(category, messagesTemplateFile, messagesTemplate)
+	if err != nil {
+		return err
+	}
+
+	var builder strings.Builder
+	for _, lang := range languages(definition) {
+		builder.WriteString(fmt.Sprintf("\t%q: {\n", lang))
+		for _, code := range definition.Codes {
+			if message, ok := code.Messages[lang]; ok {
+				builder.WriteString(fmt.Sprintf("\t\t%d: %q,\n", code.Code, message))
+			}
+		}
+		builder.WriteString("\t},\n")
+	}
+
+	filename, err := format.FileNamingFormat(cfg.NamingFormat, "messages")
+	if err != nil {
+		return err
+	}
+
+	return util.With("messages").GoFmt(true).Parse(text).SaveTo(map[string]interface{}{
+		"package":         definition.Package,
+		"defaultLanguage": definition.DefaultLanguage,
+		"catalogs":        builder.String(),
+	}, filepath.Join(dir, filename+".go"), true)
+}
+
+// message returns the message compiled into the error, which is the translation
+// of the default language, or the desc when there is no translation
+func message(definition Definition, code Code) string {
+	if message, ok := code.Messages[definition.DefaultLanguage]; ok {
+		return message
+	}
+	if len(code.Desc) > 0 {
+		return code.Desc
+	}
+	return code.Name
+}
+
+// languages returns the sorted languages used in the definition
+func languages(definition Definition) []string {
+	set := make(map[string]bool)
+	for _, code := range definition.Codes {
+		for lang := range code.Messages {
+			set[lang] = true
+		}
+	}
+
+	var result []string
+	for lang := range set {
+		result = append(result, lang)
+	}
+	sort.Strings(result)
+	return result
+}
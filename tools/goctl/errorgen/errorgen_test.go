@@ -0,0 +1,91 @@
+package errorgen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	conf "github.com/tal-tech/go-zero/tools/goctl/config"
+)
+
+const testDefinition = `Package: xerrors
+DefaultLanguage: en
+Codes:
+- Name: UserNotFound
+  Code: 100404
+  Desc: the requested user does not exist
+  HttpStatus: 404
+  GrpcCode: 5
+  Messages:
+    en: user not found
+    zh: 用户不存在
+- Name: InvalidParam
+  Code: 100400
+  Messages:
+    en: invalid parameter
+`
+
+func loadTestDefinition(t *testing.T) Definition {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "errors.yaml")
+	assert.Nil(t, ioutil.WriteFile(file, []byte(testDefinition), os.ModePerm))
+
+	definition, err := LoadDefinition(file)
+	assert.Nil(t, err)
+	return definition
+}
+
+func TestLoadDefinition(t *testing.T) {
+	definition := loadTestDefinition(t)
+	assert.Equal(t, "xerrors", definition.Package)
+	assert.Equal(t, 2, len(definition.Codes))
+	assert.Equal(t, 404, definition.Codes[0].HttpStatus)
+	// defaults apply for unspecified mappings
+	assert.Equal(t, 400, definition.Codes[1].HttpStatus)
+	assert.Equal(t, 2, definition.Codes[1].GrpcCode)
+}
+
+func TestLoadDefinitionDuplicated(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "errors.yaml")
+	content := `Codes:
+- Name: UserNotFound
+  Code: 1
+- Name: UserNotFound
+  Code: 2
+`
+	assert.Nil(t, ioutil.WriteFile(file, []byte(content), os.ModePerm))
+	_, err := LoadDefinition(file)
+	assert.NotNil(t, err)
+}
+
+func TestGenerate(t *testing.T) {
+	definition := loadTestDefinition(t)
+	cfg, err := conf.NewConfig("gozero")
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	assert.Nil(t, generate(dir, cfg, definition))
+
+	errorsContent, err := ioutil.ReadFile(filepath.Join(dir, "errors.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(errorsContent), "package xerrors")
+	assert.Contains(t, string(errorsContent), "// ErrUserNotFound the requested user does not exist")
+	assert.Contains(t, string(errorsContent), `ErrUserNotFound = NewCodeError(100404, "user not found")`)
+	assert.Contains(t, string(errorsContent), `ErrInvalidParam = NewCodeError(100400, "invalid parameter")`)
+
+	handlerContent, err := ioutil.ReadFile(filepath.Join(dir, "handler.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(handlerContent), "100404: 404,")
+	assert.Contains(t, string(handlerContent), "100404: 5,")
+	assert.Contains(t, string(handlerContent), "func HttpErrorHandler(err error) (int, interface{})")
+	assert.Contains(t, string(handlerContent), "func GrpcError(err error) error")
+
+	messagesContent, err := ioutil.ReadFile(filepath.Join(dir, "messages.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(messagesContent), `defaultLanguage = "en"`)
+	assert.Contains(t, string(messagesContent), `100404: "用户不存在",`)
+	assert.Contains(t, string(messagesContent), "func Message(lang string, code int) string")
+}
@@ -0,0 +1,170 @@
+package errorgen
+
+import (
+	"fmt"
+
+	"github.com/tal-tech/go-zero/tools/goctl/util"
+	"github.com/urfave/cli"
+)
+
+const (
+	category             = "errors"
+	errorsTemplateFile   = "errors.tpl"
+	handlerTemplateFile  = "handler.tpl"
+	messagesTemplateFile = "messages.tpl"
+)
+
+const errorsTemplate = `// Code generated by goctl. DO NOT EDIT.
+package {{.package}}
+
+// CodeError is an error with a business error code
+type CodeError struct {
+	Code    int    ` + "`" + `json:"code"` + "`" + `
+	Message string ` + "`" + `json:"message"` + "`" + `
+}
+
+// NewCodeError creates a CodeError with the given code and message
+func NewCodeError(code int, message string) *CodeError {
+	return &CodeError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+func (e *CodeError) Error() string {
+	return e.Message
+}
+
+var (
+{{.errors}})
+`
+
+const errorItemTemplate = `	{{if .hasDesc}}// Err{{.name}} {{.desc}}
+	{{end}}Err{{.name}} = NewCodeError({{.code}}, "{{.message}}")
+`
+
+const handlerTemplate = `// Code generated by goctl. DO NOT EDIT.
+package {{.package}}
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// httpStatus maps the error codes to http status codes
+var httpStatus = map[int]int{
+{{.httpStatus}}}
+
+// grpcCodes maps the error codes to grpc codes
+var grpcCodes = map[int]codes.Code{
+{{.grpcCodes}}}
+
+// HttpErrorHandler maps an error to its http status and response body,
+// plug it in with httpx.SetErrorHandler(HttpErrorHandler)
+func HttpErrorHandler(err error) (int, interface{}) {
+	if codeErr, ok := err.(*CodeError); ok {
+		if httpStatus, ok := httpStatus[codeErr.Code]; ok {
+			return httpStatus, codeErr
+		}
+		return http.StatusBadRequest, codeErr
+	}
+
+	return http.StatusInternalServerError, map[string]string{
+		"message": err.Error(),
+	}
+}
+
+// GrpcError converts an error to a grpc status error
+func GrpcError(err error) error {
+	if codeErr, ok := err.(*CodeError); ok {
+		if grpcCode, ok := grpcCodes[codeErr.Code]; ok {
+			return status.Error(grpcCode, codeErr.Message)
+		}
+		return status.Error(codes.Unknown, codeErr.Message)
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+`
+
+const messagesTemplate = `// Code generated by goctl. DO NOT EDIT.
+package {{.package}}
+
+const defaultLanguage = "{{.defaultLanguage}}"
+
+// messages holds the i18n message catalogs keyed by language and error code
+var messages = map[string]map[int]string{
+{{.catalogs}}}
+
+// Message returns the message of the code in the given language,
+// it falls back to the default language when the translation is missing
+func Message(lang string, code int) string {
+	if catalog, ok := messages[lang]; ok {
+		if message, ok := catalog[code]; ok {
+			return message
+		}
+	}
+
+	if message, ok := messages[defaultLanguage][code]; ok {
+		return message
+	}
+
+	return ""
+}
+
+// WithLanguage returns a copy of the error with the message translated to lang
+func (e *CodeError) WithLanguage(lang string) *CodeError {
+	if message := Message(lang, e.Code); len(message) > 0 {
+		return NewCodeError(e.Code, message)
+	}
+
+	return e
+}
+`
+
+var templates = map[string]string{
+	errorsTemplateFile:   errorsTemplate,
+	handlerTemplateFile:  handlerTemplate,
+	messagesTemplateFile: messagesTemplate,
+}
+
+// Category returns the const string of errors category
+func Category() string {
+	return category
+}
+
+// BuiltinTemplates returns the builtin templates of the errors category
+func BuiltinTemplates() map[string]string {
+	return templates
+}
+
+// Clean deletes all errors template files
+func Clean() error {
+	return util.Clean(category)
+}
+
+// GenTemplates creates errors template files
+func GenTemplates(_ *cli.Context) error {
+	return util.InitTemplates(category, templates)
+}
+
+// RevertTemplate recovers the deleted template files
+func RevertTemplate(name string) error {
+	content, ok := templates[name]
+	if !ok {
+		return fmt.Errorf("unexpected template name: %s", name)
+	}
+	return util.CreateTemplate(category, name, content)
+}
+
+// Update deletes and creates new template files
+func Update() error {
+	err := Clean()
+	if err != nil {
+		return err
+	}
+
+	return util.InitTemplates(category, templates)
+}
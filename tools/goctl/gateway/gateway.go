@@ -0,0 +1,315 @@
+package gateway
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/logrusorgru/aurora"
+	conf "github.com/tal-tech/go-zero/tools/goctl/config"
+	"github.com/tal-tech/go-zero/tools/goctl/rpc/execx"
+	"github.com/tal-tech/go-zero/tools/goctl/rpc/parser"
+	"github.com/tal-tech/go-zero/tools/goctl/util"
+	"github.com/tal-tech/go-zero/tools/goctl/util/ctx"
+	"github.com/tal-tech/go-zero/tools/goctl/util/format"
+	"github.com/tal-tech/go-zero/tools/goctl/util/stringx"
+	"github.com/urfave/cli"
+)
+
+// GatewayCommand generates a rest gateway service from a proto file and a route mapping file,
+// the handlers of the gateway transcode the http requests into the rpc calls of the zrpc service
+func GatewayCommand(c *cli.Context) error {
+	src := c.String("src")
+	mappingFile := c.String("mapping")
+	dir := c.String("dir")
+	protoImportPath := c.StringSlice("proto_path")
+	if len(src) == 0 {
+		return errors.New("missing -src")
+	}
+	if len(mappingFile) == 0 {
+		return errors.New("missing -mapping")
+	}
+	if len(dir) == 0 {
+		return errors.New("missing -dir")
+	}
+
+	cfg, err := conf.NewConfig(c.String("style"))
+	if err != nil {
+		return err
+	}
+
+	mapping, err := LoadMapping(mappingFile)
+	if err != nil {
+		return err
+	}
+
+	p := parser.NewDefaultProtoParser()
+	proto, err := p.Parse(src)
+	if err != nil {
+		return err
+	}
+
+	routes, err := buildRoutes(proto, mapping)
+	if err != nil {
+		return err
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	if err := util.MkdirIfNotExist(abs); err != nil {
+		return err
+	}
+
+	projectCtx, err := ctx.Prepare(abs)
+	if err != nil {
+		return err
+	}
+
+	g := newGenerator(projectCtx, proto, cfg)
+	if err := g.generate(routes, protoImportPath); err != nil {
+		return err
+	}
+
+	fmt.Println(aurora.Green("Done."))
+	return nil
+}
+
+type generator struct {
+	ctx         *ctx.ProjectContext
+	proto       parser.Proto
+	cfg         *conf.Config
+	serviceName string
+	etcDir      string
+	configDir   string
+	svcDir      string
+	handlerDir  string
+	pbDir       string
+}
+
+func newGenerator(projectCtx *ctx.ProjectContext, proto parser.Proto, cfg *conf.Config) *generator {
+	name := strings.TrimSuffix(proto.Name, filepath.Ext(proto.Name))
+	internalDir := filepath.Join(projectCtx.WorkDir, "internal")
+	return &generator{
+		ctx:         projectCtx,
+		proto:       proto,
+		cfg:         cfg,
+		serviceName: strings.ToLower(stringx.From(strings.ReplaceAll(name, "-", "")).ToCamel()),
+		etcDir:      filepath.Join(projectCtx.WorkDir, "etc"),
+		configDir:   filepath.Join(internalDir, "config"),
+		svcDir:      filepath.Join(internalDir, "svc"),
+		handlerDir:  filepath.Join(internalDir, "handler"),
+		pbDir:       filepath.Join(projectCtx.WorkDir, proto.GoPackage),
+	}
+}
+
+func (g *generator) generate(routes []route, protoImportPath []string) error {
+	for _, dir := range []string{g.etcDir, g.configDir, g.svcDir, g.handlerDir, g.pbDir} {
+		if err := util.MkdirIfNotExist(dir); err != nil {
+			return err
+		}
+	}
+
+	if err := g.genEtc(); err != nil {
+		return err
+	}
+
+	if err := g.genPb(protoImportPath); err != nil {
+		return err
+	}
+
+	if err := g.genConfig(); err != nil {
+		return err
+	}
+
+	if err := g.genSvc(); err != nil {
+		return err
+	}
+
+	if err := g.genHandlers(routes); err != nil {
+		return err
+	}
+
+	if err := g.genMetadata(); err != nil {
+		return err
+	}
+
+	if err := g.genRoutes(routes); err != nil {
+		return err
+	}
+
+	return g.genMain()
+}
+
+func (g *generator) genEtc() error {
+	text, err := util.LoadTemplate(category, etcTemplateFile, etcTemplate)
+	if err != nil {
+		return err
+	}
+
+	filename := filepath.Join(g.etcDir, fmt.Sprintf("%s-gateway.yaml", g.serviceName))
+	return util.With("etc").Parse(text).SaveTo(map[string]interface{}{
+		"serviceName": g.serviceName,
+	}, filename, false)
+}
+
+func (g *generator) genPb(protoImportPath []string) error {
+	cw := new(bytes.Buffer)
+	base := filepath.Dir(g.proto.Src)
+	cw.WriteString("protoc ")
+	for _, ip := range protoImportPath {
+		cw.WriteString(" -I=" + ip)
+	}
+	cw.WriteString(" -I=" + base)
+	cw.WriteString(" " + g.proto.Name)
+	if strings.Contains(g.proto.GoPackage, "/") {
+		cw.WriteString(" --go_out=plugins=grpc:" + g.ctx.WorkDir)
+	} else {
+		cw.WriteString(" --go_out=plugins=grpc:" + g.pbDir)
+	}
+
+	_, err := execx.Run(cw.String(), "")
+	return err
+}
+
+func (g *generator) genConfig() error {
+	text, err := util.LoadTemplate(category, configTemplateFile, configTemplate)
+	if err != nil {
+		return err
+	}
+
+	filename, err := format.FileNamingFormat(g.cfg.NamingFormat, "config")
+	if err != nil {
+		return err
+	}
+
+	return util.With("config").GoFmt(true).Parse(text).SaveTo(nil,
+		filepath.Join(g.configDir, filename+".go"), false)
+}
+
+func (g *generator) genSvc() error {
+	text, err := util.LoadTemplate(category, svcTemplateFile, svcTemplate)
+	if err != nil {
+		return err
+	}
+
+	var services []string
+	for _, service := range g.proto.Services {
+		services = append(services, parser.CamelCase(service.Name))
+	}
+
+	filename, err := format.FileNamingFormat(g.cfg.NamingFormat, "service_context")
+	if err != nil {
+		return err
+	}
+
+	return util.With("svc").GoFmt(true).Parse(text).SaveTo(map[string]interface{}{
+		"configImport": g.pkg(g.configDir),
+		"pbPackage":    g.proto.PbPackage,
+		"pbImport":     g.pkg(g.pbDir),
+		"services":     services,
+	}, filepath.Join(g.svcDir, filename+".go"), false)
+}
+
+func (g *generator) genHandlers(routes []route) error {
+	text, err := util.LoadTemplate(category, handlerTemplateFile, handlerTemplate)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range routes {
+		filename, err := format.FileNamingFormat(g.cfg.NamingFormat, r.handlerName)
+		if err != nil {
+			return err
+		}
+
+		err = util.With("handler").GoFmt(true).Parse(text).SaveTo(map[string]interface{}{
+			"handlerName": r.handlerName,
+			"svcImport":   g.pkg(g.svcDir),
+			"pbPackage":   g.proto.PbPackage,
+			"pbImport":    g.pkg(g.pbDir),
+			"requestType": parser.CamelCase(r.rpc.RequestType),
+			"service":     parser.CamelCase(r.service.Name),
+			"rpc":         parser.CamelCase(r.rpc.Name),
+		}, filepath.Join(g.handlerDir, filename+".go"), false)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) genMetadata() error {
+	text, err := util.LoadTemplate(category, metadataTemplateFile, metadataTemplate)
+	if err != nil {
+		return err
+	}
+
+	filename, err := format.FileNamingFormat(g.cfg.NamingFormat, "metadata")
+	if err != nil {
+		return err
+	}
+
+	return util.With("metadata").GoFmt(true).Parse(text).SaveTo(nil,
+		filepath.Join(g.handlerDir, filename+".go"), false)
+}
+
+func (g *generator) genRoutes(routes []route) error {
+	text, err := util.LoadTemplate(category, routesTemplateFile, routesTemplate)
+	if err != nil {
+		return err
+	}
+
+	var builder strings.Builder
+	for _, r := range routes {
+		buffer, err := util.With("routeItem").Parse(routeItemTemplate).Execute(map[string]interface{}{
+			"method":      r.method,
+			"path":        r.path,
+			"handlerName": r.handlerName,
+		})
+		if err != nil {
+			return err
+		}
+
+		builder.WriteString(buffer.String())
+	}
+
+	filename, err := format.FileNamingFormat(g.cfg.NamingFormat, "routes")
+	if err != nil {
+		return err
+	}
+
+	return util.With("routes").GoFmt(true).Parse(text).SaveTo(map[string]interface{}{
+		"svcImport": g.pkg(g.svcDir),
+		"routes":    builder.String(),
+	}, filepath.Join(g.handlerDir, filename+".go"), true)
+}
+
+func (g *generator) genMain() error {
+	text, err := util.LoadTemplate(category, mainTemplateFile, mainTemplate)
+	if err != nil {
+		return err
+	}
+
+	filename, err := format.FileNamingFormat(g.cfg.NamingFormat, g.serviceName)
+	if err != nil {
+		return err
+	}
+
+	return util.With("main").GoFmt(true).Parse(text).SaveTo(map[string]interface{}{
+		"serviceName":   g.serviceName,
+		"configImport":  g.pkg(g.configDir),
+		"handlerImport": g.pkg(g.handlerDir),
+		"svcImport":     g.pkg(g.svcDir),
+	}, filepath.Join(g.ctx.WorkDir, filename+".go"), false)
+}
+
+// pkg returns the import path of the given directory in the project
+func (g *generator) pkg(dir string) string {
+	return filepath.ToSlash(filepath.Join(g.ctx.Path, strings.TrimPrefix(dir, g.ctx.Dir)))
+}
@@ -0,0 +1,151 @@
+package gateway
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	conf "github.com/tal-tech/go-zero/tools/goctl/config"
+	"github.com/tal-tech/go-zero/tools/goctl/rpc/parser"
+	"github.com/tal-tech/go-zero/tools/goctl/util/ctx"
+)
+
+const testProto = `
+syntax = "proto3";
+
+package user;
+option go_package = "pb";
+
+message LoginRequest {
+  string username = 1;
+  string password = 2;
+}
+
+message LoginResponse {
+  string token = 1;
+}
+
+service UserService {
+  rpc Login(LoginRequest) returns (LoginResponse);
+}
+`
+
+const testMapping = `Prefix: /api
+Routes:
+- Method: post
+  Path: /user/login
+  Rpc: Login
+`
+
+func setupGenerator(t *testing.T) (*generator, parser.Proto) {
+	dir := t.TempDir()
+	protoFile := filepath.Join(dir, "user.proto")
+	assert.Nil(t, ioutil.WriteFile(protoFile, []byte(testProto), os.ModePerm))
+
+	proto, err := parser.NewDefaultProtoParser().Parse(protoFile)
+	assert.Nil(t, err)
+
+	cfg, err := conf.NewConfig("gozero")
+	assert.Nil(t, err)
+
+	projectCtx := &ctx.ProjectContext{
+		WorkDir: dir,
+		Name:    "user",
+		Path:    "user",
+		Dir:     dir,
+	}
+
+	return newGenerator(projectCtx, proto, cfg), proto
+}
+
+func TestLoadMapping(t *testing.T) {
+	dir := t.TempDir()
+	mappingFile := filepath.Join(dir, "gateway.yaml")
+	assert.Nil(t, ioutil.WriteFile(mappingFile, []byte(testMapping), os.ModePerm))
+
+	mapping, err := LoadMapping(mappingFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "/api", mapping.Prefix)
+	assert.Equal(t, 1, len(mapping.Routes))
+	assert.Equal(t, "post", mapping.Routes[0].Method)
+	assert.Equal(t, "/user/login", mapping.Routes[0].Path)
+	assert.Equal(t, "Login", mapping.Routes[0].Rpc)
+}
+
+func TestBuildRoutes(t *testing.T) {
+	_, proto := setupGenerator(t)
+
+	routes, err := buildRoutes(proto, Mapping{
+		Prefix: "api",
+		Routes: []RouteMapping{
+			{Method: "post", Path: "/user/login", Rpc: "Login"},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(routes))
+	assert.Equal(t, "http.MethodPost", routes[0].method)
+	assert.Equal(t, "/api/user/login", routes[0].path)
+	assert.Equal(t, "LoginHandler", routes[0].handlerName)
+
+	_, err = buildRoutes(proto, Mapping{
+		Routes: []RouteMapping{{Method: "post", Path: "/user/login", Rpc: "Logout"}},
+	})
+	assert.NotNil(t, err)
+
+	_, err = buildRoutes(proto, Mapping{
+		Routes: []RouteMapping{{Method: "trace", Path: "/user/login", Rpc: "Login"}},
+	})
+	assert.NotNil(t, err)
+
+	_, err = buildRoutes(proto, Mapping{
+		Routes: []RouteMapping{{Method: "post", Path: "user/login", Rpc: "Login"}},
+	})
+	assert.NotNil(t, err)
+}
+
+func TestGatewayGenerate(t *testing.T) {
+	g, proto := setupGenerator(t)
+
+	routes, err := buildRoutes(proto, Mapping{
+		Prefix: "/api",
+		Routes: []RouteMapping{
+			{Method: "post", Path: "/user/login", Rpc: "Login"},
+		},
+	})
+	assert.Nil(t, err)
+
+	for _, dir := range []string{g.etcDir, g.configDir, g.svcDir, g.handlerDir} {
+		assert.Nil(t, os.MkdirAll(dir, os.ModePerm))
+	}
+
+	assert.Nil(t, g.genEtc())
+	assert.Nil(t, g.genConfig())
+	assert.Nil(t, g.genSvc())
+	assert.Nil(t, g.genHandlers(routes))
+	assert.Nil(t, g.genMetadata())
+	assert.Nil(t, g.genRoutes(routes))
+	assert.Nil(t, g.genMain())
+
+	svcContent, err := ioutil.ReadFile(filepath.Join(g.svcDir, "servicecontext.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(svcContent), "UserService pb.UserServiceClient")
+	assert.Contains(t, string(svcContent), "UserService: pb.NewUserServiceClient(conn)")
+
+	handlerContent, err := ioutil.ReadFile(filepath.Join(g.handlerDir, "loginhandler.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(handlerContent), "var req pb.LoginRequest")
+	assert.Contains(t, string(handlerContent), "svcCtx.UserService.Login(requestWithMetadata(r), &req)")
+
+	routesContent, err := ioutil.ReadFile(filepath.Join(g.handlerDir, "routes.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(routesContent), "Method:  http.MethodPost")
+	assert.Contains(t, string(routesContent), `Path:    "/api/user/login"`)
+	assert.Contains(t, string(routesContent), "Handler: LoginHandler(serverCtx)")
+
+	mainContent, err := ioutil.ReadFile(filepath.Join(g.ctx.WorkDir, "user.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(mainContent), "httpx.SetErrorHandler(grpcErrorHandler)")
+	assert.Contains(t, string(mainContent), "handler.RegisterHandlers(server, ctx)")
+}
@@ -0,0 +1,135 @@
+package gateway
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tal-tech/go-zero/core/conf"
+	"github.com/tal-tech/go-zero/tools/goctl/rpc/parser"
+)
+
+var methodMapping = map[string]string{
+	"delete": "http.MethodDelete",
+	"get":    "http.MethodGet",
+	"head":   "http.MethodHead",
+	"post":   "http.MethodPost",
+	"put":    "http.MethodPut",
+	"patch":  "http.MethodPatch",
+}
+
+type (
+	// RouteMapping maps an rpc method of the proto to an http route
+	RouteMapping struct {
+		// Method is the http method, eg: get, post
+		Method string
+		// Path is the http path of the route
+		Path string
+		// Service is the rpc service that owns the rpc method,
+		// it can be omitted when the proto defines only one service
+		Service string `json:",optional"`
+		// Rpc is the name of the rpc method to transcode to
+		Rpc string
+	}
+
+	// Mapping describes the http routes exposed by the gateway
+	Mapping struct {
+		// Prefix is the path prefix of all the routes, eg: /api
+		Prefix string `json:",optional"`
+		// Routes are the http routes of the gateway
+		Routes []RouteMapping
+	}
+
+	// route is a resolved route mapping against the proto
+	route struct {
+		method      string
+		path        string
+		service     parser.Service
+		rpc         *parser.RPC
+		handlerName string
+	}
+)
+
+// LoadMapping loads and parses the route mapping file, yaml and json are both supported
+func LoadMapping(file string) (Mapping, error) {
+	var m Mapping
+	if err := conf.LoadConfig(file, &m); err != nil {
+		return Mapping{}, err
+	}
+
+	if len(m.Routes) == 0 {
+		return Mapping{}, fmt.Errorf("no routes found in %s", file)
+	}
+
+	return m, nil
+}
+
+// buildRoutes resolves the route mappings against the rpc methods in the proto
+func buildRoutes(proto parser.Proto, mapping Mapping) ([]route, error) {
+	prefix := strings.TrimSuffix(mapping.Prefix, "/")
+	if len(prefix) > 0 && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+
+	var routes []route
+	for _, item := range mapping.Routes {
+		if _, ok := methodMapping[strings.ToLower(item.Method)]; !ok {
+			return nil, fmt.Errorf("unsupported method: %s", item.Method)
+		}
+		if !strings.HasPrefix(item.Path, "/") {
+			return nil, fmt.Errorf("path %q must start with /", item.Path)
+		}
+
+		service, err := findService(proto, item.Service)
+		if err != nil {
+			return nil, err
+		}
+
+		rpc, err := findRpc(service, item.Rpc)
+		if err != nil {
+			return nil, err
+		}
+
+		handlerName := parser.CamelCase(rpc.Name) + "Handler"
+		if proto.IsMultiServices() {
+			handlerName = parser.CamelCase(service.Name) + handlerName
+		}
+
+		routes = append(routes, route{
+			method:      methodMapping[strings.ToLower(item.Method)],
+			path:        prefix + item.Path,
+			service:     service,
+			rpc:         rpc,
+			handlerName: handlerName,
+		})
+	}
+
+	return routes, nil
+}
+
+func findService(proto parser.Proto, name string) (parser.Service, error) {
+	if len(name) == 0 {
+		if proto.IsMultiServices() {
+			return parser.Service{}, fmt.Errorf(
+				"the proto defines multiple services, the service of the route must be specified")
+		}
+		return proto.Service, nil
+	}
+
+	for _, service := range proto.Services {
+		if service.Name == name {
+			return service, nil
+		}
+	}
+
+	return parser.Service{}, fmt.Errorf("service %s not found in %s", name, proto.Name)
+}
+
+func findRpc(service parser.Service, name string) (*parser.RPC, error) {
+	for _, rpc := range service.RPC {
+		if rpc.Name == name {
+			return rpc, nil
+		}
+	}
+
+	return nil, fmt.Errorf("rpc %s not found in service %s", name, service.Name)
+}
@@ -0,0 +1,275 @@
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/tal-tech/go-zero/tools/goctl/util"
+	"github.com/urfave/cli"
+)
+
+const (
+	category             = "gateway"
+	etcTemplateFile      = "etc.tpl"
+	configTemplateFile   = "config.tpl"
+	svcTemplateFile      = "svc.tpl"
+	handlerTemplateFile  = "handler.tpl"
+	metadataTemplateFile = "metadata.tpl"
+	routesTemplateFile   = "routes.tpl"
+	mainTemplateFile     = "main.tpl"
+)
+
+const etcTemplate = `Name: {{.serviceName}}-gateway
+Host: 0.0.0.0
+Port: 8888
+Rpc:
+  Etcd:
+    Hosts:
+    - 127.0.0.1:2379
+    Key: {{.serviceName}}.rpc
+`
+
+const configTemplate = `package config
+
+import (
+	"github.com/tal-tech/go-zero/rest"
+	"github.com/tal-tech/go-zero/zrpc"
+)
+
+type Config struct {
+	rest.RestConf
+	Rpc zrpc.RpcClientConf
+}
+`
+
+const svcTemplate = `package svc
+
+import (
+	"{{.configImport}}"
+	{{.pbPackage}} "{{.pbImport}}"
+
+	"github.com/tal-tech/go-zero/zrpc"
+)
+
+type ServiceContext struct {
+	Config config.Config
+{{range .services}}	{{.}} {{$.pbPackage}}.{{.}}Client
+{{end}}}
+
+func NewServiceContext(c config.Config) *ServiceContext {
+	conn := zrpc.MustNewClient(c.Rpc).Conn()
+	return &ServiceContext{
+		Config: c,
+{{range .services}}		{{.}}: {{$.pbPackage}}.New{{.}}Client(conn),
+{{end}}	}
+}
+`
+
+const handlerTemplate = `package handler
+
+import (
+	"net/http"
+
+	"{{.svcImport}}"
+	{{.pbPackage}} "{{.pbImport}}"
+
+	"github.com/tal-tech/go-zero/rest/httpx"
+)
+
+// {{.handlerName}} transcodes the http request into the {{.service}}.{{.rpc}} rpc call.
+func {{.handlerName}}(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req {{.pbPackage}}.{{.requestType}}
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.Error(w, err)
+			return
+		}
+
+		resp, err := svcCtx.{{.service}}.{{.rpc}}(requestWithMetadata(r), &req)
+		if err != nil {
+			httpx.Error(w, err)
+		} else {
+			httpx.OkJson(w, resp)
+		}
+	}
+}
+`
+
+const metadataTemplate = `package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// metadataHeaderPrefix marks the http headers to be propagated to the rpc server.
+const metadataHeaderPrefix = "Grpc-Metadata-"
+
+// requestWithMetadata converts the prefixed http headers into the outgoing grpc metadata.
+func requestWithMetadata(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for name, values := range r.Header {
+		if strings.HasPrefix(name, metadataHeaderPrefix) {
+			key := strings.ToLower(strings.TrimPrefix(name, metadataHeaderPrefix))
+			md[key] = append(md[key], values...)
+		}
+	}
+
+	if len(md) == 0 {
+		return r.Context()
+	}
+
+	return metadata.NewOutgoingContext(r.Context(), md)
+}
+`
+
+const routesTemplate = `// Code generated by goctl. DO NOT EDIT.
+package handler
+
+import (
+	"net/http"
+
+	"{{.svcImport}}"
+
+	"github.com/tal-tech/go-zero/rest"
+)
+
+func RegisterHandlers(engine *rest.Server, serverCtx *svc.ServiceContext) {
+	engine.AddRoutes(
+		[]rest.Route{
+{{.routes}}		},
+	)
+}
+`
+
+const routeItemTemplate = `			{
+				Method:  {{.method}},
+				Path:    "{{.path}}",
+				Handler: {{.handlerName}}(serverCtx),
+			},
+`
+
+const mainTemplate = `package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+
+	"{{.configImport}}"
+	"{{.handlerImport}}"
+	"{{.svcImport}}"
+
+	"github.com/tal-tech/go-zero/core/conf"
+	"github.com/tal-tech/go-zero/rest"
+	"github.com/tal-tech/go-zero/rest/httpx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var configFile = flag.String("f", "etc/{{.serviceName}}-gateway.yaml", "the config file")
+
+func main() {
+	flag.Parse()
+
+	var c config.Config
+	conf.MustLoad(*configFile, &c)
+
+	ctx := svc.NewServiceContext(c)
+	server := rest.MustNewServer(c.RestConf)
+	defer server.Stop()
+
+	httpx.SetErrorHandler(grpcErrorHandler)
+	handler.RegisterHandlers(server, ctx)
+
+	fmt.Printf("Starting gateway at %s:%d...\n", c.Host, c.Port)
+	server.Start()
+}
+
+// grpcErrorHandler maps the errors returned by the rpc server to http status codes.
+func grpcErrorHandler(err error) (int, interface{}) {
+	if st, ok := status.FromError(err); ok {
+		return httpStatus(st.Code()), st.Message()
+	}
+
+	return http.StatusInternalServerError, err.Error()
+}
+
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+`
+
+var templates = map[string]string{
+	etcTemplateFile:      etcTemplate,
+	configTemplateFile:   configTemplate,
+	svcTemplateFile:      svcTemplate,
+	handlerTemplateFile:  handlerTemplate,
+	metadataTemplateFile: metadataTemplate,
+	routesTemplateFile:   routesTemplate,
+	mainTemplateFile:     mainTemplate,
+}
+
+// Category returns the const string of gateway category
+func Category() string {
+	return category
+}
+
+// BuiltinTemplates returns the builtin templates of the gateway category
+func BuiltinTemplates() map[string]string {
+	return templates
+}
+
+// Clean deletes all gateway template files
+func Clean() error {
+	return util.Clean(category)
+}
+
+// GenTemplates creates gateway template files
+func GenTemplates(_ *cli.Context) error {
+	return util.InitTemplates(category, templates)
+}
+
+// RevertTemplate recovers the deleted template files
+func RevertTemplate(name string) error {
+	content, ok := templates[name]
+	if !ok {
+		return fmt.Errorf("unexpected template name: %s", name)
+	}
+	return util.CreateTemplate(category, name, content)
+}
+
+// Update deletes and creates new template files
+func Update() error {
+	err := Clean()
+	if err != nil {
+		return err
+	}
+
+	return util.InitTemplates(category, templates)
+}
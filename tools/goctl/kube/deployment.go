@@ -47,13 +47,23 @@ spec:
         volumeMounts:
         - name: timezone
           mountPath: /etc/localtime
-      {{if .Secret}}imagePullSecrets:
+{{if .ConfigMap}}        - name: config
+          mountPath: {{.ConfigMountPath}}
+{{end}}{{if .SecretMount}}        - name: secret
+          mountPath: {{.SecretMountPath}}
+{{end}}      {{if .Secret}}imagePullSecrets:
       - name: {{.Secret}}
       {{end}}volumes:
         - name: timezone
           hostPath:
             path: /usr/share/zoneinfo/Asia/Shanghai
-
+{{if .ConfigMap}}        - name: config
+          configMap:
+            name: {{.ConfigMap}}
+{{end}}{{if .SecretMount}}        - name: secret
+          secret:
+            secretName: {{.SecretMount}}
+{{end}}
 ---
 
 apiVersion: v1
@@ -67,10 +77,11 @@ spec:
       port: {{.Port}}
       protocol: TCP
       targetPort: {{.Port}}
-  type: NodePort{{else}}- port: {{.Port}}{{end}}
+  type: NodePort{{else}}- port: {{.Port}}{{if .ServiceType}}
+  type: {{.ServiceType}}{{end}}{{end}}
   selector:
     app: {{.Name}}
-
+{{if .UseHpa}}
 ---
 
 apiVersion: autoscaling/v2beta1
@@ -114,4 +125,4 @@ spec:
     resource:
       name: memory
       targetAverageUtilization: 80
-`
+{{end}}`
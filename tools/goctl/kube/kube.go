@@ -3,6 +3,7 @@ package kube
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"text/template"
 
 	"github.com/logrusorgru/aurora"
@@ -20,21 +21,27 @@ const (
 
 // Deployment describes the k8s deployment yaml
 type Deployment struct {
-	Name        string
-	Namespace   string
-	Image       string
-	Secret      string
-	Replicas    int
-	Revisions   int
-	Port        int
-	NodePort    int
-	UseNodePort bool
-	RequestCpu  int
-	RequestMem  int
-	LimitCpu    int
-	LimitMem    int
-	MinReplicas int
-	MaxReplicas int
+	Name            string
+	Namespace       string
+	Image           string
+	Secret          string
+	Replicas        int
+	Revisions       int
+	Port            int
+	NodePort        int
+	UseNodePort     bool
+	RequestCpu      int
+	RequestMem      int
+	LimitCpu        int
+	LimitMem        int
+	MinReplicas     int
+	MaxReplicas     int
+	UseHpa          bool
+	ServiceType     string
+	ConfigMap       string
+	ConfigMountPath string
+	SecretMount     string
+	SecretMountPath string
 }
 
 // DeploymentCommand is used to generate the kubernetes deployment yaml files.
@@ -56,23 +63,34 @@ func DeploymentCommand(c *cli.Context) error {
 	}
 	defer out.Close()
 
+	image := c.String("image")
+	if registry := c.String("registry"); len(registry) > 0 {
+		image = strings.TrimSuffix(registry, "/") + "/" + image
+	}
+
 	t := template.Must(template.New("deploymentTemplate").Parse(text))
 	err = t.Execute(out, Deployment{
-		Name:        c.String("name"),
-		Namespace:   c.String("namespace"),
-		Image:       c.String("image"),
-		Secret:      c.String("secret"),
-		Replicas:    c.Int("replicas"),
-		Revisions:   c.Int("revisions"),
-		Port:        c.Int("port"),
-		NodePort:    nodePort,
-		UseNodePort: nodePort > 0,
-		RequestCpu:  c.Int("requestCpu"),
-		RequestMem:  c.Int("requestMem"),
-		LimitCpu:    c.Int("limitCpu"),
-		LimitMem:    c.Int("limitMem"),
-		MinReplicas: c.Int("minReplicas"),
-		MaxReplicas: c.Int("maxReplicas"),
+		Name:            c.String("name"),
+		Namespace:       c.String("namespace"),
+		Image:           image,
+		Secret:          c.String("secret"),
+		Replicas:        c.Int("replicas"),
+		Revisions:       c.Int("revisions"),
+		Port:            c.Int("port"),
+		NodePort:        nodePort,
+		UseNodePort:     nodePort > 0,
+		RequestCpu:      c.Int("requestCpu"),
+		RequestMem:      c.Int("requestMem"),
+		LimitCpu:        c.Int("limitCpu"),
+		LimitMem:        c.Int("limitMem"),
+		MinReplicas:     c.Int("minReplicas"),
+		MaxReplicas:     c.Int("maxReplicas"),
+		UseHpa:          !c.Bool("disableHpa"),
+		ServiceType:     c.String("serviceType"),
+		ConfigMap:       c.String("configMap"),
+		ConfigMountPath: c.String("configMountPath"),
+		SecretMount:     c.String("secretMount"),
+		SecretMountPath: c.String("secretMountPath"),
 	})
 	if err != nil {
 		return err
@@ -82,11 +100,21 @@ func DeploymentCommand(c *cli.Context) error {
 	return nil
 }
 
+var templates = map[string]string{
+	deployTemplateFile: deploymentTemplate,
+	jobTemplateFile:    jobTmeplate,
+}
+
 // Category returns the category of the deployments.
 func Category() string {
 	return category
 }
 
+// BuiltinTemplates returns the builtin templates of the kube category.
+func BuiltinTemplates() map[string]string {
+	return templates
+}
+
 // Clean cleans the generated deployment files.
 func Clean() error {
 	return util.Clean(category)
@@ -94,10 +122,7 @@ func Clean() error {
 
 // GenTemplates generates the deployment template files.
 func GenTemplates(_ *cli.Context) error {
-	return util.InitTemplates(category, map[string]string{
-		deployTemplateFile: deploymentTemplate,
-		jobTemplateFile:    jobTmeplate,
-	})
+	return util.InitTemplates(category, templates)
 }
 
 // RevertTemplate reverts the given template file to the default value.
@@ -112,8 +137,5 @@ func Update() error {
 		return err
 	}
 
-	return util.InitTemplates(category, map[string]string{
-		deployTemplateFile: deploymentTemplate,
-		jobTemplateFile:    jobTmeplate,
-	})
+	return util.InitTemplates(category, templates)
 }
@@ -2,20 +2,24 @@ package generate
 
 import (
 	"errors"
+	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/tal-tech/go-zero/tools/goctl/config"
 	"github.com/tal-tech/go-zero/tools/goctl/model/mongo/template"
 	"github.com/tal-tech/go-zero/tools/goctl/util"
 	"github.com/tal-tech/go-zero/tools/goctl/util/format"
+	"github.com/tal-tech/go-zero/tools/goctl/util/stringx"
 )
 
 // Context defines the model generation data what they needs
 type Context struct {
-	Types  []string
-	Cache  bool
-	Output string
-	Cfg    *config.Config
+	Types   []string
+	Cache   bool
+	Indexes []string
+	Output  string
+	Cfg     *config.Config
 }
 
 // Do executes model template and output the result into the specified file path
@@ -29,6 +33,11 @@ func Do(ctx *Context) error {
 		return err
 	}
 
+	err = generateTypes(ctx)
+	if err != nil {
+		return err
+	}
+
 	return generateError(ctx)
 }
 
@@ -46,8 +55,34 @@ func generateModel(ctx *Context) error {
 
 		output := filepath.Join(ctx.Output, fn+".go")
 		err = util.With("model").Parse(text).GoFmt(true).SaveTo(map[string]interface{}{
-			"Type":  t,
-			"Cache": ctx.Cache,
+			"Type":       t,
+			"Cache":      ctx.Cache,
+			"IndexesVar": stringx.From(t).Untitle() + "Indexes",
+			"Indexes":    buildIndexes(ctx.Indexes),
+		}, output, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func generateTypes(ctx *Context) error {
+	for _, t := range ctx.Types {
+		fn, err := format.FileNamingFormat(ctx.Cfg.NamingFormat, t+"_types")
+		if err != nil {
+			return err
+		}
+
+		text, err := util.LoadTemplate(category, typesTemplateFile, template.Types)
+		if err != nil {
+			return err
+		}
+
+		output := filepath.Join(ctx.Output, fn+".go")
+		err = util.With("types").Parse(text).GoFmt(true).SaveTo(map[string]interface{}{
+			"Type": t,
 		}, output, false)
 		if err != nil {
 			return err
@@ -67,3 +102,26 @@ func generateError(ctx *Context) error {
 
 	return util.With("error").Parse(text).GoFmt(true).SaveTo(ctx, output, false)
 }
+
+func buildIndexes(indexes []string) string {
+	var builder strings.Builder
+	for _, index := range indexes {
+		var keys []string
+		for _, key := range strings.Split(index, ",") {
+			key = strings.TrimSpace(key)
+			if len(key) == 0 {
+				continue
+			}
+
+			keys = append(keys, fmt.Sprintf("%q", key))
+		}
+		if len(keys) == 0 {
+			continue
+		}
+
+		builder.WriteString(fmt.Sprintf("\t{\n\t\tKey:        []string{%s},\n\t\tBackground: true,\n\t},\n",
+			strings.Join(keys, ", ")))
+	}
+
+	return builder.String()
+}
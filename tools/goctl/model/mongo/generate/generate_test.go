@@ -32,3 +32,35 @@ func TestDo(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestDoWithIndexes(t *testing.T) {
+	cfg, err := config.NewConfig(config.DefaultFormat)
+	assert.Nil(t, err)
+
+	tempDir := t.TempDir()
+	err = Do(&Context{
+		Types:   []string{"User"},
+		Cache:   true,
+		Indexes: []string{"name", "age,-createAt"},
+		Output:  tempDir,
+		Cfg:     cfg,
+	})
+	assert.Nil(t, err)
+
+	model, err := ioutil.ReadFile(filepath.Join(tempDir, "usermodel.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(model), `Key:        []string{"name"},`)
+	assert.Contains(t, string(model), `Key:        []string{"age", "-createAt"},`)
+	assert.Contains(t, string(model), "func (m *defaultUserModel) EnsureIndexes() error {")
+
+	types, err := ioutil.ReadFile(filepath.Join(tempDir, "usertypes.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(types), "type User struct {")
+	assert.Contains(t, string(types), "ID bson.ObjectId")
+}
+
+func TestBuildIndexes(t *testing.T) {
+	assert.Empty(t, buildIndexes(nil))
+	assert.Equal(t, "\t{\n\t\tKey:        []string{\"name\", \"-age\"},\n\t\tBackground: true,\n\t},\n",
+		buildIndexes([]string{"name, -age"}))
+}
@@ -11,11 +11,13 @@ import (
 const (
 	category          = "mongo"
 	modelTemplateFile = "model.tpl"
+	typesTemplateFile = "types.tpl"
 	errTemplateFile   = "err.tpl"
 )
 
 var templates = map[string]string{
 	modelTemplateFile: template.Text,
+	typesTemplateFile: template.Types,
 	errTemplateFile:   template.Error,
 }
 
@@ -24,6 +26,11 @@ func Category() string {
 	return category
 }
 
+// BuiltinTemplates returns the builtin templates of the mongo category.
+func BuiltinTemplates() map[string]string {
+	return templates
+}
+
 // Clean cleans the mongo templates.
 func Clean() error {
 	return util.Clean(category)
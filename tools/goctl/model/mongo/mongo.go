@@ -31,9 +31,10 @@ func Action(ctx *cli.Context) error {
 	}
 
 	return generate.Do(&generate.Context{
-		Types:  tp,
-		Cache:  c,
-		Output: a,
-		Cfg:    cfg,
+		Types:   tp,
+		Cache:   c,
+		Indexes: ctx.StringSlice("index"),
+		Output:  a,
+		Cfg:     cfg,
 	})
 }
@@ -5,7 +5,9 @@ var Text = `package model
 
 import (
     "context"
+    "log"
 
+    "github.com/globalsign/mgo"
     "github.com/globalsign/mgo/bson"
      cachec "github.com/tal-tech/go-zero/core/stores/cache"
 	"github.com/tal-tech/go-zero/core/stores/mongoc"
@@ -13,21 +15,34 @@ import (
 
 {{if .Cache}}var prefix{{.Type}}CacheKey = "cache#{{.Type}}#"{{end}}
 
+// {{.IndexesVar}} declares the indexes of the {{.Type}} collection,
+// they are ensured when the model is created
+var {{.IndexesVar}} = []mgo.Index{
+{{.Indexes}}}
+
 type {{.Type}}Model interface{
 	Insert(ctx context.Context,data *{{.Type}}) error
 	FindOne(ctx context.Context,id string) (*{{.Type}}, error)
 	Update(ctx context.Context,data *{{.Type}}) error
 	Delete(ctx context.Context,id string) error
+	EnsureIndexes() error
 }
 
 type default{{.Type}}Model struct {
     *mongoc.Model
+    collection string
 }
 
 func New{{.Type}}Model(url, collection string, c cachec.CacheConf) {{.Type}}Model {
-	return &default{{.Type}}Model{
-		Model: mongoc.MustNewModel(url, collection, c),
+	m := &default{{.Type}}Model{
+		Model:      mongoc.MustNewModel(url, collection, c),
+		collection: collection,
+	}
+	if err := m.EnsureIndexes(); err != nil {
+		log.Fatal(err)
 	}
+
+	return m
 }
 
 
@@ -99,6 +114,46 @@ func (m *default{{.Type}}Model) Delete(ctx context.Context, id string) error {
 	return m.GetCollection(session).RemoveIdNoCache(bson.ObjectIdHex(id))
 	{{- end}}
 }
+
+// EnsureIndexes ensures the declared indexes of the collection
+func (m *default{{.Type}}Model) EnsureIndexes() error {
+    if len({{.IndexesVar}}) == 0 {
+        return nil
+    }
+
+    session, err := m.TakeSession()
+    if err != nil {
+        return err
+    }
+
+    defer m.PutSession(session)
+    for _, index := range {{.IndexesVar}} {
+        if err := session.DB("").C(m.collection).EnsureIndex(index); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+`
+
+// Types provides the default template for the type definition in mongo code generation,
+// it only generates a scaffold, the fields should be filled by the developer.
+var Types = `package model
+
+import (
+    "time"
+
+    "github.com/globalsign/mgo/bson"
+)
+
+// {{.Type}} describes a {{.Type}} document
+type {{.Type}} struct {
+	ID bson.ObjectId ` + "`" + `bson:"_id,omitempty" json:"id,omitempty"` + "`" + `
+	// TODO: replace the following fields with your own fields
+	UpdateAt time.Time ` + "`" + `bson:"updateAt,omitempty" json:"updateAt,omitempty"` + "`" + `
+	CreateAt time.Time ` + "`" + `bson:"createAt,omitempty" json:"createAt,omitempty"` + "`" + `
+}
 `
 
 // Error provides the default template for error definition in mongo code generation.
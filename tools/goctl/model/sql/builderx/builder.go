@@ -3,6 +3,7 @@ package builderx
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/go-xorm/builder"
 )
@@ -80,14 +81,20 @@ func FieldNames(in interface{}) []string {
 	return out
 }
 
-// RawFieldNames converts golang struct field into slice string
-func RawFieldNames(in interface{}) []string {
+// RawFieldNames converts golang struct field into slice string,
+// the optional postgresSql flag skips the mysql backquotes.
+func RawFieldNames(in interface{}, postgresSql ...bool) []string {
 	out := make([]string, 0)
 	v := reflect.ValueOf(in)
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
 	}
 
+	var pg bool
+	if len(postgresSql) > 0 {
+		pg = postgresSql[0]
+	}
+
 	// we only accept structs
 	if v.Kind() != reflect.Struct {
 		panic(fmt.Errorf("ToMap only accepts structs; got %T", v))
@@ -98,7 +105,11 @@ func RawFieldNames(in interface{}) []string {
 		// gets us a StructField
 		fi := typ.Field(i)
 		if tagv := fi.Tag.Get(dbTag); tagv != "" {
-			out = append(out, fmt.Sprintf("`%s`", tagv))
+			if pg {
+				out = append(out, tagv)
+			} else {
+				out = append(out, fmt.Sprintf("`%s`", tagv))
+			}
 		} else {
 			out = append(out, fmt.Sprintf(`"%s"`, fi.Name))
 		}
@@ -106,3 +117,18 @@ func RawFieldNames(in interface{}) []string {
 
 	return out
 }
+
+// PostgreSqlJoin concatenates the given elements into a string with
+// $N placeholders, started from 2 to reserve $1 for the primary key.
+func PostgreSqlJoin(elems []string) string {
+	b := new(strings.Builder)
+	for index, e := range elems {
+		b.WriteString(fmt.Sprintf("%s = $%d, ", e, index+2))
+	}
+
+	if b.Len() == 0 {
+		return b.String()
+	}
+
+	return b.String()[0 : b.Len()-2]
+}
@@ -118,3 +118,14 @@ func TestBuildSqlLike(t *testing.T) {
 	assert.Equal(t, sql, actualSQL)
 	assert.Equal(t, args, actualArgs)
 }
+
+func TestPostgreSqlJoin(t *testing.T) {
+	assert.Equal(t, "", PostgreSqlJoin(nil))
+	assert.Equal(t, "id = $2", PostgreSqlJoin([]string{"id"}))
+	assert.Equal(t, "id = $2, name = $3, age = $4", PostgreSqlJoin([]string{"id", "name", "age"}))
+}
+
+func TestRawFieldNamesPostgreSql(t *testing.T) {
+	names := RawFieldNames(&mockedUser{}, true)
+	assert.Equal(t, []string{"id", "user_name", "sex", "uuid", "age"}, names)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/stores/postgres"
 	"github.com/tal-tech/go-zero/core/stores/sqlx"
 	"github.com/tal-tech/go-zero/tools/goctl/config"
 	"github.com/tal-tech/go-zero/tools/goctl/model/sql/gen"
@@ -18,13 +19,16 @@ import (
 )
 
 const (
-	flagSrc   = "src"
-	flagDir   = "dir"
-	flagCache = "cache"
-	flagIdea  = "idea"
-	flagURL   = "url"
-	flagTable = "table"
-	flagStyle = "style"
+	flagSrc    = "src"
+	flagDir    = "dir"
+	flagCache  = "cache"
+	flagIdea   = "idea"
+	flagURL    = "url"
+	flagTable  = "table"
+	flagStyle  = "style"
+	flagSchema = "schema"
+	// flagRelations enables the FindBy helpers for the foreign keys
+	flagRelations = "relations"
 )
 
 var errNotMatched = errors.New("sql not matched")
@@ -44,6 +48,21 @@ func MysqlDDL(ctx *cli.Context) error {
 	return fromDDl(src, dir, cfg, cache, idea)
 }
 
+// PostgreSqlDDL generates postgresql model code from ddl
+func PostgreSqlDDL(ctx *cli.Context) error {
+	src := ctx.String(flagSrc)
+	dir := ctx.String(flagDir)
+	cache := ctx.Bool(flagCache)
+	idea := ctx.Bool(flagIdea)
+	style := ctx.String(flagStyle)
+	cfg, err := config.NewConfig(style)
+	if err != nil {
+		return err
+	}
+
+	return fromDDl(src, dir, cfg, cache, idea, gen.WithPostgreSql())
+}
+
 // MyDataSource generates model code from datasource
 func MyDataSource(ctx *cli.Context) error {
 	url := strings.TrimSpace(ctx.String(flagURL))
@@ -52,15 +71,38 @@ func MyDataSource(ctx *cli.Context) error {
 	idea := ctx.Bool(flagIdea)
 	style := ctx.String(flagStyle)
 	pattern := strings.TrimSpace(ctx.String(flagTable))
+	relations := ctx.Bool(flagRelations)
 	cfg, err := config.NewConfig(style)
 	if err != nil {
 		return err
 	}
 
-	return fromDataSource(url, pattern, dir, cfg, cache, idea)
+	return fromDataSource(url, pattern, dir, cfg, cache, idea, relations)
 }
 
-func fromDDl(src, dir string, cfg *config.Config, cache, idea bool) error {
+// PostgreSqlDataSource generates postgresql model code from datasource
+func PostgreSqlDataSource(ctx *cli.Context) error {
+	url := strings.TrimSpace(ctx.String(flagURL))
+	dir := strings.TrimSpace(ctx.String(flagDir))
+	cache := ctx.Bool(flagCache)
+	idea := ctx.Bool(flagIdea)
+	style := ctx.String(flagStyle)
+	schema := strings.TrimSpace(ctx.String(flagSchema))
+	if len(schema) == 0 {
+		schema = "public"
+	}
+
+	pattern := strings.TrimSpace(ctx.String(flagTable))
+	relations := ctx.Bool(flagRelations)
+	cfg, err := config.NewConfig(style)
+	if err != nil {
+		return err
+	}
+
+	return fromPostgreSqlDataSource(url, pattern, dir, schema, cfg, cache, idea, relations)
+}
+
+func fromDDl(src, dir string, cfg *config.Config, cache, idea bool, opts ...gen.Option) error {
 	log := console.NewConsole(idea)
 	src = strings.TrimSpace(src)
 	if len(src) == 0 {
@@ -86,7 +128,8 @@ func fromDDl(src, dir string, cfg *config.Config, cache, idea bool) error {
 		source = append(source, string(data))
 	}
 
-	generator, err := gen.NewDefaultGenerator(dir, cfg, gen.WithConsoleOption(log))
+	generator, err := gen.NewDefaultGenerator(dir, cfg,
+		append([]gen.Option{gen.WithConsoleOption(log)}, opts...)...)
 	if err != nil {
 		return err
 	}
@@ -94,7 +137,7 @@ func fromDDl(src, dir string, cfg *config.Config, cache, idea bool) error {
 	return generator.StartFromDDL(strings.Join(source, "\n"), cache)
 }
 
-func fromDataSource(url, pattern, dir string, cfg *config.Config, cache, idea bool) error {
+func fromDataSource(url, pattern, dir string, cfg *config.Config, cache, idea, relations bool) error {
 	log := console.NewConsole(idea)
 	if len(url) == 0 {
 		log.Error("%v", "expected data source of mysql, but nothing found")
@@ -142,6 +185,13 @@ func fromDataSource(url, pattern, dir string, cfg *config.Config, cache, idea bo
 			return err
 		}
 
+		if relations {
+			table.ForeignKeys, err = im.FindForeignKeys(dsn.DBName, item)
+			if err != nil {
+				return err
+			}
+		}
+
 		matchTables[item] = table
 	}
 
@@ -156,3 +206,69 @@ func fromDataSource(url, pattern, dir string, cfg *config.Config, cache, idea bo
 
 	return generator.StartFromInformationSchema(matchTables, cache)
 }
+
+func fromPostgreSqlDataSource(url, pattern, dir, schema string, cfg *config.Config,
+	cache, idea, relations bool) error {
+	log := console.NewConsole(idea)
+	if len(url) == 0 {
+		log.Error("%v", "expected data source of postgresql, but nothing found")
+		return nil
+	}
+
+	if len(pattern) == 0 {
+		log.Error("%v", "expected table or table globbing patterns, but nothing found")
+		return nil
+	}
+
+	logx.Disable()
+	db := postgres.New(url)
+	im := model.NewPostgreSqlModel(db)
+
+	tables, err := im.GetAllTables(schema)
+	if err != nil {
+		return err
+	}
+
+	matchTables := make(map[string]*model.Table)
+	for _, item := range tables {
+		match, err := filepath.Match(pattern, item)
+		if err != nil {
+			return err
+		}
+
+		if !match {
+			continue
+		}
+
+		columnData, err := im.FindColumns(schema, item)
+		if err != nil {
+			return err
+		}
+
+		table, err := columnData.Convert()
+		if err != nil {
+			return err
+		}
+
+		if relations {
+			table.ForeignKeys, err = im.FindForeignKeys(schema, item)
+			if err != nil {
+				return err
+			}
+		}
+
+		matchTables[item] = table
+	}
+
+	if len(matchTables) == 0 {
+		return errors.New("no tables matched")
+	}
+
+	generator, err := gen.NewDefaultGenerator(dir, cfg,
+		gen.WithConsoleOption(log), gen.WithPostgreSql())
+	if err != nil {
+		return err
+	}
+
+	return generator.StartFromInformationSchema(matchTables, cache)
+}
@@ -39,6 +39,56 @@ var commonMysqlDataTypeMap = map[string]string{
 	"json":       "string",
 }
 
+var commonPostgreSqlDataTypeMap = map[string]string{
+	// number
+	"bool":             "bool",
+	"boolean":          "bool",
+	"smallint":         "int64",
+	"int2":             "int64",
+	"int":              "int64",
+	"int4":             "int64",
+	"integer":          "int64",
+	"bigint":           "int64",
+	"int8":             "int64",
+	"smallserial":      "int64",
+	"serial":           "int64",
+	"bigserial":        "int64",
+	"real":             "float64",
+	"float":            "float64",
+	"float4":           "float64",
+	"double":           "float64",
+	"double precision": "float64",
+	"float8":           "float64",
+	"numeric":          "float64",
+	"decimal":          "float64",
+	"money":            "float64",
+	// date&time
+	"date":                        "time.Time",
+	"timestamp":                   "time.Time",
+	"timestamptz":                 "time.Time",
+	"timestamp with time zone":    "time.Time",
+	"timestamp without time zone": "time.Time",
+	"time":                        "string",
+	"timetz":                      "string",
+	"time with time zone":         "string",
+	"time without time zone":      "string",
+	"interval":                    "string",
+	// string
+	"char":              "string",
+	"character":         "string",
+	"varchar":           "string",
+	"character varying": "string",
+	"text":              "string",
+	"bytea":             "string",
+	"uuid":              "string",
+	"cidr":              "string",
+	"inet":              "string",
+	"macaddr":           "string",
+	"json":              "string",
+	"jsonb":             "string",
+	"xml":               "string",
+}
+
 // ConvertDataType converts mysql column type into golang type
 func ConvertDataType(dataBaseType string, isDefaultNull bool) (string, error) {
 	tp, ok := commonMysqlDataTypeMap[strings.ToLower(dataBaseType)]
@@ -49,6 +99,16 @@ func ConvertDataType(dataBaseType string, isDefaultNull bool) (string, error) {
 	return mayConvertNullType(tp, isDefaultNull), nil
 }
 
+// ConvertPostgreSqlDataType converts postgresql column type into golang type
+func ConvertPostgreSqlDataType(dataBaseType string, isDefaultNull bool) (string, error) {
+	tp, ok := commonPostgreSqlDataTypeMap[strings.ToLower(dataBaseType)]
+	if !ok {
+		return "", fmt.Errorf("unexpected database type: %s", dataBaseType)
+	}
+
+	return mayConvertNullType(tp, isDefaultNull), nil
+}
+
 func mayConvertNullType(goDataType string, isDefaultNull bool) string {
 	if !isDefaultNull {
 		return goDataType
@@ -26,3 +26,24 @@ func TestConvertDataType(t *testing.T) {
 	_, err = ConvertDataType("float32", false)
 	assert.NotNil(t, err)
 }
+
+func TestConvertPostgreSqlDataType(t *testing.T) {
+	v, err := ConvertPostgreSqlDataType("bigserial", false)
+	assert.Nil(t, err)
+	assert.Equal(t, "int64", v)
+
+	v, err = ConvertPostgreSqlDataType("jsonb", false)
+	assert.Nil(t, err)
+	assert.Equal(t, "string", v)
+
+	v, err = ConvertPostgreSqlDataType("timestamptz", true)
+	assert.Nil(t, err)
+	assert.Equal(t, "sql.NullTime", v)
+
+	v, err = ConvertPostgreSqlDataType("boolean", false)
+	assert.Nil(t, err)
+	assert.Equal(t, "bool", v)
+
+	_, err = ConvertPostgreSqlDataType("tsvector", false)
+	assert.NotNil(t, err)
+}
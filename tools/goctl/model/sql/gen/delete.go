@@ -34,7 +34,8 @@ func genDelete(table Table, withCache bool) (string, string, error) {
 			"lowerStartCamelPrimaryKey": stringx.From(table.PrimaryKey.Name.ToCamel()).Untitle(),
 			"dataType":                  table.PrimaryKey.DataType,
 			"keys":                      strings.Join(keySet.KeysStr(), "\n"),
-			"originalPrimaryKey":        wrapWithRawString(table.PrimaryKey.Name.Source()),
+			"originalPrimaryKey":        wrapWithRawString(table.PrimaryKey.Name.Source(), table.PostgreSql),
+			"postgreSql":                table.PostgreSql,
 			"keyValues":                 strings.Join(keyVariableSet.KeysStr(), ", "),
 		})
 	if err != nil {
@@ -0,0 +1,67 @@
+package gen
+
+import (
+	"strings"
+
+	"github.com/tal-tech/go-zero/tools/goctl/model/sql/template"
+	"github.com/tal-tech/go-zero/tools/goctl/util"
+	"github.com/tal-tech/go-zero/tools/goctl/util/stringx"
+)
+
+// genFindManyByField generates the FindBy methods for the foreign keys,
+// the lookups return slices and bypass the cache.
+func genFindManyByField(table Table, withCache bool) (string, string, error) {
+	if len(table.ForeignKeys) == 0 {
+		return "", "", nil
+	}
+
+	text, err := util.LoadTemplate(category, findManyByFieldTemplateFile, template.FindManyByField)
+	if err != nil {
+		return "", "", err
+	}
+
+	camelTableName := table.Name.ToCamel()
+	t := util.With("findManyByField").Parse(text)
+	var list []string
+	for _, key := range table.ForeignKeys {
+		output, err := t.Execute(map[string]interface{}{
+			"upperStartCamelObject": camelTableName,
+			"lowerStartCamelObject": stringx.From(camelTableName).Untitle(),
+			"upperField":            key.Field.Name.ToCamel(),
+			"lowerField":            stringx.From(key.Field.Name.ToCamel()).Untitle(),
+			"dataType":              key.Field.DataType,
+			"originalField":         wrapWithRawString(key.Field.Name.Source(), table.PostgreSql),
+			"withCache":             withCache,
+			"postgreSql":            table.PostgreSql,
+		})
+		if err != nil {
+			return "", "", err
+		}
+
+		list = append(list, output.String())
+	}
+
+	text, err = util.LoadTemplate(category, findManyByFieldMethodTemplateFile,
+		template.FindManyByFieldMethod)
+	if err != nil {
+		return "", "", err
+	}
+
+	t = util.With("findManyByFieldMethod").Parse(text)
+	var listMethod []string
+	for _, key := range table.ForeignKeys {
+		output, err := t.Execute(map[string]interface{}{
+			"upperStartCamelObject": camelTableName,
+			"upperField":            key.Field.Name.ToCamel(),
+			"lowerField":            stringx.From(key.Field.Name.ToCamel()).Untitle(),
+			"dataType":              key.Field.DataType,
+		})
+		if err != nil {
+			return "", "", err
+		}
+
+		listMethod = append(listMethod, output.String())
+	}
+
+	return strings.Join(list, util.NL), strings.Join(listMethod, util.NL), nil
+}
@@ -0,0 +1,48 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/tools/goctl/model/sql/parser"
+	"github.com/tal-tech/go-zero/tools/goctl/util/stringx"
+)
+
+func TestGenFindManyByField(t *testing.T) {
+	userIdField := &parser.Field{
+		Name:     stringx.From("user_id"),
+		DataType: "int64",
+	}
+	table := Table{
+		Table: parser.Table{
+			Name: stringx.From("order"),
+			ForeignKeys: []*parser.ForeignKey{
+				{
+					Field:           userIdField,
+					ReferencedTable: stringx.From("user"),
+					ReferencedField: stringx.From("id"),
+				},
+			},
+		},
+	}
+
+	code, method, err := genFindManyByField(table, false)
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(code, "func (m *defaultOrderModel) FindByUserId(userId int64) ([]Order, error)"))
+	assert.True(t, strings.Contains(code, "where `user_id` = ?"))
+	assert.True(t, strings.Contains(code, "m.conn.QueryRows(&resp, query, userId)"))
+	assert.Equal(t, "FindByUserId(userId int64) ([]Order, error)", method)
+
+	table.PostgreSql = true
+	code, _, err = genFindManyByField(table, true)
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(code, "where user_id = $1"))
+	assert.True(t, strings.Contains(code, "m.QueryRowsNoCache(&resp, query, userId)"))
+
+	table.ForeignKeys = nil
+	code, method, err = genFindManyByField(table, false)
+	assert.Nil(t, err)
+	assert.Empty(t, code)
+	assert.Empty(t, method)
+}
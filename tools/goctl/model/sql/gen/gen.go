@@ -29,8 +29,9 @@ type (
 		// source string
 		dir string
 		console.Console
-		pkg string
-		cfg *config.Config
+		pkg          string
+		cfg          *config.Config
+		isPostgreSql bool
 	}
 
 	// Option defines a function with argument defaultGenerator
@@ -84,6 +85,14 @@ func WithConsoleOption(c console.Console) Option {
 	}
 }
 
+// WithPostgreSql generates model code with $N placeholders and
+// postgresql type mapping instead of the mysql defaults
+func WithPostgreSql() Option {
+	return func(generator *defaultGenerator) {
+		generator.isPostgreSql = true
+	}
+}
+
 func newDefaultOption() Option {
 	return func(generator *defaultGenerator) {
 		generator.Console = console.NewColorConsole()
@@ -102,7 +111,13 @@ func (g *defaultGenerator) StartFromDDL(source string, withCache bool) error {
 func (g *defaultGenerator) StartFromInformationSchema(tables map[string]*model.Table, withCache bool) error {
 	m := make(map[string]string)
 	for _, each := range tables {
-		table, err := parser.ConvertDataType(each)
+		var table *parser.Table
+		var err error
+		if g.isPostgreSql {
+			table, err = parser.ConvertPostgreSqlDataType(each)
+		} else {
+			table, err = parser.ConvertDataType(each)
+		}
 		if err != nil {
 			return err
 		}
@@ -178,7 +193,13 @@ func (g *defaultGenerator) genFromDDL(source string, withCache bool) (map[string
 	ddlList := g.split(source)
 	m := make(map[string]string)
 	for _, ddl := range ddlList {
-		table, err := parser.Parse(ddl)
+		var table *parser.Table
+		var err error
+		if g.isPostgreSql {
+			table, err = parser.ParsePostgreSqlDDL(ddl)
+		} else {
+			table, err = parser.Parse(ddl)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -200,6 +221,7 @@ type Table struct {
 	PrimaryCacheKey        Key
 	UniqueCacheKey         []Key
 	ContainsUniqueCacheKey bool
+	PostgreSql             bool
 }
 
 func (g *defaultGenerator) genModel(in parser.Table, withCache bool) (string, error) {
@@ -219,6 +241,7 @@ func (g *defaultGenerator) genModel(in parser.Table, withCache bool) (string, er
 	table.PrimaryCacheKey = primaryKey
 	table.UniqueCacheKey = uniqueKey
 	table.ContainsUniqueCacheKey = len(uniqueKey) > 0
+	table.PostgreSql = g.isPostgreSql
 
 	varsCode, err := genVars(table, withCache)
 	if err != nil {
@@ -241,7 +264,12 @@ func (g *defaultGenerator) genModel(in parser.Table, withCache bool) (string, er
 		return "", err
 	}
 
-	findCode = append(findCode, findOneCode, ret.findOneMethod)
+	findManyCode, findManyCodeMethod, err := genFindManyByField(table, withCache)
+	if err != nil {
+		return "", err
+	}
+
+	findCode = append(findCode, findOneCode, ret.findOneMethod, findManyCode)
 	updateCode, updateCodeMethod, err := genUpdate(table, withCache)
 	if err != nil {
 		return "", err
@@ -253,7 +281,8 @@ func (g *defaultGenerator) genModel(in parser.Table, withCache bool) (string, er
 	}
 
 	var list []string
-	list = append(list, insertCodeMethod, findOneCodeMethod, ret.findOneInterfaceMethod, updateCodeMethod, deleteCodeMethod)
+	list = append(list, insertCodeMethod, findOneCodeMethod, ret.findOneInterfaceMethod,
+		findManyCodeMethod, updateCodeMethod, deleteCodeMethod)
 	typesCode, err := genTypes(table, strings.Join(modelutil.TrimStringSlice(list), util.NL), withCache)
 	if err != nil {
 		return "", err
@@ -310,7 +339,11 @@ func (g *defaultGenerator) executeModel(code *code) (*bytes.Buffer, error) {
 	return output, nil
 }
 
-func wrapWithRawString(v string) string {
+func wrapWithRawString(v string, postgreSql bool) string {
+	if postgreSql {
+		return v
+	}
+
 	if v == "`" {
 		return v
 	}
@@ -1,6 +1,7 @@
 package gen
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/tal-tech/go-zero/core/collection"
@@ -31,7 +32,11 @@ func genInsert(table Table, withCache bool) (string, string, error) {
 			}
 		}
 
-		expressions = append(expressions, "?")
+		if table.PostgreSql {
+			expressions = append(expressions, fmt.Sprintf("$%d", len(expressions)+1))
+		} else {
+			expressions = append(expressions, "?")
+		}
 		expressionValues = append(expressionValues, "data."+camel)
 	}
 
@@ -46,6 +51,7 @@ func genInsert(table Table, withCache bool) (string, string, error) {
 		Execute(map[string]interface{}{
 			"withCache":             withCache,
 			"containsIndexCache":    table.ContainsUniqueCacheKey,
+			"postgreSql":            table.PostgreSql,
 			"upperStartCamelObject": camel,
 			"lowerStartCamelObject": stringx.From(camel).Untitle(),
 			"expression":            strings.Join(expressions, ", "),
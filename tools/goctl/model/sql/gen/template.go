@@ -18,6 +18,8 @@ const (
 	findOneByFieldTemplateFile            = "find-one-by-field.tpl"
 	findOneByFieldMethodTemplateFile      = "interface-find-one-by-field.tpl"
 	findOneByFieldExtraMethodTemplateFile = "find-one-by-field-extra-method.tpl"
+	findManyByFieldTemplateFile           = "find-many-by-field.tpl"
+	findManyByFieldMethodTemplateFile     = "interface-find-many-by-field.tpl"
 	importsTemplateFile                   = "import.tpl"
 	importsWithNoCacheTemplateFile        = "import-no-cache.tpl"
 	insertTemplateFile                    = "insert.tpl"
@@ -41,6 +43,8 @@ var templates = map[string]string{
 	findOneByFieldTemplateFile:            template.FindOneByField,
 	findOneByFieldMethodTemplateFile:      template.FindOneByFieldMethod,
 	findOneByFieldExtraMethodTemplateFile: template.FindOneByFieldExtraMethod,
+	findManyByFieldTemplateFile:           template.FindManyByField,
+	findManyByFieldMethodTemplateFile:     template.FindManyByFieldMethod,
 	importsTemplateFile:                   template.Imports,
 	importsWithNoCacheTemplateFile:        template.ImportsNoCache,
 	insertTemplateFile:                    template.Insert,
@@ -59,6 +63,11 @@ func Category() string {
 	return category
 }
 
+// BuiltinTemplates returns the builtin templates of the model category
+func BuiltinTemplates() map[string]string {
+	return templates
+}
+
 // Clean deletes all template files
 func Clean() error {
 	return util.Clean(category)
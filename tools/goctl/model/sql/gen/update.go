@@ -33,7 +33,12 @@ func genUpdate(table Table, withCache bool) (string, string, error) {
 		keyVariableSet.AddStr(key.KeyLeft)
 	}
 
-	expressionValues = append(expressionValues, "data."+table.PrimaryKey.Name.ToCamel())
+	if table.PostgreSql {
+		// the where clause takes $1, the set clause starts from $2
+		expressionValues = append([]string{"data." + table.PrimaryKey.Name.ToCamel()}, expressionValues...)
+	} else {
+		expressionValues = append(expressionValues, "data."+table.PrimaryKey.Name.ToCamel())
+	}
 	camelTableName := table.Name.ToCamel()
 	text, err := util.LoadTemplate(category, updateTemplateFile, template.Update)
 	if err != nil {
@@ -50,7 +55,8 @@ func genUpdate(table Table, withCache bool) (string, string, error) {
 			"primaryCacheKey":       table.PrimaryCacheKey.DataKeyExpression,
 			"primaryKeyVariable":    table.PrimaryCacheKey.KeyLeft,
 			"lowerStartCamelObject": stringx.From(camelTableName).Untitle(),
-			"originalPrimaryKey":    wrapWithRawString(table.PrimaryKey.Name.Source()),
+			"originalPrimaryKey":    wrapWithRawString(table.PrimaryKey.Name.Source(), table.PostgreSql),
+			"postgreSql":            table.PostgreSql,
 			"expressionValues":      strings.Join(expressionValues, ", "),
 		})
 	if err != nil {
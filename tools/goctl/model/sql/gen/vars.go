@@ -27,7 +27,8 @@ func genVars(table Table, withCache bool) (string, error) {
 		"upperStartCamelObject": camel,
 		"cacheKeys":             strings.Join(keys, "\n"),
 		"autoIncrement":         table.PrimaryKey.AutoIncrement,
-		"originalPrimaryKey":    wrapWithRawString(table.PrimaryKey.Name.Source()),
+		"originalPrimaryKey":    wrapWithRawString(table.PrimaryKey.Name.Source(), table.PostgreSql),
+		"postgreSql":            table.PostgreSql,
 		"withCache":             withCache,
 	})
 	if err != nil {
@@ -39,6 +39,13 @@ type (
 		SeqInIndex int    `db:"SEQ_IN_INDEX"`
 	}
 
+	// DbForeignKey describes a foreign key of a column
+	DbForeignKey struct {
+		ColumnName           string `db:"COLUMN_NAME"`
+		ReferencedTableName  string `db:"REFERENCED_TABLE_NAME"`
+		ReferencedColumnName string `db:"REFERENCED_COLUMN_NAME"`
+	}
+
 	// ColumnData describes the columns of table
 	ColumnData struct {
 		Db      string
@@ -55,6 +62,7 @@ type (
 		UniqueIndex map[string][]*Column
 		PrimaryKey  *Column
 		NormalIndex map[string][]*Column
+		ForeignKeys []*DbForeignKey
 	}
 
 	// IndexType describes an alias of string
@@ -140,6 +148,18 @@ func (m *InformationSchemaModel) FindIndex(db, table, column string) ([]*DbIndex
 	return reply, nil
 }
 
+// FindForeignKeys finds the foreign keys of the given table
+func (m *InformationSchemaModel) FindForeignKeys(db, table string) ([]*DbForeignKey, error) {
+	querySql := `select COLUMN_NAME, REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME from KEY_COLUMN_USAGE where TABLE_SCHEMA = ? and TABLE_NAME = ? and REFERENCED_TABLE_NAME is not null`
+	var reply []*DbForeignKey
+	err := m.conn.QueryRowsPartial(&reply, querySql, db, table)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
 // Convert converts column data into Table
 func (c *ColumnData) Convert() (*Table, error) {
 	var table Table
@@ -0,0 +1,181 @@
+package model
+
+import (
+	"sort"
+
+	"github.com/tal-tech/go-zero/core/stores/sqlx"
+)
+
+type (
+	// PostgreSqlModel gets table information from postgresql catalogs
+	PostgreSqlModel struct {
+		conn sqlx.SqlConn
+	}
+
+	// the scan targets use lowercase db tags, postgresql folds
+	// unquoted column aliases to lowercase.
+	pgColumn struct {
+		Name            string      `db:"column_name"`
+		DataType        string      `db:"data_type"`
+		Extra           string      `db:"extra"`
+		Comment         string      `db:"column_comment"`
+		ColumnDefault   interface{} `db:"column_default"`
+		IsNullAble      string      `db:"is_nullable"`
+		OrdinalPosition int         `db:"ordinal_position"`
+	}
+
+	pgForeignKey struct {
+		ColumnName           string `db:"column_name"`
+		ReferencedTableName  string `db:"referenced_table_name"`
+		ReferencedColumnName string `db:"referenced_column_name"`
+	}
+
+	pgIndex struct {
+		IndexName  string `db:"index_name"`
+		NonUnique  int    `db:"non_unique"`
+		SeqInIndex int    `db:"seq_in_index"`
+	}
+)
+
+// NewPostgreSqlModel creates an instance for PostgreSqlModel
+func NewPostgreSqlModel(conn sqlx.SqlConn) *PostgreSqlModel {
+	return &PostgreSqlModel{conn: conn}
+}
+
+// GetAllTables selects all tables from the given schema
+func (m *PostgreSqlModel) GetAllTables(schema string) ([]string, error) {
+	query := `select table_name from information_schema.tables where table_schema = $1 and table_type = 'BASE TABLE'`
+	var tables []string
+	err := m.conn.QueryRows(&tables, query, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// FindColumns return columns in the given schema and table
+func (m *PostgreSqlModel) FindColumns(schema, table string) (*ColumnData, error) {
+	querySql := `select c.column_name,
+	c.data_type,
+	case when c.column_default like 'nextval(%' then 'auto_increment' else '' end as extra,
+	coalesce(d.description, '') as column_comment,
+	c.column_default,
+	c.is_nullable,
+	c.ordinal_position
+from information_schema.columns c
+left join pg_catalog.pg_statio_all_tables st
+	on st.schemaname = c.table_schema and st.relname = c.table_name
+left join pg_catalog.pg_description d
+	on d.objoid = st.relid and d.objsubid = c.ordinal_position
+where c.table_schema = $1 and c.table_name = $2
+order by c.ordinal_position`
+	var reply []*pgColumn
+	err := m.conn.QueryRowsPartial(&reply, querySql, schema, table)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*Column
+	for _, item := range reply {
+		column := &DbColumn{
+			Name:            item.Name,
+			DataType:        item.DataType,
+			Extra:           item.Extra,
+			Comment:         item.Comment,
+			ColumnDefault:   item.ColumnDefault,
+			IsNullAble:      item.IsNullAble,
+			OrdinalPosition: item.OrdinalPosition,
+		}
+
+		index, err := m.findIndex(schema, table, item.Name)
+		if err != nil {
+			if err != sqlx.ErrNotFound {
+				return nil, err
+			}
+			continue
+		}
+
+		if len(index) > 0 {
+			for _, i := range index {
+				list = append(list, &Column{
+					DbColumn: column,
+					Index:    i,
+				})
+			}
+		} else {
+			list = append(list, &Column{
+				DbColumn: column,
+			})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].OrdinalPosition < list[j].OrdinalPosition
+	})
+
+	var columnData ColumnData
+	columnData.Db = schema
+	columnData.Table = table
+	columnData.Columns = list
+	return &columnData, nil
+}
+
+// findIndex finds indexes with given schema, table and column,
+// the primary key index is reported as PRIMARY like mysql does.
+func (m *PostgreSqlModel) findIndex(schema, table, column string) ([]*DbIndex, error) {
+	querySql := `select case when ix.indisprimary then 'PRIMARY' else i.relname end as index_name,
+	case when ix.indisunique then 0 else 1 end as non_unique,
+	array_position(ix.indkey, a.attnum) + 1 as seq_in_index
+from pg_index ix
+join pg_class t on t.oid = ix.indrelid
+join pg_class i on i.oid = ix.indexrelid
+join pg_namespace n on n.oid = t.relnamespace
+join pg_attribute a on a.attrelid = t.oid and a.attnum = any(ix.indkey)
+where n.nspname = $1 and t.relname = $2 and a.attname = $3`
+	var reply []*pgIndex
+	err := m.conn.QueryRowsPartial(&reply, querySql, schema, table, column)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*DbIndex, 0, len(reply))
+	for _, item := range reply {
+		list = append(list, &DbIndex{
+			IndexName:  item.IndexName,
+			NonUnique:  item.NonUnique,
+			SeqInIndex: item.SeqInIndex,
+		})
+	}
+
+	return list, nil
+}
+
+// FindForeignKeys finds the foreign keys of the given table
+func (m *PostgreSqlModel) FindForeignKeys(schema, table string) ([]*DbForeignKey, error) {
+	querySql := `select kcu.column_name,
+	ccu.table_name as referenced_table_name,
+	ccu.column_name as referenced_column_name
+from information_schema.table_constraints tc
+join information_schema.key_column_usage kcu
+	on kcu.constraint_name = tc.constraint_name and kcu.table_schema = tc.table_schema
+join information_schema.constraint_column_usage ccu
+	on ccu.constraint_name = tc.constraint_name and ccu.table_schema = tc.table_schema
+where tc.constraint_type = 'FOREIGN KEY' and tc.table_schema = $1 and tc.table_name = $2`
+	var reply []*pgForeignKey
+	err := m.conn.QueryRowsPartial(&reply, querySql, schema, table)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*DbForeignKey, 0, len(reply))
+	for _, item := range reply {
+		list = append(list, &DbForeignKey{
+			ColumnName:           item.ColumnName,
+			ReferencedTableName:  item.ReferencedTableName,
+			ReferencedColumnName: item.ReferencedColumnName,
+		})
+	}
+
+	return list, nil
+}
@@ -23,6 +23,14 @@ type (
 		UniqueIndex map[string][]*Field
 		NormalIndex map[string][]*Field
 		Fields      []*Field
+		ForeignKeys []*ForeignKey
+	}
+
+	// ForeignKey describes a foreign key of a table
+	ForeignKey struct {
+		Field           *Field
+		ReferencedTable stringx.String
+		ReferencedField stringx.String
 	}
 
 	// Primary describes a primary key
@@ -43,10 +51,17 @@ type (
 
 	// KeyType types alias of int
 	KeyType int
+
+	// convertFunc converts a database column type into a golang type
+	convertFunc func(dataBaseType string, isDefaultNull bool) (string, error)
 )
 
 // Parse parses ddl into golang structure
 func Parse(ddl string) (*Table, error) {
+	return parse(ddl, converter.ConvertDataType)
+}
+
+func parse(ddl string, convert convertFunc) (*Table, error) {
 	stmt, err := sqlparser.ParseStrictDDL(ddl)
 	if err != nil {
 		return nil, err
@@ -75,7 +90,7 @@ func Parse(ddl string) (*Table, error) {
 		return nil, err
 	}
 
-	primaryKey, fieldM, err := convertColumns(columns, primaryColumn)
+	primaryKey, fieldM, err := convertColumns(columns, primaryColumn, convert)
 	if err != nil {
 		return nil, err
 	}
@@ -149,7 +164,8 @@ func checkDuplicateUniqueIndex(uniqueIndex map[string][]*Field, tableName string
 	}
 }
 
-func convertColumns(columns []*sqlparser.ColumnDefinition, primaryColumn string) (Primary, map[string]*Field, error) {
+func convertColumns(columns []*sqlparser.ColumnDefinition, primaryColumn string,
+	convert convertFunc) (Primary, map[string]*Field, error) {
 	var (
 		primaryKey Primary
 		fieldM     = make(map[string]*Field)
@@ -176,7 +192,7 @@ func convertColumns(columns []*sqlparser.ColumnDefinition, primaryColumn string)
 			}
 		}
 
-		dataType, err := converter.ConvertDataType(column.Type.Type, isDefaultNull)
+		dataType, err := convert(column.Type.Type, isDefaultNull)
 		if err != nil {
 			return Primary{}, nil, err
 		}
@@ -265,8 +281,17 @@ func (t *Table) ContainsTime() bool {
 
 // ConvertDataType converts mysql data type into golang data type
 func ConvertDataType(table *model.Table) (*Table, error) {
+	return convertDataType(table, converter.ConvertDataType)
+}
+
+// ConvertPostgreSqlDataType converts postgresql data type into golang data type
+func ConvertPostgreSqlDataType(table *model.Table) (*Table, error) {
+	return convertDataType(table, converter.ConvertPostgreSqlDataType)
+}
+
+func convertDataType(table *model.Table, convert convertFunc) (*Table, error) {
 	isPrimaryDefaultNull := table.PrimaryKey.ColumnDefault == nil && table.PrimaryKey.IsNullAble == "YES"
-	primaryDataType, err := converter.ConvertDataType(table.PrimaryKey.DataType, isPrimaryDefaultNull)
+	primaryDataType, err := convert(table.PrimaryKey.DataType, isPrimaryDefaultNull)
 	if err != nil {
 		return nil, err
 	}
@@ -292,7 +317,7 @@ func ConvertDataType(table *model.Table) (*Table, error) {
 		AutoIncrement: strings.Contains(table.PrimaryKey.Extra, "auto_increment"),
 	}
 
-	fieldM, err := getTableFields(table)
+	fieldM, err := getTableFields(table, convert)
 	if err != nil {
 		return nil, err
 	}
@@ -304,6 +329,19 @@ func ConvertDataType(table *model.Table) (*Table, error) {
 		return reply.Fields[i].OrdinalPosition < reply.Fields[j].OrdinalPosition
 	})
 
+	for _, each := range table.ForeignKeys {
+		field, ok := fieldM[each.ColumnName]
+		if !ok {
+			continue
+		}
+
+		reply.ForeignKeys = append(reply.ForeignKeys, &ForeignKey{
+			Field:           field,
+			ReferencedTable: stringx.From(each.ReferencedTableName),
+			ReferencedField: stringx.From(each.ReferencedColumnName),
+		})
+	}
+
 	uniqueIndexSet := collection.NewSet()
 	log := console.NewColorConsole()
 	for indexName, each := range table.UniqueIndex {
@@ -365,11 +403,11 @@ func ConvertDataType(table *model.Table) (*Table, error) {
 	return &reply, nil
 }
 
-func getTableFields(table *model.Table) (map[string]*Field, error) {
+func getTableFields(table *model.Table, convert convertFunc) (map[string]*Field, error) {
 	fieldM := make(map[string]*Field)
 	for _, each := range table.Columns {
 		isDefaultNull := each.ColumnDefault == nil && each.IsNullAble == "YES"
-		dt, err := converter.ConvertDataType(each.DataType, isDefaultNull)
+		dt, err := convert(each.DataType, isDefaultNull)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,48 @@
+package parser
+
+import (
+	"regexp"
+
+	"github.com/tal-tech/go-zero/tools/goctl/model/sql/converter"
+)
+
+// the ddl parser only speaks the mysql dialect, the postgresql specific
+// column types and default casts are rewritten before parsing, the
+// serial types keep their auto increment semantics.
+var postgreSqlReplacements = []struct {
+	pattern *regexp.Regexp
+	replace string
+}{
+	{regexp.MustCompile(`(?i)(\bbigserial\b|\bserial8\b)(\s+NOT\s+NULL)?`), "bigint NOT NULL AUTO_INCREMENT"},
+	{regexp.MustCompile(`(?i)(\bsmallserial\b|\bserial2\b)(\s+NOT\s+NULL)?`), "smallint NOT NULL AUTO_INCREMENT"},
+	{regexp.MustCompile(`(?i)(\bserial\b|\bserial4\b)(\s+NOT\s+NULL)?`), "int NOT NULL AUTO_INCREMENT"},
+	{regexp.MustCompile(`(?i)\bcharacter\s+varying\b`), "varchar"},
+	{regexp.MustCompile(`(?i)\bcharacter\b`), "char"},
+	{regexp.MustCompile(`(?i)\btimestamptz\b`), "timestamp"},
+	{regexp.MustCompile(`(?i)\btimestamp\s+with(?:out)?\s+time\s+zone\b`), "timestamp"},
+	{regexp.MustCompile(`(?i)\btimetz\b`), "time"},
+	{regexp.MustCompile(`(?i)\btime\s+with(?:out)?\s+time\s+zone\b`), "time"},
+	{regexp.MustCompile(`(?i)\bjsonb\b`), "json"},
+	{regexp.MustCompile(`(?i)\buuid\b`), "char(36)"},
+	{regexp.MustCompile(`(?i)\bbytea\b`), "text"},
+	{regexp.MustCompile(`(?i)\bdouble\s+precision\b|\bfloat8\b`), "double"},
+	{regexp.MustCompile(`(?i)\bnumeric\b`), "decimal"},
+	{regexp.MustCompile(`(?i)\breal\b|\bfloat4\b`), "float"},
+	{regexp.MustCompile(`(?i)\bint2\b`), "smallint"},
+	{regexp.MustCompile(`(?i)\bint4\b`), "int"},
+	{regexp.MustCompile(`(?i)\bint8\b`), "bigint"},
+	// default value casts, like 'pending'::character varying
+	{regexp.MustCompile(`::[a-z_]+(\s+varying)?(\(\d+(,\s*\d+)?\))?`), ""},
+}
+
+// ParsePostgreSqlDDL parses postgresql ddl into golang structure
+func ParsePostgreSqlDDL(ddl string) (*Table, error) {
+	return parse(normalizePostgreSqlDDL(ddl), converter.ConvertPostgreSqlDataType)
+}
+
+func normalizePostgreSqlDDL(ddl string) string {
+	for _, each := range postgreSqlReplacements {
+		ddl = each.pattern.ReplaceAllString(ddl, each.replace)
+	}
+	return ddl
+}
@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePostgreSqlCreateTable(t *testing.T) {
+	table, err := ParsePostgreSqlDDL("CREATE TABLE test_user (\n" +
+		"  id bigserial NOT NULL,\n" +
+		"  mobile varchar(255) NOT NULL,\n" +
+		"  nickname character varying(64) NOT NULL,\n" +
+		"  balance numeric(10, 2) NOT NULL,\n" +
+		"  info jsonb NOT NULL,\n" +
+		"  create_time timestamp with time zone NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+		"  update_time timestamptz NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+		"  PRIMARY KEY (id),\n" +
+		"  UNIQUE KEY mobile_unique (mobile)\n" +
+		");")
+	assert.Nil(t, err)
+	assert.Equal(t, "test_user", table.Name.Source())
+	assert.Equal(t, "id", table.PrimaryKey.Name.Source())
+	assert.True(t, table.PrimaryKey.AutoIncrement)
+	assert.True(t, table.ContainsTime())
+
+	dataTypes := make(map[string]string)
+	for _, field := range table.Fields {
+		dataTypes[field.Name.Source()] = field.DataType
+	}
+	assert.Equal(t, "int64", dataTypes["id"])
+	assert.Equal(t, "string", dataTypes["mobile"])
+	assert.Equal(t, "string", dataTypes["nickname"])
+	assert.Equal(t, "float64", dataTypes["balance"])
+	assert.Equal(t, "string", dataTypes["info"])
+	assert.Equal(t, "time.Time", dataTypes["create_time"])
+	assert.Equal(t, "time.Time", dataTypes["update_time"])
+
+	_, ok := table.UniqueIndex["mobile_unique"]
+	assert.True(t, ok)
+}
+
+func TestNormalizePostgreSqlDDL(t *testing.T) {
+	normalized := normalizePostgreSqlDDL(
+		"id serial NOT NULL, flag boolean default 'f'::boolean, tag uuid, price double precision")
+	assert.Equal(t, "id int NOT NULL AUTO_INCREMENT, flag boolean default 'f', tag char(36), price double", normalized)
+}
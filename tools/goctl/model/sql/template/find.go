@@ -6,7 +6,7 @@ func (m *default{{.upperStartCamelObject}}Model) FindOne({{.lowerStartCamelPrima
 	{{if .withCache}}{{.cacheKey}}
 	var resp {{.upperStartCamelObject}}
 	err := m.QueryRow(&resp, {{.cacheKeyVariable}}, func(conn sqlx.SqlConn, v interface{}) error {
-		query :=  fmt.Sprintf("select %s from %s where {{.originalPrimaryKey}} = ? limit 1", {{.lowerStartCamelObject}}Rows, m.table)
+		query :=  fmt.Sprintf("select %s from %s where {{.originalPrimaryKey}} = {{if .postgreSql}}$1{{else}}?{{end}} limit 1", {{.lowerStartCamelObject}}Rows, m.table)
 		return conn.QueryRow(v, query, {{.lowerStartCamelPrimaryKey}})
 	})
 	switch err {
@@ -16,7 +16,7 @@ func (m *default{{.upperStartCamelObject}}Model) FindOne({{.lowerStartCamelPrima
 		return nil, ErrNotFound
 	default:
 		return nil, err
-	}{{else}}query := fmt.Sprintf("select %s from %s where {{.originalPrimaryKey}} = ? limit 1", {{.lowerStartCamelObject}}Rows, m.table)
+	}{{else}}query := fmt.Sprintf("select %s from %s where {{.originalPrimaryKey}} = {{if .postgreSql}}$1{{else}}?{{end}} limit 1", {{.lowerStartCamelObject}}Rows, m.table)
 	var resp {{.upperStartCamelObject}}
 	err := m.conn.QueryRow(&resp, query, {{.lowerStartCamelPrimaryKey}})
 	switch err {
@@ -71,7 +71,7 @@ func (m *default{{.upperStartCamelObject}}Model) formatPrimary(primary interface
 }
 
 func (m *default{{.upperStartCamelObject}}Model) queryPrimary(conn sqlx.SqlConn, v, primary interface{}) error {
-	query := fmt.Sprintf("select %s from %s where {{.originalPrimaryField}} = ? limit 1", {{.lowerStartCamelObject}}Rows, m.table )
+	query := fmt.Sprintf("select %s from %s where {{.originalPrimaryField}} = {{if .postgreSql}}$1{{else}}?{{end}} limit 1", {{.lowerStartCamelObject}}Rows, m.table )
 	return conn.QueryRow(v, query, primary)
 }
 `
@@ -81,3 +81,20 @@ var FindOneMethod = `FindOne({{.lowerStartCamelPrimaryKey}} {{.dataType}}) (*{{.
 
 // FindOneByFieldMethod defines find row by field method.
 var FindOneByFieldMethod = `FindOneBy{{.upperField}}({{.in}}) (*{{.upperStartCamelObject}}, error) `
+
+// FindManyByField defines find rows by a foreign key field.
+var FindManyByField = `
+func (m *default{{.upperStartCamelObject}}Model) FindBy{{.upperField}}({{.lowerField}} {{.dataType}}) ([]{{.upperStartCamelObject}}, error) {
+	query := fmt.Sprintf("select %s from %s where {{.originalField}} = {{if .postgreSql}}$1{{else}}?{{end}}", {{.lowerStartCamelObject}}Rows, m.table)
+	var resp []{{.upperStartCamelObject}}
+	{{if .withCache}}err := m.QueryRowsNoCache(&resp, query, {{.lowerField}}){{else}}err := m.conn.QueryRows(&resp, query, {{.lowerField}}){{end}}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+`
+
+// FindManyByFieldMethod defines an interface method to find rows by a foreign key field.
+var FindManyByFieldMethod = `FindBy{{.upperField}}({{.lowerField}} {{.dataType}}) ([]{{.upperStartCamelObject}}, error)`
@@ -3,17 +3,15 @@ package main
 import (
 	"fmt"
 
-	"github.com/tal-tech/go-zero/tools/goctl/plugin"
+	"github.com/tal-tech/go-zero/tools/goctl/plugin/sdk"
 )
 
 func main() {
-	plugin, err := plugin.NewPlugin()
-	if err != nil {
-		panic(err)
-	}
-
-	if plugin.Api != nil {
-		fmt.Printf("api: %+v \n", plugin.Api)
-	}
-	fmt.Println("Enjoy anything you want.")
+	sdk.Run(func(ctx *sdk.Context) error {
+		if ctx.Api() != nil {
+			fmt.Printf("api: %+v \n", ctx.Api())
+		}
+		fmt.Println("Enjoy anything you want.")
+		return nil
+	})
 }
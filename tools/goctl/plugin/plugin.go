@@ -22,12 +22,17 @@ import (
 
 const pluginArg = "_plugin"
 
+// Version is the version of the transfer data handed over to plugins,
+// it only changes when the layout of Plugin changes incompatibly.
+const Version = "v1"
+
 // Plugin defines an api plugin
 type Plugin struct {
 	Api         *spec.ApiSpec
 	ApiFilePath string
 	Style       string
 	Dir         string
+	Version     string
 }
 
 // PluginCommand is the entry of goctl api plugin
@@ -95,6 +100,7 @@ func prepareArgs(c *cli.Context) ([]byte, error) {
 
 	transferData.Dir = dirAbs
 	transferData.Style = c.String("style")
+	transferData.Version = Version
 	data, err := json.Marshal(transferData)
 	if err != nil {
 		return nil, err
@@ -169,6 +175,7 @@ func NewPlugin() (*Plugin, error) {
 		ApiFilePath string
 		Style       string
 		Dir         string
+		Version     string
 	}
 	err = json.Unmarshal(content, &info)
 	if err != nil {
@@ -178,6 +185,7 @@ func NewPlugin() (*Plugin, error) {
 	plugin.ApiFilePath = info.ApiFilePath
 	plugin.Style = info.Style
 	plugin.Dir = info.Dir
+	plugin.Version = info.Version
 	api, err := parser.Parse(info.ApiFilePath)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,111 @@
+// Package sdk is a stable toolkit for writing goctl plugins, it gives
+// typed access to the parsed api spec, template rendering helpers and
+// an output file api, so custom generators can be built without
+// depending on goctl internals.
+package sdk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tal-tech/go-zero/tools/goctl/api/spec"
+	"github.com/tal-tech/go-zero/tools/goctl/plugin"
+	"github.com/tal-tech/go-zero/tools/goctl/util"
+)
+
+// Version is the plugin transfer data version this sdk speaks,
+// NewContext refuses transfer data with another version.
+const Version = plugin.Version
+
+// Context carries the data goctl handed over to the plugin
+type Context struct {
+	plugin *plugin.Plugin
+}
+
+// NewContext reads the transfer data from stdin and validates its version
+func NewContext() (*Context, error) {
+	p, err := plugin.NewPlugin()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateVersion(p.Version); err != nil {
+		return nil, err
+	}
+
+	return &Context{plugin: p}, nil
+}
+
+// Api returns the parsed api spec, nil if goctl was not given an api file
+func (c *Context) Api() *spec.ApiSpec {
+	return c.plugin.Api
+}
+
+// ApiFilePath returns the absolute path of the api file
+func (c *Context) ApiFilePath() string {
+	return c.plugin.ApiFilePath
+}
+
+// Style returns the file naming style passed to goctl
+func (c *Context) Style() string {
+	return c.plugin.Style
+}
+
+// Dir returns the output directory passed to goctl
+func (c *Context) Dir() string {
+	return c.plugin.Dir
+}
+
+// SaveFile writes content into the file at path relative to the output
+// directory, missing parent directories are created.
+func (c *Context) SaveFile(relativePath, content string) error {
+	filename := filepath.Join(c.plugin.Dir, relativePath)
+	if err := util.MkdirIfNotExist(filepath.Dir(filename)); err != nil {
+		return err
+	}
+
+	fp, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+
+	_, err = fp.WriteString(content)
+	return err
+}
+
+// RenderTemplate renders the given template text with data,
+// the template syntax is the same as the goctl builtin templates
+func RenderTemplate(name, text string, data interface{}) (string, error) {
+	buffer, err := util.With(name).Parse(text).Execute(data)
+	if err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// Run wraps the main function of a plugin, it builds the context,
+// calls fn and reports errors on stderr with a non-zero exit code
+func Run(fn func(*Context) error) {
+	ctx, err := NewContext()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := fn(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func validateVersion(version string) error {
+	// goctl before the version field was introduced sends an empty version
+	if len(version) > 0 && version != Version {
+		return fmt.Errorf("incompatible plugin data version %q, sdk speaks %q", version, Version)
+	}
+
+	return nil
+}
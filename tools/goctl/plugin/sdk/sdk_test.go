@@ -0,0 +1,45 @@
+package sdk
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/tools/goctl/plugin"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	output, err := RenderTemplate("test", "hello {{.name}}", map[string]interface{}{
+		"name": "goctl",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "hello goctl", output)
+
+	_, err = RenderTemplate("test", "hello {{.name", nil)
+	assert.NotNil(t, err)
+}
+
+func TestSaveFile(t *testing.T) {
+	dir := t.TempDir()
+	ctx := &Context{plugin: &plugin.Plugin{Dir: dir}}
+	err := ctx.SaveFile(filepath.Join("client", "client.go"), "package client")
+	assert.Nil(t, err)
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "client", "client.go"))
+	assert.Nil(t, err)
+	assert.Equal(t, "package client", string(content))
+
+	// overwriting an existing file is allowed
+	err = ctx.SaveFile(filepath.Join("client", "client.go"), "package client2")
+	assert.Nil(t, err)
+	content, err = ioutil.ReadFile(filepath.Join(dir, "client", "client.go"))
+	assert.Nil(t, err)
+	assert.Equal(t, "package client2", string(content))
+}
+
+func TestValidateVersion(t *testing.T) {
+	assert.Nil(t, validateVersion(""))
+	assert.Nil(t, validateVersion(Version))
+	assert.NotNil(t, validateVersion("v0"))
+}
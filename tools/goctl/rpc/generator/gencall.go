@@ -63,48 +63,57 @@ func (m *default{{.serviceName}}) {{.method}}(ctx context.Context,in *{{.pbReque
 
 // GenCall generates the rpc client code, which is the entry point for the rpc service call.
 // It is a layer of encapsulation for the rpc client and shields the details in the pb.
+// Each service in the proto gets its own client package.
 func (g *DefaultGenerator) GenCall(ctx DirContext, proto parser.Proto, cfg *conf.Config) error {
-	dir := ctx.GetCall()
-	service := proto.Service
 	head := util.GetHead(proto.Name)
 
-	callFilename, err := format.FileNamingFormat(cfg.NamingFormat, service.Name)
-	if err != nil {
-		return err
+	var alias = collection.NewSet()
+	for _, item := range proto.Message {
+		alias.AddStr(fmt.Sprintf("%s = %s", parser.CamelCase(item.Name), fmt.Sprintf("%s.%s", proto.PbPackage, parser.CamelCase(item.Name))))
 	}
 
-	filename := filepath.Join(dir.Filename, fmt.Sprintf("%s.go", callFilename))
-	functions, err := g.genFunction(proto.PbPackage, service)
-	if err != nil {
-		return err
-	}
+	for _, service := range proto.Services {
+		dir := serviceCallDir(ctx, proto.GoPackage, service)
+		if err := util.MkdirIfNotExist(dir.Filename); err != nil {
+			return err
+		}
 
-	iFunctions, err := g.getInterfaceFuncs(service)
-	if err != nil {
-		return err
-	}
+		callFilename, err := format.FileNamingFormat(cfg.NamingFormat, service.Name)
+		if err != nil {
+			return err
+		}
 
-	text, err := util.LoadTemplate(category, callTemplateFile, callTemplateText)
-	if err != nil {
-		return err
-	}
+		filename := filepath.Join(dir.Filename, fmt.Sprintf("%s.go", callFilename))
+		functions, err := g.genFunction(proto.PbPackage, service)
+		if err != nil {
+			return err
+		}
 
-	var alias = collection.NewSet()
-	for _, item := range proto.Message {
-		alias.AddStr(fmt.Sprintf("%s = %s", parser.CamelCase(item.Name), fmt.Sprintf("%s.%s", proto.PbPackage, parser.CamelCase(item.Name))))
-	}
+		iFunctions, err := g.getInterfaceFuncs(service)
+		if err != nil {
+			return err
+		}
 
-	err = util.With("shared").GoFmt(true).Parse(text).SaveTo(map[string]interface{}{
-		"name":        callFilename,
-		"alias":       strings.Join(alias.KeysStr(), util.NL),
-		"head":        head,
-		"filePackage": dir.Base,
-		"package":     fmt.Sprintf(`"%s"`, ctx.GetPb().Package),
-		"serviceName": stringx.From(service.Name).ToCamel(),
-		"functions":   strings.Join(functions, util.NL),
-		"interface":   strings.Join(iFunctions, util.NL),
-	}, filename, true)
-	return err
+		text, err := util.LoadTemplate(category, callTemplateFile, callTemplateText)
+		if err != nil {
+			return err
+		}
+
+		err = util.With("shared").GoFmt(true).Parse(text).SaveTo(map[string]interface{}{
+			"name":        callFilename,
+			"alias":       strings.Join(alias.KeysStr(), util.NL),
+			"head":        head,
+			"filePackage": dir.Base,
+			"package":     fmt.Sprintf(`"%s"`, ctx.GetPb().Package),
+			"serviceName": stringx.From(service.Name).ToCamel(),
+			"functions":   strings.Join(functions, util.NL),
+			"interface":   strings.Join(iFunctions, util.NL),
+		}, filename, true)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (g *DefaultGenerator) genFunction(goPackage string, service parser.Service) ([]string, error) {
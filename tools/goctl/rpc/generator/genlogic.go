@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	logicTemplate = `package logic
+	logicTemplate = `package {{.packageName}}
 
 import (
 	"context"
@@ -49,34 +49,45 @@ func (l *{{.logicName}}) {{.method}} (in {{.request}}) ({{.response}}, error) {
 )
 
 // GenLogic generates the logic file of the rpc service, which corresponds to the RPC definition items in proto.
+// If the proto defines multiple services, each service gets its own logic sub directory.
 func (g *DefaultGenerator) GenLogic(ctx DirContext, proto parser.Proto, cfg *conf.Config) error {
-	dir := ctx.GetLogic()
-	for _, rpc := range proto.Service.RPC {
-		logicFilename, err := format.FileNamingFormat(cfg.NamingFormat, rpc.Name+"_logic")
-		if err != nil {
-			return err
+	for _, service := range proto.Services {
+		dir := ctx.GetLogic()
+		if proto.IsMultiServices() {
+			dir = childDir(dir, parser.GoSanitized(strings.ToLower(service.Name)))
+			if err := util.MkdirIfNotExist(dir.Filename); err != nil {
+				return err
+			}
 		}
 
-		filename := filepath.Join(dir.Filename, logicFilename+".go")
-		functions, err := g.genLogicFunction(proto.PbPackage, rpc)
-		if err != nil {
-			return err
-		}
+		for _, rpc := range service.RPC {
+			logicFilename, err := format.FileNamingFormat(cfg.NamingFormat, rpc.Name+"_logic")
+			if err != nil {
+				return err
+			}
 
-		imports := collection.NewSet()
-		imports.AddStr(fmt.Sprintf(`"%v"`, ctx.GetSvc().Package))
-		imports.AddStr(fmt.Sprintf(`"%v"`, ctx.GetPb().Package))
-		text, err := util.LoadTemplate(category, logicTemplateFileFile, logicTemplate)
-		if err != nil {
-			return err
-		}
-		err = util.With("logic").GoFmt(true).Parse(text).SaveTo(map[string]interface{}{
-			"logicName": fmt.Sprintf("%sLogic", stringx.From(rpc.Name).ToCamel()),
-			"functions": functions,
-			"imports":   strings.Join(imports.KeysStr(), util.NL),
-		}, filename, false)
-		if err != nil {
-			return err
+			filename := filepath.Join(dir.Filename, logicFilename+".go")
+			functions, err := g.genLogicFunction(proto.PbPackage, rpc)
+			if err != nil {
+				return err
+			}
+
+			imports := collection.NewSet()
+			imports.AddStr(fmt.Sprintf(`"%v"`, ctx.GetSvc().Package))
+			imports.AddStr(fmt.Sprintf(`"%v"`, ctx.GetPb().Package))
+			text, err := util.LoadTemplate(category, logicTemplateFileFile, logicTemplate)
+			if err != nil {
+				return err
+			}
+			err = util.With("logic").GoFmt(true).Parse(text).SaveTo(map[string]interface{}{
+				"packageName": dir.Base,
+				"logicName":   fmt.Sprintf("%sLogic", stringx.From(rpc.Name).ToCamel()),
+				"functions":   functions,
+				"imports":     strings.Join(imports.KeysStr(), util.NL),
+			}, filename, false)
+			if err != nil {
+				return err
+			}
 		}
 	}
 	return nil
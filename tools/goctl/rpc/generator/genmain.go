@@ -33,11 +33,10 @@ func main() {
 	var c config.Config
 	conf.MustLoad(*configFile, &c)
 	ctx := svc.NewServiceContext(c)
-	srv := server.New{{.serviceNew}}Server(ctx)
 
 	s := zrpc.MustNewServer(c.RpcServerConf, func(grpcServer *grpc.Server) {
-		{{.pkg}}.Register{{.service}}Server(grpcServer, srv)
-	})
+{{range .services}}		{{$.pkg}}.Register{{.Service}}Server(grpcServer, {{.ServerPkg}}.New{{.ServiceNew}}Server(ctx))
+{{end}}	})
 	defer s.Stop()
 
 	fmt.Printf("Starting rpc server at %s...\n", c.ListenOn)
@@ -45,6 +44,13 @@ func main() {
 }
 `
 
+// mainService describes the server registration of one service in the main file
+type mainService struct {
+	Service    string
+	ServiceNew string
+	ServerPkg  string
+}
+
 // GenMain generates the main file of the rpc service, which is an rpc service program call entry
 func (g *DefaultGenerator) GenMain(ctx DirContext, proto parser.Proto, cfg *conf.Config) error {
 	mainFilename, err := format.FileNamingFormat(cfg.NamingFormat, ctx.GetServiceName().Source())
@@ -56,9 +62,27 @@ func (g *DefaultGenerator) GenMain(ctx DirContext, proto parser.Proto, cfg *conf
 	imports := make([]string, 0)
 	pbImport := fmt.Sprintf(`"%v"`, ctx.GetPb().Package)
 	svcImport := fmt.Sprintf(`"%v"`, ctx.GetSvc().Package)
-	remoteImport := fmt.Sprintf(`"%v"`, ctx.GetServer().Package)
 	configImport := fmt.Sprintf(`"%v"`, ctx.GetConfig().Package)
-	imports = append(imports, configImport, pbImport, remoteImport, svcImport)
+	imports = append(imports, configImport, pbImport, svcImport)
+
+	services := make([]mainService, 0, len(proto.Services))
+	for _, service := range proto.Services {
+		serverPkg := ctx.GetServer().Base
+		serverImport := fmt.Sprintf(`"%v"`, ctx.GetServer().Package)
+		if proto.IsMultiServices() {
+			child := parser.GoSanitized(strings.ToLower(service.Name))
+			serverPkg = child + "server"
+			serverImport = fmt.Sprintf(`%s "%v"`, serverPkg, childDir(ctx.GetServer(), child).Package)
+		}
+
+		imports = append(imports, serverImport)
+		services = append(services, mainService{
+			Service:    parser.CamelCase(service.Name),
+			ServiceNew: stringx.From(service.Name).ToCamel(),
+			ServerPkg:  serverPkg,
+		})
+	}
+
 	text, err := util.LoadTemplate(category, mainTemplateFile, mainTemplate)
 	if err != nil {
 		return err
@@ -68,7 +92,6 @@ func (g *DefaultGenerator) GenMain(ctx DirContext, proto parser.Proto, cfg *conf
 		"serviceName": strings.ToLower(ctx.GetServiceName().ToCamel()),
 		"imports":     strings.Join(imports, util.NL),
 		"pkg":         proto.PbPackage,
-		"serviceNew":  stringx.From(proto.Service.Name).ToCamel(),
-		"service":     parser.CamelCase(proto.Service.Name),
+		"services":    services,
 	}, fileName, false)
 }
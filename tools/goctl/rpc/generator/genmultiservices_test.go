@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/tools/goctl/rpc/execx"
+	"github.com/tal-tech/go-zero/tools/goctl/rpc/parser"
+	"github.com/tal-tech/go-zero/tools/goctl/util/ctx"
+)
+
+func TestRpcGenerateMultiServices(t *testing.T) {
+	workDir := t.TempDir()
+	_, err := execx.Run("go mod init multitest", workDir)
+	if err != nil {
+		return
+	}
+
+	protoFile := filepath.Join(workDir, "multi.proto")
+	err = os.WriteFile(protoFile, []byte(`syntax = "proto3";
+package multi;
+option go_package = "pb";
+
+message Req{}
+message Reply{}
+
+service UserService{
+  rpc Login (Req)returns(Reply);
+}
+
+service OrderService{
+  rpc Create (Req)returns(Reply);
+  rpc Cancel (Req)returns(Reply);
+}
+`), 0644)
+	assert.Nil(t, err)
+
+	p := parser.NewDefaultProtoParser()
+	proto, err := p.Parse(protoFile)
+	assert.Nil(t, err)
+	assert.True(t, proto.IsMultiServices())
+
+	projectCtx, err := ctx.Prepare(workDir)
+	assert.Nil(t, err)
+
+	dirCtx, err := mkdir(projectCtx, proto)
+	assert.Nil(t, err)
+
+	g := NewDefaultGenerator()
+	assert.Nil(t, g.GenLogic(dirCtx, proto, cfg))
+	assert.Nil(t, g.GenServer(dirCtx, proto, cfg))
+	assert.Nil(t, g.GenMain(dirCtx, proto, cfg))
+	assert.Nil(t, g.GenCall(dirCtx, proto, cfg))
+
+	// each service gets its own logic, server and client directories
+	for _, f := range []string{
+		"internal/logic/userservice/loginlogic.go",
+		"internal/logic/orderservice/createlogic.go",
+		"internal/logic/orderservice/cancellogic.go",
+		"internal/server/userservice/userserviceserver.go",
+		"internal/server/orderservice/orderserviceserver.go",
+		"userservice/userservice.go",
+		"orderservice/orderservice.go",
+	} {
+		_, err = os.Stat(filepath.Join(workDir, f))
+		assert.Nil(t, err, f)
+	}
+
+	// the main file registers all servers
+	data, err := os.ReadFile(filepath.Join(workDir, "multi.go"))
+	assert.Nil(t, err)
+	mainCode := string(data)
+	assert.True(t, strings.Contains(mainCode,
+		"pb.RegisterUserServiceServer(grpcServer, userserviceserver.NewUserServiceServer(ctx))"))
+	assert.True(t, strings.Contains(mainCode,
+		"pb.RegisterOrderServiceServer(grpcServer, orderserviceserver.NewOrderServiceServer(ctx))"))
+
+	// the server delegates to the logic package of its own service
+	data, err = os.ReadFile(filepath.Join(workDir, "internal/server/userservice/userserviceserver.go"))
+	assert.Nil(t, err)
+	serverCode := string(data)
+	assert.True(t, strings.Contains(serverCode, "package userservice"))
+	assert.True(t, strings.Contains(serverCode, "userservicelogic.NewLoginLogic(ctx, s.svcCtx)"))
+}
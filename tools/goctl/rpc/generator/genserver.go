@@ -16,7 +16,7 @@ import (
 const (
 	serverTemplate = `{{.head}}
 
-package server
+package {{.packageName}}
 
 import (
 	"context"
@@ -39,50 +39,70 @@ func New{{.server}}Server(svcCtx *svc.ServiceContext) *{{.server}}Server {
 	functionTemplate = `
 {{if .hasComment}}{{.comment}}{{end}}
 func (s *{{.server}}Server) {{.method}} (ctx context.Context, in {{.request}}) ({{.response}}, error) {
-	l := logic.New{{.logicName}}(ctx,s.svcCtx)
+	l := {{.logicPkg}}.New{{.logicName}}(ctx,s.svcCtx)
 	return l.{{.method}}(in)
 }
 `
 )
 
-// GenServer generates rpc server file, which is an implementation of rpc server
+// GenServer generates rpc server file, which is an implementation of rpc server.
+// If the proto defines multiple services, each service gets its own server sub directory.
 func (g *DefaultGenerator) GenServer(ctx DirContext, proto parser.Proto, cfg *conf.Config) error {
-	dir := ctx.GetServer()
-	logicImport := fmt.Sprintf(`"%v"`, ctx.GetLogic().Package)
-	svcImport := fmt.Sprintf(`"%v"`, ctx.GetSvc().Package)
-	pbImport := fmt.Sprintf(`"%v"`, ctx.GetPb().Package)
+	head := util.GetHead(proto.Name)
+	for _, service := range proto.Services {
+		dir := ctx.GetServer()
+		logicDir := ctx.GetLogic()
+		logicPkg := logicDir.Base
+		logicImport := fmt.Sprintf(`"%v"`, logicDir.Package)
+		if proto.IsMultiServices() {
+			child := parser.GoSanitized(strings.ToLower(service.Name))
+			dir = childDir(dir, child)
+			if err := util.MkdirIfNotExist(dir.Filename); err != nil {
+				return err
+			}
+
+			logicDir = childDir(logicDir, child)
+			logicPkg = child + "logic"
+			logicImport = fmt.Sprintf(`%s "%v"`, logicPkg, logicDir.Package)
+		}
 
-	imports := collection.NewSet()
-	imports.AddStr(logicImport, svcImport, pbImport)
+		svcImport := fmt.Sprintf(`"%v"`, ctx.GetSvc().Package)
+		pbImport := fmt.Sprintf(`"%v"`, ctx.GetPb().Package)
 
-	head := util.GetHead(proto.Name)
-	service := proto.Service
-	serverFilename, err := format.FileNamingFormat(cfg.NamingFormat, service.Name+"_server")
-	if err != nil {
-		return err
-	}
+		imports := collection.NewSet()
+		imports.AddStr(logicImport, svcImport, pbImport)
 
-	serverFile := filepath.Join(dir.Filename, serverFilename+".go")
-	funcList, err := g.genFunctions(proto.PbPackage, service)
-	if err != nil {
-		return err
-	}
+		serverFilename, err := format.FileNamingFormat(cfg.NamingFormat, service.Name+"_server")
+		if err != nil {
+			return err
+		}
 
-	text, err := util.LoadTemplate(category, serverTemplateFile, serverTemplate)
-	if err != nil {
-		return err
-	}
+		serverFile := filepath.Join(dir.Filename, serverFilename+".go")
+		funcList, err := g.genFunctions(proto.PbPackage, logicPkg, service)
+		if err != nil {
+			return err
+		}
+
+		text, err := util.LoadTemplate(category, serverTemplateFile, serverTemplate)
+		if err != nil {
+			return err
+		}
 
-	err = util.With("server").GoFmt(true).Parse(text).SaveTo(map[string]interface{}{
-		"head":    head,
-		"server":  stringx.From(service.Name).ToCamel(),
-		"imports": strings.Join(imports.KeysStr(), util.NL),
-		"funcs":   strings.Join(funcList, util.NL),
-	}, serverFile, true)
-	return err
+		err = util.With("server").GoFmt(true).Parse(text).SaveTo(map[string]interface{}{
+			"head":        head,
+			"packageName": dir.Base,
+			"server":      stringx.From(service.Name).ToCamel(),
+			"imports":     strings.Join(imports.KeysStr(), util.NL),
+			"funcs":       strings.Join(funcList, util.NL),
+		}, serverFile, true)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (g *DefaultGenerator) genFunctions(goPackage string, service parser.Service) ([]string, error) {
+func (g *DefaultGenerator) genFunctions(goPackage, logicPkg string, service parser.Service) ([]string, error) {
 	var functionList []string
 	for _, rpc := range service.RPC {
 		text, err := util.LoadTemplate(category, serverFuncTemplateFile, functionTemplate)
@@ -93,6 +113,7 @@ func (g *DefaultGenerator) genFunctions(goPackage string, service parser.Service
 		comment := parser.GetComment(rpc.Doc())
 		buffer, err := util.With("func").Parse(text).Execute(map[string]interface{}{
 			"server":     stringx.From(service.Name).ToCamel(),
+			"logicPkg":   logicPkg,
 			"logicName":  fmt.Sprintf("%sLogic", stringx.From(rpc.Name).ToCamel()),
 			"method":     parser.CamelCase(rpc.Name),
 			"request":    fmt.Sprintf("*%s.%s", goPackage, parser.CamelCase(rpc.RequestType)),
@@ -166,3 +166,23 @@ func (d *defaultDirContext) GetServiceName() stringx.String {
 func (d *Dir) Valid() bool {
 	return len(d.Filename) > 0 && len(d.Package) > 0
 }
+
+// childDir derives a child directory of the given directory, it keeps the
+// generated files of each service apart when the proto defines multiple services
+func childDir(d Dir, name string) Dir {
+	return Dir{
+		Filename: filepath.Join(d.Filename, name),
+		Package:  filepath.ToSlash(filepath.Join(d.Package, name)),
+		Base:     name,
+	}
+}
+
+// serviceCallDir returns the client directory of the given service,
+// which follows the same naming rule as the call directory in mkdir
+func serviceCallDir(ctx DirContext, goPackage string, service parser.Service) Dir {
+	name := strings.ToLower(stringx.From(service.Name).ToCamel())
+	if name == strings.ToLower(goPackage) {
+		name = strings.ToLower(stringx.From(service.Name + "_client").ToCamel())
+	}
+	return childDir(ctx.GetMain(), name)
+}
@@ -73,3 +73,8 @@ func Update() error {
 func Category() string {
 	return category
 }
+
+// BuiltinTemplates returns the builtin templates of the rpc category
+func BuiltinTemplates() map[string]string {
+	return templates
+}
@@ -39,6 +39,18 @@ func TestDefaultProtoParse(t *testing.T) {
 	}())
 }
 
+func TestDefaultProtoParseMultiService(t *testing.T) {
+	p := NewDefaultProtoParser()
+	data, err := p.Parse("./test_multi_service.proto")
+	assert.Nil(t, err)
+	assert.True(t, data.IsMultiServices())
+	assert.Equal(t, 2, len(data.Services))
+	assert.Equal(t, "UserService", data.Service.Name)
+	assert.Equal(t, "UserService", data.Services[0].Name)
+	assert.Equal(t, "OrderService", data.Services[1].Name)
+	assert.Equal(t, 2, len(data.Services[1].RPC))
+}
+
 func TestDefaultProtoParseCaseInvalidRequestType(t *testing.T) {
 	p := NewDefaultProtoParser()
 	_, err := p.Parse("./test_invalid_request.proto")
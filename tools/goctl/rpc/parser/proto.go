@@ -9,5 +9,14 @@ type Proto struct {
 	GoPackage string
 	Import    []Import
 	Message   []Message
-	Service   Service
+	// Service is the first service in the proto file,
+	// it keeps the single service case simple
+	Service Service
+	// Services contains all services in the proto file
+	Services []Service
+}
+
+// IsMultiServices returns true if the proto file defines more than one service
+func (p Proto) IsMultiServices() bool {
+	return len(p.Services) > 1
 }
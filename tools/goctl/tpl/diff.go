@@ -0,0 +1,257 @@
+package tpl
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/tal-tech/go-zero/tools/goctl/api/clientgen"
+	"github.com/tal-tech/go-zero/tools/goctl/api/gogen"
+	"github.com/tal-tech/go-zero/tools/goctl/docker"
+	"github.com/tal-tech/go-zero/tools/goctl/errorgen"
+	"github.com/tal-tech/go-zero/tools/goctl/gateway"
+	"github.com/tal-tech/go-zero/tools/goctl/kube"
+	mongogen "github.com/tal-tech/go-zero/tools/goctl/model/mongo/generate"
+	modelgen "github.com/tal-tech/go-zero/tools/goctl/model/sql/gen"
+	rpcgen "github.com/tal-tech/go-zero/tools/goctl/rpc/generator"
+	"github.com/tal-tech/go-zero/tools/goctl/util"
+	"github.com/tal-tech/go-zero/tools/goctl/vars"
+	"github.com/urfave/cli"
+)
+
+// builtinTemplates returns the builtin templates of all categories
+func builtinTemplates() map[string]map[string]string {
+	return map[string]map[string]string{
+		gogen.Category():     gogen.BuiltinTemplates(),
+		rpcgen.Category():    rpcgen.BuiltinTemplates(),
+		modelgen.Category():  modelgen.BuiltinTemplates(),
+		mongogen.Category():  mongogen.BuiltinTemplates(),
+		docker.Category():    docker.BuiltinTemplates(),
+		kube.Category():      kube.BuiltinTemplates(),
+		gateway.Category():   gateway.BuiltinTemplates(),
+		errorgen.Category():  errorgen.BuiltinTemplates(),
+		clientgen.Category(): clientgen.BuiltinTemplates(),
+	}
+}
+
+// DiffTemplates shows the differences between the user templates
+// and the templates built in current goctl
+func DiffTemplates(ctx *cli.Context) error {
+	category := ctx.String("category")
+	name := ctx.String("name")
+
+	count := 0
+	err := rangeCategories(category, func(category string, templates map[string]string) error {
+		dir, err := util.GetTemplateDir(category)
+		if err != nil {
+			return err
+		}
+
+		version, err := util.TemplateVersion(category)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range sortedFiles(templates) {
+			if len(name) > 0 && file != name {
+				continue
+			}
+
+			filename := filepath.Join(dir, file)
+			if !util.FileExists(filename) {
+				continue
+			}
+
+			content, err := ioutil.ReadFile(filename)
+			if err != nil {
+				return err
+			}
+
+			if string(content) == templates[file] {
+				continue
+			}
+
+			count++
+			printDiff(category, file, version, templates[file], string(content))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		fmt.Println(aurora.Green("templates are in sync with the builtin ones"))
+	}
+
+	return nil
+}
+
+// UpgradeTemplates upgrades the user templates to the templates built in current goctl,
+// it asks for a confirmation on each template that was edited by the user
+func UpgradeTemplates(ctx *cli.Context) error {
+	category := ctx.String("category")
+	reader := bufio.NewReader(os.Stdin)
+
+	return rangeCategories(category, func(category string, templates map[string]string) error {
+		dir, err := util.GetTemplateDir(category)
+		if err != nil {
+			return err
+		}
+
+		if err := util.MkdirIfNotExist(dir); err != nil {
+			return err
+		}
+
+		version, err := util.TemplateVersion(category)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range sortedFiles(templates) {
+			filename := filepath.Join(dir, file)
+			if util.FileExists(filename) {
+				content, err := ioutil.ReadFile(filename)
+				if err != nil {
+					return err
+				}
+
+				if string(content) == templates[file] {
+					continue
+				}
+
+				printDiff(category, file, version, templates[file], string(content))
+				fmt.Printf("overwrite %s with the goctl %s template? [y/N] ",
+					filepath.Join(category, file), vars.BuildVersion)
+				answer, err := reader.ReadString('\n')
+				if err != nil {
+					return err
+				}
+
+				if !strings.EqualFold(strings.TrimSpace(answer), "y") {
+					fmt.Printf("%s kept\n", filepath.Join(category, file))
+					continue
+				}
+			}
+
+			if err := util.CreateTemplate(category, file, templates[file]); err != nil {
+				return err
+			}
+
+			fmt.Printf("%s updated\n", filepath.Join(category, file))
+		}
+
+		return util.WriteTemplateVersion(category)
+	})
+}
+
+// rangeCategories calls fn for the given category, or for all categories when it is empty
+func rangeCategories(category string, fn func(category string, templates map[string]string) error) error {
+	builtin := builtinTemplates()
+	if len(category) > 0 {
+		templates, ok := builtin[category]
+		if !ok {
+			return fmt.Errorf("unexpected category: %s", category)
+		}
+
+		return fn(category, templates)
+	}
+
+	var categories []string
+	for each := range builtin {
+		categories = append(categories, each)
+	}
+	sort.Strings(categories)
+
+	for _, each := range categories {
+		if err := fn(each, builtin[each]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sortedFiles(templates map[string]string) []string {
+	var files []string
+	for file := range templates {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+func printDiff(category, file, version, builtin, user string) {
+	if len(version) == 0 {
+		version = "unknown"
+	}
+
+	fmt.Printf("--- %s (goctl %s)\n", filepath.Join(category, file), vars.BuildVersion)
+	fmt.Printf("+++ %s (initialized with goctl %s)\n", filepath.Join(category, file), version)
+	for _, line := range diffLines(splitLines(builtin), splitLines(user)) {
+		switch line[0] {
+		case '-':
+			fmt.Println(aurora.Red(line))
+		case '+':
+			fmt.Println(aurora.Green(line))
+		default:
+			fmt.Println(line)
+		}
+	}
+}
+
+func splitLines(content string) []string {
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
+
+// diffLines returns the line based differences between old and new,
+// removed lines are prefixed with -, added lines with +, common lines with a space
+func diffLines(old, new []string) []string {
+	// longest common subsequence table
+	lcs := make([][]int, len(old)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(new)+1)
+	}
+	for i := len(old) - 1; i >= 0; i-- {
+		for j := len(new) - 1; j >= 0; j-- {
+			if old[i] == new[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var result []string
+	var i, j int
+	for i < len(old) && j < len(new) {
+		switch {
+		case old[i] == new[j]:
+			result = append(result, " "+old[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, "-"+old[i])
+			i++
+		default:
+			result = append(result, "+"+new[j])
+			j++
+		}
+	}
+	for ; i < len(old); i++ {
+		result = append(result, "-"+old[i])
+	}
+	for ; j < len(new); j++ {
+		result = append(result, "+"+new[j])
+	}
+
+	return result
+}
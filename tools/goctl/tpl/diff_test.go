@@ -0,0 +1,29 @@
+package tpl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLinesEqual(t *testing.T) {
+	lines := diffLines([]string{"a", "b"}, []string{"a", "b"})
+	assert.Equal(t, []string{" a", " b"}, lines)
+}
+
+func TestDiffLinesChanged(t *testing.T) {
+	lines := diffLines([]string{"a", "b", "c"}, []string{"a", "x", "c", "d"})
+	assert.Equal(t, []string{" a", "-b", "+x", " c", "+d"}, lines)
+}
+
+func TestDiffLinesRemoved(t *testing.T) {
+	lines := diffLines([]string{"a", "b"}, nil)
+	assert.Equal(t, []string{"-a", "-b"}, lines)
+}
+
+func TestRangeCategoriesUnknown(t *testing.T) {
+	err := rangeCategories("unknown", func(string, map[string]string) error {
+		return nil
+	})
+	assert.NotNil(t, err)
+}
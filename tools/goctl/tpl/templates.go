@@ -5,8 +5,11 @@ import (
 
 	"github.com/logrusorgru/aurora"
 	"github.com/tal-tech/go-zero/core/errorx"
+	"github.com/tal-tech/go-zero/tools/goctl/api/clientgen"
 	"github.com/tal-tech/go-zero/tools/goctl/api/gogen"
 	"github.com/tal-tech/go-zero/tools/goctl/docker"
+	"github.com/tal-tech/go-zero/tools/goctl/errorgen"
+	"github.com/tal-tech/go-zero/tools/goctl/gateway"
 	"github.com/tal-tech/go-zero/tools/goctl/kube"
 	mongogen "github.com/tal-tech/go-zero/tools/goctl/model/mongo/generate"
 	modelgen "github.com/tal-tech/go-zero/tools/goctl/model/sql/gen"
@@ -35,9 +38,18 @@ func GenTemplates(ctx *cli.Context) error {
 		func() error {
 			return kube.GenTemplates(ctx)
 		},
+		func() error {
+			return gateway.GenTemplates(ctx)
+		},
+		func() error {
+			return errorgen.GenTemplates(ctx)
+		},
 		func() error {
 			return mongogen.Templates(ctx)
 		},
+		func() error {
+			return clientgen.GenTemplates(ctx)
+		},
 	); err != nil {
 		return err
 	}
@@ -71,9 +83,18 @@ func CleanTemplates(_ *cli.Context) error {
 		func() error {
 			return kube.Clean()
 		},
+		func() error {
+			return gateway.Clean()
+		},
+		func() error {
+			return errorgen.Clean()
+		},
 		func() error {
 			return mongogen.Clean()
 		},
+		func() error {
+			return clientgen.Clean()
+		},
 	)
 	if err != nil {
 		return err
@@ -99,12 +120,18 @@ func UpdateTemplates(ctx *cli.Context) (err error) {
 		return gogen.Update()
 	case kube.Category():
 		return kube.Update()
+	case gateway.Category():
+		return gateway.Update()
+	case errorgen.Category():
+		return errorgen.Update()
 	case rpcgen.Category():
 		return rpcgen.Update()
 	case modelgen.Category():
 		return modelgen.Update()
 	case mongogen.Category():
 		return mongogen.Update()
+	case clientgen.Category():
+		return clientgen.Update()
 	default:
 		err = fmt.Errorf("unexpected category: %s", category)
 		return
@@ -125,6 +152,10 @@ func RevertTemplates(ctx *cli.Context) (err error) {
 		return docker.RevertTemplate(filename)
 	case kube.Category():
 		return kube.RevertTemplate(filename)
+	case gateway.Category():
+		return gateway.RevertTemplate(filename)
+	case errorgen.Category():
+		return errorgen.RevertTemplate(filename)
 	case gogen.Category():
 		return gogen.RevertTemplate(filename)
 	case rpcgen.Category():
@@ -133,6 +164,8 @@ func RevertTemplates(ctx *cli.Context) (err error) {
 		return modelgen.RevertTemplate(filename)
 	case mongogen.Category():
 		return mongogen.RevertTemplate(filename)
+	case clientgen.Category():
+		return clientgen.RevertTemplate(filename)
 	default:
 		err = fmt.Errorf("unexpected category: %s", category)
 		return
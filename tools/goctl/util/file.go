@@ -9,12 +9,14 @@ import (
 	"strings"
 
 	"github.com/logrusorgru/aurora"
+	"github.com/tal-tech/go-zero/tools/goctl/vars"
 )
 
 // NL defines a new line
 const (
-	NL       = "\n"
-	goctlDir = ".goctl"
+	NL                  = "\n"
+	goctlDir            = ".goctl"
+	templateVersionFile = ".version"
 )
 
 // CreateIfNotExist creates a file if it is not exists
@@ -96,7 +98,47 @@ func InitTemplates(category string, templates map[string]string) error {
 		}
 	}
 
-	return nil
+	// keep the version of an earlier initialization, the files are not overwritten either
+	if FileExists(filepath.Join(dir, templateVersionFile)) {
+		return nil
+	}
+
+	return WriteTemplateVersion(category)
+}
+
+// TemplateVersion returns the goctl version the category templates were written with,
+// it returns an empty string if the templates have no version stamp
+func TemplateVersion(category string) (string, error) {
+	dir, err := GetTemplateDir(category)
+	if err != nil {
+		return "", err
+	}
+
+	file := filepath.Join(dir, templateVersionFile)
+	if !FileExists(file) {
+		return "", nil
+	}
+
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// WriteTemplateVersion stamps the category templates with the current goctl version
+func WriteTemplateVersion(category string) error {
+	dir, err := GetTemplateDir(category)
+	if err != nil {
+		return err
+	}
+
+	if err := MkdirIfNotExist(dir); err != nil {
+		return err
+	}
+
+	return createTemplate(filepath.Join(dir, templateVersionFile), vars.BuildVersion+NL, true)
 }
 
 // CreateTemplate writes template into file even it is exists
@@ -117,8 +159,21 @@ func Clean(category string) error {
 	return os.RemoveAll(dir)
 }
 
-// LoadTemplate gets template content by the specified file
+// LoadTemplate gets template content by the specified file,
+// a project local template in the .goctl dir takes precedence over the home one
 func LoadTemplate(category, file, builtin string) (string, error) {
+	if dir, ok := findProjectTemplateDir(category); ok {
+		local := filepath.Join(dir, file)
+		if FileExists(local) {
+			content, err := ioutil.ReadFile(local)
+			if err != nil {
+				return "", err
+			}
+
+			return string(content), nil
+		}
+	}
+
 	dir, err := GetTemplateDir(category)
 	if err != nil {
 		return "", err
@@ -137,6 +192,35 @@ func LoadTemplate(category, file, builtin string) (string, error) {
 	return string(content), nil
 }
 
+// findProjectTemplateDir looks up the .goctl/category dir from the working directory
+// up to the dir that contains go.mod, returns false if not found
+func findProjectTemplateDir(category string) (string, bool) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		templateDir := filepath.Join(dir, goctlDir, category)
+		if FileExists(templateDir) {
+			return templateDir, true
+		}
+
+		if FileExists(filepath.Join(dir, goModeIdentifier)) {
+			break
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+
+		dir = parent
+	}
+
+	return "", false
+}
+
 func createTemplate(file, content string, force bool) error {
 	if FileExists(file) && !force {
 		return nil
@@ -1,6 +1,8 @@
 package vars
 
 const (
+	// BuildVersion is the version of goctl
+	BuildVersion = "1.1.6"
 	// ProjectName the const value of zero
 	ProjectName = "zero"
 	// ProjectOpenSourceURL the github url of go-zero
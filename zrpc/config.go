@@ -2,6 +2,7 @@ package zrpc
 
 import (
 	"github.com/tal-tech/go-zero/core/discov"
+	"github.com/tal-tech/go-zero/core/load"
 	"github.com/tal-tech/go-zero/core/service"
 	"github.com/tal-tech/go-zero/core/stores/redis"
 )
@@ -16,8 +17,9 @@ type (
 		Redis         redis.RedisKeyConf `json:",optional"`
 		StrictControl bool               `json:",optional"`
 		// setting 0 means no timeout
-		Timeout      int64 `json:",default=2000"`
-		CpuThreshold int64 `json:",default=900,range=[0:1000]"`
+		Timeout      int64            `json:",default=2000"`
+		CpuThreshold int64            `json:",default=900,range=[0:1000]"`
+		Shedding     load.ShedderConf `json:",optional"`
 	}
 
 	// A RpcClientConf is a rpc client config.
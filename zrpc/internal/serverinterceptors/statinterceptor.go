@@ -8,6 +8,7 @@ import (
 	"github.com/tal-tech/go-zero/core/logx"
 	"github.com/tal-tech/go-zero/core/stat"
 	"github.com/tal-tech/go-zero/core/timex"
+	"github.com/tal-tech/go-zero/core/trace/tracespec"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/peer"
 )
@@ -25,8 +26,14 @@ func UnaryStatInterceptor(metrics *stat.Metrics) grpc.UnaryServerInterceptor {
 		startTime := timex.Now()
 		defer func() {
 			duration := timex.Since(startTime)
+			var traceId string
+			if span, ok := ctx.Value(tracespec.TracingKey).(tracespec.Trace); ok {
+				traceId = span.TraceId()
+			}
 			metrics.Add(stat.Task{
-				Duration: duration,
+				Duration:    duration,
+				Description: info.FullMethod,
+				TraceId:     traceId,
 			})
 			logDuration(ctx, info.FullMethod, req, duration)
 		}()
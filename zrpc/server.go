@@ -95,7 +95,8 @@ func (rs *RpcServer) Stop() {
 
 func setupInterceptors(server internal.Server, c RpcServerConf, metrics *stat.Metrics) error {
 	if c.CpuThreshold > 0 {
-		shedder := load.NewAdaptiveShedder(load.WithCpuThreshold(c.CpuThreshold))
+		shedder := load.NewAdaptiveShedder(append(c.Shedding.Options(),
+			load.WithCpuThreshold(c.CpuThreshold))...)
 		server.AddUnaryInterceptors(serverinterceptors.UnarySheddingInterceptor(shedder, metrics))
 	}
 